@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/noerr"
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/memory"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
@@ -23,6 +27,17 @@ type Config struct {
 	DBConnection    string
 	ServerPort      string
 	ShutdownTimeout time.Duration
+	MigrateOnStart  bool
+	// PurgeRetention is how long deleted subscriptions can be restored, zero
+	// keeps them forever.
+	PurgeRetention time.Duration
+	PurgeInterval  time.Duration
+	// MonthlyCostsInterval is how often the stored monthly costs are checked
+	// and repaired.
+	MonthlyCostsInterval time.Duration
+	// AdminToken is the bearer token of the analytics, exchange rates and
+	// catalog changes, they are closed when it is empty.
+	AdminToken string
 }
 
 func loadConfig() (*Config, error) {
@@ -30,6 +45,7 @@ func loadConfig() (*Config, error) {
 
 	cfg := &Config{
 		DBConnection:    os.Getenv("DB_CONNECTION"),
+		AdminToken:      os.Getenv("ADMIN_TOKEN"),
 		ServerPort:      getEnvOrDefault("SERVER_PORT", ":8080"),
 		ShutdownTimeout: 10 * time.Second,
 	}
@@ -38,6 +54,30 @@ func loadConfig() (*Config, error) {
 		return nil, errors.New("DB_CONNECTION environment variable is required")
 	}
 
+	migrateOnStart, err := strconv.ParseBool(getEnvOrDefault("MIGRATE_ON_START", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("MIGRATE_ON_START: %w", err)
+	}
+	cfg.MigrateOnStart = migrateOnStart
+
+	retentionDays, err := strconv.Atoi(getEnvOrDefault("PURGE_RETENTION_DAYS", "30"))
+	if err != nil || retentionDays < 0 {
+		return nil, fmt.Errorf("PURGE_RETENTION_DAYS must be a non-negative number of days")
+	}
+	cfg.PurgeRetention = time.Duration(retentionDays) * 24 * time.Hour
+
+	cfg.PurgeInterval, err = time.ParseDuration(getEnvOrDefault("PURGE_INTERVAL", "1h"))
+	if err != nil || cfg.PurgeInterval <= 0 {
+		return nil, fmt.Errorf("PURGE_INTERVAL must be a positive duration")
+	}
+
+	cfg.MonthlyCostsInterval, err = time.ParseDuration(
+		getEnvOrDefault("MONTHLY_COSTS_INTERVAL", "24h"),
+	)
+	if err != nil || cfg.MonthlyCostsInterval <= 0 {
+		return nil, fmt.Errorf("MONTHLY_COSTS_INTERVAL must be a positive duration")
+	}
+
 	return cfg, nil
 }
 
@@ -48,6 +88,53 @@ func getEnvOrDefault(key, defaultVal string) string {
 	return defaultVal
 }
 
+// storage is the backend picked by DB_CONNECTION.
+type storage struct {
+	provider     domain.ConnectionProvider
+	repositories domain.Repositories
+	ping         func(context.Context) error
+}
+
+// openStorage picks the backend by DB_CONNECTION, memory:// keeps everything
+// in process memory and anything else is a Postgres connection string whose
+// schema is migrated unless MIGRATE_ON_START is false.
+func openStorage(ctx context.Context, cfg *Config) (storage, error) {
+	if strings.HasPrefix(cfg.DBConnection, memory.Scheme) {
+		slog.Warn("Using in-memory storage, data is lost on restart")
+
+		return storage{
+			provider:     memory.NewProvider(),
+			repositories: memory.NewRepositories(),
+			ping: func(context.Context) error {
+				return nil
+			},
+		}, nil
+	}
+
+	pool := noerr.Must(pgxpool.New(ctx, cfg.DBConnection))
+	if cfg.MigrateOnStart {
+		if err := migrateOnStart(ctx, pool); err != nil {
+			pool.Close()
+			return storage{}, err
+		}
+		slog.Info("Schema migrations applied")
+	}
+
+	provider := database.NewPostgresProvider(pool)
+	ping := func(ctx context.Context) error {
+		return provider.Execute(ctx, func(ctx context.Context, c domain.Connection) error {
+			_, err := c.ExecContext(ctx, "SELECT 1")
+			return err
+		})
+	}
+
+	return storage{
+		provider:     provider,
+		repositories: repository.NewRepositories(),
+		ping:         ping,
+	}, nil
+}
+
 func setupLogger() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
@@ -72,14 +159,40 @@ func main() {
 	)
 	defer stop()
 
-	provider := database.NewPostgresProvider(
-		noerr.Must(pgxpool.New(ctx, cfg.DBConnection)),
-	)
-	defer provider.Close()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, cfg.DBConnection, os.Args[2:], os.Stdout); err != nil {
+			slog.Error("Migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	store, err := openStorage(ctx, cfg)
+	if err != nil {
+		slog.Error("Failed to open storage", "error", err)
+		os.Exit(1)
+	}
+	defer store.provider.Close()
+
+	subscriptionService := domain.NewSubscriptionService(store.provider, store.repositories)
+	if cfg.PurgeRetention > 0 {
+		go runPurgeJob(ctx, subscriptionService, cfg.PurgeRetention, cfg.PurgeInterval)
+	}
+	go runMonthlyCostsJob(ctx, subscriptionService, cfg.MonthlyCostsInterval)
+
+	if cfg.AdminToken == "" {
+		slog.Warn("ADMIN_TOKEN is not set, analytics, exchange rates and catalog changes are disabled")
+	}
 
-	subscriptionService := domain.NewSubscriptionService(provider, repository.NewSubscription())
 	server := httpadapter.NewServer(subscriptionService)
-	strictHandler := httpadapter.NewStrictHandler(server, nil)
+	strictHandler := httpadapter.NewStrictHandlerWithOptions(
+		server,
+		nil,
+		httpadapter.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  httpadapter.RequestErrorHandler,
+			ResponseErrorHandlerFunc: httpadapter.ResponseErrorHandler,
+		},
+	)
 
 	mux := http.NewServeMux()
 
@@ -87,10 +200,7 @@ func main() {
 		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 		defer cancel()
 
-		if err := provider.Execute(ctx, func(ctx context.Context, c domain.Connection) error {
-			_, err := c.ExecContext(ctx, "SELECT 1")
-			return err
-		}); err != nil {
+		if err := store.ping(ctx); err != nil {
 			slog.Error("Health check failed", "error", err)
 			http.Error(w, "unhealthy", http.StatusServiceUnavailable)
 			return
@@ -101,12 +211,15 @@ func main() {
 	})
 
 	handler := httpadapter.HandlerWithOptions(strictHandler, httpadapter.StdHTTPServerOptions{
-		BaseRouter: mux,
+		BaseRouter:       mux,
+		ErrorHandlerFunc: httpadapter.ParamErrorHandler,
 	})
 
 	httpServer := &http.Server{
-		Addr:           cfg.ServerPort,
-		Handler:        handler,
+		Addr: cfg.ServerPort,
+		Handler: httpadapter.RequestID(
+			httpadapter.Actor(httpadapter.Admin(cfg.AdminToken, handler)),
+		),
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		IdleTimeout:    60 * time.Second,
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/db/migrations"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/migrate"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrateUsage = "usage: server migrate up|down|status|to <version>"
+
+// runMigrate handles `server migrate ...` with the arguments after migrate.
+func runMigrate(ctx context.Context, dbConnection string, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	pool, err := pgxpool.New(ctx, dbConnection)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		return migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		return migrator.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", migrateUsage, err)
+		}
+		return migrator.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.ChecksumMismatch {
+				state += ", checksum mismatch"
+			}
+			fmt.Fprintf(out, "%03d %-40s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
+
+// migrateOnStart applies pending migrations before the server starts serving.
+func migrateOnStart(ctx context.Context, pool *pgxpool.Pool) error {
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Up(ctx)
+}
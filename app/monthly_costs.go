@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// runMonthlyCostsJob maintains the stored monthly costs, once at start and
+// then every interval until ctx is done. Every instance runs the job and one
+// of them does the work each interval, months that differed from the live
+// calculation are logged.
+func runMonthlyCostsJob(
+	ctx context.Context,
+	service *domain.SubscriptionService,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Half an interval leaves room for the ticks of instances to drift
+		// without one of them skipping its own turn.
+		now := time.Now()
+		mismatches, ran, err := service.MaintainMonthlyCosts(ctx, now, now.Add(-interval/2))
+		switch {
+		case err != nil:
+			slog.ErrorContext(ctx, "Maintenance of monthly costs failed", "error", err)
+		case !ran:
+			slog.DebugContext(ctx, "Monthly costs are maintained by another instance")
+		case len(mismatches) > 0:
+			first := mismatches[0]
+			slog.WarnContext(
+				ctx,
+				"Stored monthly costs differed from live costs and were repaired",
+				"count", len(mismatches),
+				"user_id", first.UserID,
+				"service_name", first.Name,
+				"month", first.Month.Format("01-2006"),
+				"currency", first.Currency,
+				"stored", first.Stored,
+				"live", first.Live,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
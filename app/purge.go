@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// runPurgeJob removes subscriptions deleted more than retention ago, once at
+// start and then every interval until ctx is done.
+func runPurgeJob(
+	ctx context.Context,
+	service *domain.SubscriptionService,
+	retention, interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := service.PurgeDeleted(ctx, time.Now().Add(-retention))
+		if err != nil {
+			slog.ErrorContext(ctx, "Purge of deleted subscriptions failed", "error", err)
+		} else if purged > 0 {
+			slog.InfoContext(ctx, "Purged deleted subscriptions", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
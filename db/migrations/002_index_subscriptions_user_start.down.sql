@@ -0,0 +1 @@
+DROP INDEX IF EXISTS subscriptions_user_start_id_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS subscriptions_user_start_id_idx
+    ON subscriptions (user_id, subs_start_date DESC, id DESC);
@@ -0,0 +1,45 @@
+-- Subscriptions of one user to one service must not share a day, end dates are
+-- inclusive and a NULL end date never ends.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Databases initialised before the migration runner may already have it.
+-- Earlier releases accepted back-dated overlaps, which are listed instead of
+-- being ended or dropped behind the owner's back.
+DO $$
+DECLARE
+    overlapping TEXT;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'subscriptions_no_overlap'
+    ) THEN
+        SELECT string_agg(pair, ', ')
+        INTO overlapping
+        FROM (
+            SELECT format('%s and %s', a.id, b.id) AS pair
+            FROM subscriptions a
+            JOIN subscriptions b
+                ON b.user_id = a.user_id
+               AND b.service_name = a.service_name
+               AND b.id > a.id
+               AND daterange(b.subs_start_date, b.subs_end_date, '[]')
+                   && daterange(a.subs_start_date, a.subs_end_date, '[]')
+            ORDER BY a.id, b.id
+            LIMIT 20
+        ) pairs;
+
+        IF overlapping IS NOT NULL THEN
+            RAISE EXCEPTION 'subscriptions overlap: %', overlapping
+                USING HINT = 'Set subs_end_date of one subscription of each pair '
+                    'before the start of the other or delete it, then migrate again. '
+                    'At most 20 pairs are listed.';
+        END IF;
+
+        ALTER TABLE subscriptions
+            ADD CONSTRAINT subscriptions_no_overlap EXCLUDE USING gist (
+                user_id WITH =,
+                service_name WITH =,
+                daterange(subs_start_date, subs_end_date, '[]') WITH &&
+            );
+    END IF;
+END
+$$;
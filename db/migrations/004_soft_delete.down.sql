@@ -0,0 +1,14 @@
+-- Deleted subscriptions would come back to life without the column, and could
+-- break the overlap constraint, so they are purged first.
+DROP INDEX IF EXISTS subscriptions_deleted_at_idx;
+DELETE FROM subscriptions WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_no_overlap;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_no_overlap EXCLUDE USING gist (
+        user_id WITH =,
+        service_name WITH =,
+        daterange(subs_start_date, subs_end_date, '[]') WITH &&
+    );
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS deleted_at;
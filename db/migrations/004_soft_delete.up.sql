@@ -0,0 +1,13 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Deleted subscriptions no longer block new ones for the same days.
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_no_overlap;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_no_overlap EXCLUDE USING gist (
+        user_id WITH =,
+        service_name WITH =,
+        daterange(subs_start_date, subs_end_date, '[]') WITH &&
+    ) WHERE (deleted_at IS NULL);
+
+CREATE INDEX IF NOT EXISTS subscriptions_deleted_at_idx
+    ON subscriptions (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS subscription_events;
+DROP FUNCTION IF EXISTS subscription_events_append_only();
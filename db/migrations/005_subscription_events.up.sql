@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS subscription_events (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    actor TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS subscription_events_subscription_idx
+    ON subscription_events (subscription_id, id DESC);
+CREATE INDEX IF NOT EXISTS subscription_events_user_idx
+    ON subscription_events (user_id, id DESC);
+
+-- The history is append-only, purged subscriptions keep theirs.
+CREATE OR REPLACE FUNCTION subscription_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'subscription_events is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscription_events_append_only
+    BEFORE UPDATE OR DELETE ON subscription_events
+    FOR EACH ROW EXECUTE FUNCTION subscription_events_append_only();
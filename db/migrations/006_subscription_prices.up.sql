@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS subscription_prices (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    -- The first day of the month the price is billed from.
+    effective_from DATE NOT NULL CHECK (effective_from = date_trunc('month', effective_from)),
+    month_cost INTEGER NOT NULL CHECK (month_cost >= 0),
+    PRIMARY KEY (subscription_id, effective_from)
+);
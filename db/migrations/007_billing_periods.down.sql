@@ -0,0 +1,5 @@
+COMMENT ON COLUMN subscriptions.month_cost IS NULL;
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_billing_period_check;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS billing_anchor;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS billing_period;
@@ -0,0 +1,17 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS billing_period TEXT NOT NULL DEFAULT 'monthly';
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS billing_anchor DATE;
+
+UPDATE subscriptions
+SET billing_anchor = date_trunc('month', subs_start_date)
+WHERE billing_anchor IS NULL;
+
+ALTER TABLE subscriptions ALTER COLUMN billing_anchor SET NOT NULL;
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_billing_period_check;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_billing_period_check
+    CHECK (billing_period IN ('weekly', 'monthly', 'quarterly', 'yearly'));
+
+COMMENT ON COLUMN subscriptions.month_cost IS 'Price of one billing period';
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+UPDATE subscription_prices SET month_cost = month_cost / 100;
+UPDATE subscriptions SET month_cost = month_cost / 100;
+
+COMMENT ON COLUMN subscriptions.month_cost IS 'Price of one billing period';
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_currency_check;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,24 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_currency_check;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_currency_check CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Prices used to be whole rubles, from now on they are minor units of the
+-- subscription currency.
+UPDATE subscriptions SET month_cost = month_cost * 100;
+UPDATE subscription_prices SET month_cost = month_cost * 100;
+
+COMMENT ON COLUMN subscriptions.month_cost IS 'Price of one billing period in minor units of currency';
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base_currency TEXT NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency TEXT NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    -- The first day of the month the rate is used from.
+    effective_from DATE NOT NULL CHECK (effective_from = date_trunc('month', effective_from)),
+    -- Units of quote_currency one unit of base_currency buys.
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base_currency, quote_currency, effective_from),
+    CHECK (base_currency <> quote_currency)
+);
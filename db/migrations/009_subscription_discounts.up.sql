@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS subscription_discounts (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('trial', 'percent', 'fixed')),
+    -- Percent off, minor units off per charge or 0 for trials.
+    value INTEGER NOT NULL,
+    -- First and last discounted months, both on the first day of the month.
+    start_month DATE NOT NULL CHECK (start_month = date_trunc('month', start_month)),
+    end_month DATE NOT NULL CHECK (end_month = date_trunc('month', end_month)),
+    PRIMARY KEY (subscription_id, start_month),
+    CHECK (end_month >= start_month),
+    CHECK (
+        (kind = 'trial' AND value = 0)
+        OR (kind = 'percent' AND value BETWEEN 1 AND 100)
+        OR (kind = 'fixed' AND value > 0)
+    ),
+    CONSTRAINT subscription_discounts_no_overlap EXCLUDE USING gist (
+        subscription_id WITH =,
+        daterange(start_month, end_month, '[]') WITH &&
+    )
+);
@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS services (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL CHECK (name <> ''),
+    category TEXT NOT NULL DEFAULT '',
+    -- Price of one billing period in minor units of currency, used for new
+    -- subscriptions that come without a price.
+    default_price INTEGER CHECK (default_price >= 0),
+    currency TEXT NOT NULL DEFAULT 'RUB' CHECK (currency ~ '^[A-Z]{3}$'),
+    billing_period TEXT NOT NULL DEFAULT 'monthly'
+        CHECK (billing_period IN ('weekly', 'monthly', 'quarterly', 'yearly'))
+);
+
+-- Every name and alias of a service under its key: trimmed, inner spaces
+-- collapsed and lower cased. The name itself is one of the rows, so no key
+-- belongs to two services.
+CREATE TABLE IF NOT EXISTS service_aliases (
+    alias_key TEXT PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    alias TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS service_aliases_service_id_idx ON service_aliases (service_id);
+
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS service_id UUID REFERENCES services (id);
+
+CREATE INDEX IF NOT EXISTS subscriptions_service_id_idx ON subscriptions (service_id);
+
+-- Seed the catalog with one service per key of the names in use, named by the
+-- most used spelling.
+CREATE TEMPORARY TABLE service_names ON COMMIT DROP AS
+SELECT
+    lower(regexp_replace(btrim(service_name), '\s+', ' ', 'g')) AS alias_key,
+    regexp_replace(btrim(service_name), '\s+', ' ', 'g') AS name,
+    count(*) AS uses
+FROM subscriptions
+GROUP BY 1, 2;
+
+INSERT INTO services (id, name)
+SELECT gen_random_uuid(), name
+FROM (
+    SELECT DISTINCT ON (alias_key) alias_key, name
+    FROM service_names
+    WHERE alias_key <> ''
+    ORDER BY alias_key, uses DESC, name
+) canonical;
+
+INSERT INTO service_aliases (alias_key, service_id, alias)
+SELECT lower(name), id, name FROM services
+ON CONFLICT (alias_key) DO NOTHING;
+
+-- Move the subscriptions under the name of their service, except live ones
+-- that would then overlap another live subscription of the same user. Those
+-- keep their spelling until the overlap is resolved and the service is saved
+-- again.
+UPDATE subscriptions s
+SET service_id = a.service_id, service_name = sv.name
+FROM service_aliases a
+JOIN services sv ON sv.id = a.service_id
+WHERE a.alias_key = lower(regexp_replace(btrim(s.service_name), '\s+', ' ', 'g'))
+    AND (
+        s.service_name = sv.name
+        OR s.deleted_at IS NOT NULL
+        OR NOT EXISTS (
+            SELECT 1 FROM subscriptions o
+            WHERE o.id <> s.id
+                AND o.user_id = s.user_id
+                AND o.deleted_at IS NULL
+                AND lower(regexp_replace(btrim(o.service_name), '\s+', ' ', 'g')) = a.alias_key
+                AND daterange(o.subs_start_date, o.subs_end_date, '[]')
+                    && daterange(s.subs_start_date, s.subs_end_date, '[]')
+        )
+    );
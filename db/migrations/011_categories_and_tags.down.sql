@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS subscription_tags;
+DROP TABLE IF EXISTS tags;
+
+DROP INDEX IF EXISTS subscriptions_user_category_idx;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS category;
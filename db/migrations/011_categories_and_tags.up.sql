@@ -0,0 +1,29 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS subscriptions_user_category_idx ON subscriptions (user_id, category);
+
+-- Subscriptions linked to the catalog start out in the category of their
+-- service.
+UPDATE subscriptions s
+SET category = sv.category
+FROM services sv
+WHERE sv.id = s.service_id AND s.category = '';
+
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL CHECK (name <> ''),
+    -- The name trimmed, inner spaces collapsed and lower cased, unique per
+    -- user.
+    name_key TEXT NOT NULL,
+    UNIQUE (user_id, name_key)
+);
+
+CREATE TABLE IF NOT EXISTS subscription_tags (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (subscription_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS subscription_tags_tag_id_idx ON subscription_tags (tag_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    period TEXT NOT NULL CHECK (period IN ('monthly', 'yearly')),
+    -- Limit of the spend in every period, in the minor units totals are
+    -- counted in.
+    amount_limit INTEGER NOT NULL CHECK (amount_limit > 0),
+    -- A budget counts the subscriptions of one category or one service, or
+    -- all subscriptions of the user when neither is set.
+    category TEXT,
+    service_name TEXT,
+    CHECK (category IS NULL OR service_name IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS budgets_user_id_idx ON budgets (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS subscriptions_live_end_idx;
+DROP INDEX IF EXISTS subscriptions_live_start_idx;
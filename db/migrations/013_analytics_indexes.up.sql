@@ -0,0 +1,7 @@
+-- Fleet-wide analytics read the live subscriptions of every user by the
+-- months they start and end in, not by user.
+CREATE INDEX IF NOT EXISTS subscriptions_live_start_idx
+    ON subscriptions (subs_start_date) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS subscriptions_live_end_idx
+    ON subscriptions (subs_end_date) WHERE deleted_at IS NULL;
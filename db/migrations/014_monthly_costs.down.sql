@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS monthly_costs_state;
+DROP TABLE IF EXISTS monthly_costs;
@@ -0,0 +1,19 @@
+-- Spend of every user on every service per calendar month, billed the same
+-- way as the live cost queries. The write paths keep the rows of the users
+-- they touch up to date, a background job rebuilds the whole table.
+CREATE TABLE IF NOT EXISTS monthly_costs (
+    user_id UUID NOT NULL,
+    service_name TEXT NOT NULL,
+    month DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    PRIMARY KEY (user_id, service_name, month)
+);
+
+CREATE INDEX IF NOT EXISTS monthly_costs_month_idx ON monthly_costs (month);
+
+-- The last month monthly_costs holds. There is no row until the first
+-- rebuild, costs are calculated live until then and past the horizon.
+CREATE TABLE IF NOT EXISTS monthly_costs_state (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    horizon DATE NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE budgets DROP CONSTRAINT IF EXISTS budgets_currency_check;
+ALTER TABLE budgets DROP COLUMN IF EXISTS currency;
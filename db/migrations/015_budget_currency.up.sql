@@ -0,0 +1,10 @@
+-- Budgets were counted in whatever minor units the totals added up to, the
+-- existing ones were meant in rubles.
+ALTER TABLE budgets
+    ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE budgets DROP CONSTRAINT IF EXISTS budgets_currency_check;
+ALTER TABLE budgets
+    ADD CONSTRAINT budgets_currency_check CHECK (currency ~ '^[A-Z]{3}$');
+
+COMMENT ON COLUMN budgets.amount_limit IS 'Limit of the spend in every period in minor units of currency';
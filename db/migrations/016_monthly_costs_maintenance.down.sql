@@ -0,0 +1,3 @@
+DELETE FROM monthly_costs_state WHERE horizon IS NULL;
+ALTER TABLE monthly_costs_state DROP COLUMN IF EXISTS maintained_at;
+ALTER TABLE monthly_costs_state ALTER COLUMN horizon SET NOT NULL;
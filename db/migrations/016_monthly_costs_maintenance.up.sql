@@ -0,0 +1,9 @@
+-- The state row is always there, a null horizon means nothing is stored yet.
+-- Writes lock it for share and maintenance for update, so the first build
+-- never misses a write committing next to it.
+ALTER TABLE monthly_costs_state ALTER COLUMN horizon DROP NOT NULL;
+ALTER TABLE monthly_costs_state
+    ADD COLUMN IF NOT EXISTS maintained_at TIMESTAMPTZ;
+
+INSERT INTO monthly_costs_state (singleton) VALUES (TRUE)
+ON CONFLICT (singleton) DO NOTHING;
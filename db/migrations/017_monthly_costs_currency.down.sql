@@ -0,0 +1,6 @@
+DELETE FROM monthly_costs;
+UPDATE monthly_costs_state SET horizon = NULL, maintained_at = NULL;
+
+ALTER TABLE monthly_costs DROP CONSTRAINT IF EXISTS monthly_costs_pkey;
+ALTER TABLE monthly_costs DROP COLUMN IF EXISTS currency;
+ALTER TABLE monthly_costs ADD PRIMARY KEY (user_id, service_name, month);
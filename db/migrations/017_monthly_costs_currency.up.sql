@@ -0,0 +1,9 @@
+-- Stored months are kept per currency, so fleet-wide figures can convert
+-- them instead of adding up amounts in different currencies. The rows are
+-- derived from the subscriptions, the next maintenance stores them again.
+DELETE FROM monthly_costs;
+UPDATE monthly_costs_state SET horizon = NULL, maintained_at = NULL;
+
+ALTER TABLE monthly_costs ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL;
+ALTER TABLE monthly_costs DROP CONSTRAINT IF EXISTS monthly_costs_pkey;
+ALTER TABLE monthly_costs ADD PRIMARY KEY (user_id, service_name, month, currency);
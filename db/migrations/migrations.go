@@ -0,0 +1,8 @@
+// Package migrations embeds the schema migrations. Every version has a
+// NNN_name.up.sql script and a NNN_name.down.sql script that reverts it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
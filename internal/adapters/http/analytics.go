@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) GetMonthlyRevenue(
+	ctx context.Context,
+	request GetMonthlyRevenueRequestObject,
+) (GetMonthlyRevenueResponseObject, error) {
+	start, end, err := parseMonthRange(request.Params.StartDate, request.Params.EndDate)
+	if err != nil {
+		return GetMonthlyRevenuedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	currency := reportCurrency(request.Params.Currency)
+	months, err := s.subscriptions.MonthlyRevenue(ctx, start, end, currency)
+	if err != nil {
+		return GetMonthlyRevenuedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	resp := RevenueResponse{
+		Currency: Currency(currency),
+		Months:   make([]MonthlyCost, 0, len(months)),
+	}
+	for _, month := range months {
+		resp.Months = append(resp.Months, toHTTPMonthlyCost(month))
+	}
+
+	return GetMonthlyRevenue200JSONResponse(resp), nil
+}
+
+func (s *Server) GetSubscriptionActivity(
+	ctx context.Context,
+	request GetSubscriptionActivityRequestObject,
+) (GetSubscriptionActivityResponseObject, error) {
+	start, end, err := parseMonthRange(request.Params.StartDate, request.Params.EndDate)
+	if err != nil {
+		return GetSubscriptionActivitydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	activity, err := s.subscriptions.SubscriptionActivity(ctx, start, end)
+	if err != nil {
+		return GetSubscriptionActivitydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	resp := ActivityResponse{Months: make([]MonthlyActivity, 0, len(activity))}
+	for _, month := range activity {
+		resp.Months = append(resp.Months, MonthlyActivity{
+			Month:     month.Month.Format("01-2006"),
+			Active:    month.Active,
+			New:       month.New,
+			Cancelled: month.Cancelled,
+		})
+	}
+
+	return GetSubscriptionActivity200JSONResponse(resp), nil
+}
+
+func (s *Server) GetTopServices(
+	ctx context.Context,
+	request GetTopServicesRequestObject,
+) (GetTopServicesResponseObject, error) {
+	start, end, err := parseMonthRange(request.Params.StartDate, request.Params.EndDate)
+	if err != nil {
+		return GetTopServicesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	limit := 10
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	currency := reportCurrency(request.Params.Currency)
+	services, err := s.subscriptions.TopServices(ctx, start, end, limit, currency)
+	if err != nil {
+		return GetTopServicesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	resp := TopServicesResponse{
+		Currency: Currency(currency),
+		Items:    make([]ServiceCost, 0, len(services)),
+	}
+	for _, service := range services {
+		resp.Items = append(resp.Items, ServiceCost{ServiceName: service.Name, Cost: service.Cost})
+	}
+
+	return GetTopServices200JSONResponse(resp), nil
+}
+
+// reportCurrency is the currency fleet-wide figures are converted to,
+// DefaultCurrency unless the request names one.
+func reportCurrency(currency *Currency) domain.Currency {
+	if currency == nil {
+		return domain.DefaultCurrency
+	}
+
+	return domain.Currency(*currency)
+}
+
+// parseMonthRange parses the start_date and the optional end_date query
+// parameters.
+func parseMonthRange(startDate string, endDate *string) (time.Time, *time.Time, error) {
+	start, err := parseMonth("start_date", startDate)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if endDate == nil {
+		return start, nil, nil
+	}
+
+	end, err := parseMonth("end_date", *endDate)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	return start, &end, nil
+}
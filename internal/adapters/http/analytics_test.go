@@ -0,0 +1,120 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalytics(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+
+	for _, subscription := range []struct {
+		name  string
+		price int
+		start string
+		end   string
+	}{
+		{"Music", 100, "01-2025", "02-2025"},
+		{"Video", 300, "02-2025", ""},
+		{"Music", 100, "02-2025", ""},
+	} {
+		end := ""
+		if subscription.end != "" {
+			end = `, "end_date": "` + subscription.end + `"`
+		}
+		response := serve(http.MethodPost, "/subscriptions", `{
+			"user_id": "`+uuid.New().String()+`",
+			"service_name": "`+subscription.name+`",
+			"price": `+strconv.Itoa(subscription.price)+`,
+			"start_date": "`+subscription.start+`"`+end+`
+		}`)
+		require.Equal(t, http.StatusCreated, response.Code, response.Body.String())
+	}
+
+	period := "start_date=01-2025&end_date=03-2025"
+
+	response := serve(http.MethodGet, "/analytics/revenue?"+period, "")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	var revenue httpadapter.RevenueResponse
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&revenue))
+	require.Equal(t, httpadapter.Currency("RUB"), revenue.Currency)
+	totals := make([]int, 0, len(revenue.Months))
+	for _, month := range revenue.Months {
+		totals = append(totals, month.TotalCost)
+	}
+	require.Equal(t, []int{100, 500, 400}, totals)
+
+	response = serve(http.MethodGet, "/analytics/activity?"+period, "")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	var activity httpadapter.ActivityResponse
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&activity))
+	require.Equal(t, []httpadapter.MonthlyActivity{
+		{Month: "01-2025", Active: 1, New: 1},
+		{Month: "02-2025", Active: 3, New: 2, Cancelled: 1},
+		{Month: "03-2025", Active: 2},
+	}, activity.Months)
+
+	response = serve(http.MethodGet, "/analytics/top-services?limit=1&"+period, "")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	var top httpadapter.TopServicesResponse
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&top))
+	require.Equal(t, []httpadapter.ServiceCost{{ServiceName: "Video", Cost: 600}}, top.Items)
+	require.Equal(t, httpadapter.Currency("RUB"), top.Currency)
+
+	// Nothing is billed in dollars and there is no rate to convert rubles.
+	response = serve(http.MethodGet, "/analytics/top-services?currency=USD&"+period, "")
+	require.Equal(t, http.StatusBadRequest, response.Code, response.Body.String())
+	require.Contains(t, response.Body.String(), "exchange_rate_missing")
+
+	response = serve(http.MethodGet, "/analytics/activity?start_date=2025-01", "")
+	require.Equal(t, http.StatusBadRequest, response.Code, response.Body.String())
+}
+
+func TestAnalyticsRequireTheAdminToken(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	get := func(token, authorization, url string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		httpadapter.Admin(token, client.handler).ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	const url = "/analytics/revenue?start_date=01-2025&end_date=03-2025"
+	for _, tt := range []struct {
+		name          string
+		token         string
+		authorization string
+	}{
+		{"no header", "secret", ""},
+		{"wrong token", "secret", "Bearer other"},
+		{"not a bearer token", "secret", "secret"},
+		{"no token configured", "", "Bearer "},
+	} {
+		response := get(tt.token, tt.authorization, url)
+		require.Equal(t, http.StatusUnauthorized, response.Code, tt.name)
+		require.Contains(t, response.Body.String(), "admin_token_required", tt.name)
+		require.NotEmpty(t, response.Header().Get("WWW-Authenticate"), tt.name)
+	}
+
+	response := get("secret", "Bearer secret", url)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	// Per-user endpoints stay open.
+	response = get("secret", "", "/subscriptions?user_id="+uuid.NewString())
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+}
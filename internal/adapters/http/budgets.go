@@ -0,0 +1,162 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) CreateBudget(
+	ctx context.Context,
+	request CreateBudgetRequestObject,
+) (CreateBudgetResponseObject, error) {
+	budget := toDomainBudget(BudgetRequest{
+		Period:      request.Body.Period,
+		Limit:       request.Body.Limit,
+		Currency:    request.Body.Currency,
+		Category:    request.Body.Category,
+		ServiceName: request.Body.ServiceName,
+	})
+	budget.ID = uuid.New()
+	budget.UserID = uuid.UUID(request.Body.UserId)
+
+	created, err := s.subscriptions.CreateBudget(ctx, budget)
+	if err != nil {
+		return CreateBudgetdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return CreateBudget201JSONResponse(toHTTPBudget(created)), nil
+}
+
+func (s *Server) GetBudget(
+	ctx context.Context,
+	request GetBudgetRequestObject,
+) (GetBudgetResponseObject, error) {
+	budget, err := s.subscriptions.ReadBudget(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return GetBudgetdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return GetBudget200JSONResponse(toHTTPBudget(budget)), nil
+}
+
+func (s *Server) ReplaceBudget(
+	ctx context.Context,
+	request ReplaceBudgetRequestObject,
+) (ReplaceBudgetResponseObject, error) {
+	budget := toDomainBudget(*request.Body)
+	budget.ID = uuid.UUID(request.Id)
+
+	updated, err := s.subscriptions.UpdateBudget(ctx, budget)
+	if err != nil {
+		return ReplaceBudgetdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ReplaceBudget200JSONResponse(toHTTPBudget(updated)), nil
+}
+
+func (s *Server) DeleteBudget(
+	ctx context.Context,
+	request DeleteBudgetRequestObject,
+) (DeleteBudgetResponseObject, error) {
+	if err := s.subscriptions.DeleteBudget(ctx, uuid.UUID(request.Id)); err != nil {
+		return DeleteBudgetdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return DeleteBudget200JSONResponse{Message: "Budget deleted successfully"}, nil
+}
+
+func (s *Server) ListBudgets(
+	ctx context.Context,
+	request ListBudgetsRequestObject,
+) (ListBudgetsResponseObject, error) {
+	budgets, err := s.subscriptions.ListBudgets(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return ListBudgetsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	items := make([]Budget, 0, len(budgets))
+	for _, budget := range budgets {
+		items = append(items, toHTTPBudget(budget))
+	}
+	return ListBudgets200JSONResponse{Items: items}, nil
+}
+
+func (s *Server) GetBudgetStatus(
+	ctx context.Context,
+	request GetBudgetStatusRequestObject,
+) (GetBudgetStatusResponseObject, error) {
+	at := time.Now()
+	if request.Params.Month != nil {
+		month, err := parseMonth("month", *request.Params.Month)
+		if err != nil {
+			return GetBudgetStatusdefaultApplicationProblemPlusJSONResponse(
+				toProblemResponse(ctx, err),
+			), nil
+		}
+		at = month
+	}
+
+	statuses, err := s.subscriptions.BudgetStatuses(ctx, uuid.UUID(request.Id), at)
+	if err != nil {
+		return GetBudgetStatusdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return GetBudgetStatus200JSONResponse{Items: toHTTPBudgetStatuses(statuses)}, nil
+}
+
+func toDomainBudget(req BudgetRequest) domain.Budget {
+	var currency domain.Currency
+	if req.Currency != nil {
+		currency = domain.Currency(*req.Currency)
+	}
+
+	return domain.Budget{
+		Period:      domain.BudgetPeriod(req.Period),
+		Limit:       req.Limit,
+		Currency:    currency,
+		Category:    req.Category,
+		ServiceName: req.ServiceName,
+	}
+}
+
+func toHTTPBudget(budget domain.Budget) Budget {
+	currency := Currency(budget.Currency)
+
+	return Budget{
+		Id:          budget.ID,
+		UserId:      budget.UserID,
+		Period:      BudgetPeriod(budget.Period),
+		Limit:       budget.Limit,
+		Currency:    &currency,
+		Category:    budget.Category,
+		ServiceName: budget.ServiceName,
+	}
+}
+
+func toHTTPBudgetStatuses(statuses []domain.BudgetStatus) []BudgetStatus {
+	items := make([]BudgetStatus, 0, len(statuses))
+	for _, status := range statuses {
+		items = append(items, BudgetStatus{
+			Budget:      toHTTPBudget(status.Budget),
+			PeriodStart: status.Start.Format("01-2006"),
+			PeriodEnd:   status.End.Format("01-2006"),
+			Spent:       status.Spent,
+			Projected:   status.Projected,
+			Remaining:   status.Remaining(),
+			OverBudget:  status.OverBudget(),
+		})
+	}
+
+	return items
+}
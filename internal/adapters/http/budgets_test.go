@@ -0,0 +1,65 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionOverBudgetWarns(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+
+	userID := uuid.New().String()
+	created := serve(http.MethodPost, "/budgets", `{
+		"user_id": "`+userID+`",
+		"period": "monthly",
+		"limit": 1000
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var budget httpadapter.Budget
+	require.NoError(t, json.NewDecoder(created.Body).Decode(&budget))
+
+	month := time.Now().Format("01-2006")
+	subscribe := func(name string) httpadapter.Subscription {
+		response := serve(http.MethodPost, "/subscriptions", `{
+			"user_id": "`+userID+`",
+			"service_name": "`+name+`",
+			"price": 600,
+			"start_date": "`+month+`"
+		}`)
+		require.Equal(t, http.StatusCreated, response.Code, response.Body.String())
+
+		var subscription httpadapter.Subscription
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&subscription))
+		return subscription
+	}
+
+	require.Nil(t, subscribe("Music").BudgetWarnings)
+
+	warned := subscribe("Video")
+	require.NotNil(t, warned.BudgetWarnings)
+	require.Len(t, *warned.BudgetWarnings, 1)
+	warning := (*warned.BudgetWarnings)[0]
+	require.Equal(t, budget, warning.Budget)
+	require.True(t, warning.OverBudget)
+	require.Equal(t, -200, warning.Remaining)
+
+	status := serve(http.MethodGet, "/users/"+userID+"/budgets/status?month="+month, "")
+	require.Equal(t, http.StatusOK, status.Code, status.Body.String())
+
+	var statuses httpadapter.BudgetStatusList
+	require.NoError(t, json.NewDecoder(status.Body).Decode(&statuses))
+	require.Equal(t, []httpadapter.BudgetStatus{warning}, statuses.Items)
+
+	invalid := serve(http.MethodGet, "/users/"+userID+"/budgets/status?month=2025-07", "")
+	require.Equal(t, http.StatusBadRequest, invalid.Code, invalid.Body.String())
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// cursorToken is the JSON payload behind the opaque page cursor.
+type cursorToken struct {
+	Sort      string    `json:"o"`
+	StartDate string    `json:"s"`
+	EndDate   *string   `json:"e,omitempty"`
+	Cost      int       `json:"p"`
+	Name      string    `json:"n"`
+	ID        uuid.UUID `json:"i"`
+}
+
+func encodeCursor(cursor domain.SubscriptionCursor) string {
+	token := cursorToken{
+		Sort:      formatSort(cursor.Sort),
+		StartDate: cursor.StartDate.Format(time.DateOnly),
+		Cost:      cursor.Cost,
+		Name:      cursor.Name,
+		ID:        cursor.ID,
+	}
+	if cursor.EndDate != nil {
+		endDate := cursor.EndDate.Format(time.DateOnly)
+		token.EndDate = &endDate
+	}
+
+	raw, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (domain.SubscriptionCursor, error) {
+	invalid := domain.NewValidationError("invalid_cursor", "cursor is malformed").
+		WithFields(domain.FieldError{Field: "cursor", Message: "use X-Next-Cursor of a previous page"})
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.SubscriptionCursor{}, invalid
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(raw, &token); err != nil {
+		return domain.SubscriptionCursor{}, invalid
+	}
+
+	sort, ok := parseSort(token.Sort)
+	if !ok {
+		return domain.SubscriptionCursor{}, invalid
+	}
+
+	startDate, err := time.Parse(time.DateOnly, token.StartDate)
+	if err != nil {
+		return domain.SubscriptionCursor{}, invalid
+	}
+
+	var endDate *time.Time
+	if token.EndDate != nil {
+		t, err := time.Parse(time.DateOnly, *token.EndDate)
+		if err != nil {
+			return domain.SubscriptionCursor{}, invalid
+		}
+		endDate = &t
+	}
+
+	return domain.SubscriptionCursor{
+		Sort:      sort,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Cost:      token.Cost,
+		Name:      token.Name,
+		ID:        token.ID,
+	}, nil
+}
+
+func parseSort(value string) (domain.SubscriptionSort, bool) {
+	var sort domain.SubscriptionSort
+	if len(value) > 0 && value[0] == '-' {
+		sort.Descending = true
+		value = value[1:]
+	}
+
+	sort.Field = domain.SubscriptionSortField(value)
+	switch sort.Field {
+	case domain.SortByStartDate, domain.SortByEndDate, domain.SortByPrice, domain.SortByServiceName:
+		return sort, true
+	}
+
+	return domain.SubscriptionSort{}, false
+}
+
+func formatSort(sort domain.SubscriptionSort) string {
+	if sort.Descending {
+		return "-" + string(sort.Field)
+	}
+
+	return string(sort.Field)
+}
+
+// eventCursorToken is the JSON payload behind the opaque history cursor.
+type eventCursorToken struct {
+	Before int64 `json:"b"`
+}
+
+func encodeEventCursor(before int64) string {
+	raw, _ := json.Marshal(eventCursorToken{Before: before})
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeEventCursor(value string) (int64, error) {
+	invalid := domain.NewValidationError("invalid_cursor", "cursor is malformed").
+		WithFields(domain.FieldError{Field: "cursor", Message: "use next_cursor of a previous page"})
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return 0, invalid
+	}
+
+	var token eventCursorToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.Before <= 0 {
+		return 0, invalid
+	}
+
+	return token.Before, nil
+}
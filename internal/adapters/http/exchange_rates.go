@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) ImportExchangeRates(
+	ctx context.Context,
+	request ImportExchangeRatesRequestObject,
+) (ImportExchangeRatesResponseObject, error) {
+	rates, err := readExchangeRates(request.Body)
+	if err != nil {
+		return ImportExchangeRatesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	if err := s.subscriptions.ImportExchangeRates(ctx, rates); err != nil {
+		return ImportExchangeRatesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ImportExchangeRates200JSONResponse{Imported: len(rates)}, nil
+}
+
+// readExchangeRates parses base,quote,month,rate rows, the first row is
+// skipped when it is a header. Every malformed row is reported.
+func readExchangeRates(body io.Reader) ([]domain.ExchangeRate, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var rates []domain.ExchangeRate
+	var fields []domain.FieldError
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fields = append(fields, domain.FieldError{
+				Field:   fmt.Sprintf("row %d", row),
+				Message: err.Error(),
+			})
+			continue
+		}
+		if row == 1 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+
+		month, monthErr := time.Parse("01-2006", record[2])
+		rate, rateErr := strconv.ParseFloat(record[3], 64)
+		if monthErr != nil || rateErr != nil {
+			fields = append(fields, domain.FieldError{
+				Field:   fmt.Sprintf("row %d", row),
+				Message: "expected base,quote,MM-YYYY,rate (e.g., USD,RUB,07-2025,78.5)",
+			})
+			continue
+		}
+
+		rates = append(rates, domain.ExchangeRate{
+			Base:          domain.Currency(record[0]),
+			Quote:         domain.Currency(record[1]),
+			EffectiveFrom: month,
+			Rate:          rate,
+		})
+	}
+
+	if len(fields) == 0 && len(rates) == 0 {
+		fields = append(fields, domain.FieldError{Field: "body", Message: "no rates to import"})
+	}
+	if len(fields) > 0 {
+		return nil, domain.NewValidationError("invalid_exchange_rates", "exchange rates are invalid").
+			WithFields(fields...)
+	}
+
+	return rates, nil
+}
+
+func toHTTPAppliedRates(rates []domain.AppliedRate) []AppliedRate {
+	applied := make([]AppliedRate, 0, len(rates))
+	for _, rate := range rates {
+		applied = append(applied, AppliedRate{
+			Month:         rate.Month.Format("01-2006"),
+			From:          Currency(rate.From),
+			To:            Currency(rate.To),
+			Rate:          rate.Rate,
+			EffectiveFrom: rate.EffectiveFrom.Format("01-2006"),
+		})
+	}
+
+	return applied
+}
@@ -0,0 +1,88 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotalCostConvertedAtImportedRates(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serveContent
+
+	malformed := serve(http.MethodPost, "/exchange-rates", "text/csv", "USD,RUB,2025-01,90\n")
+	require.Equal(t, http.StatusBadRequest, malformed.Code)
+
+	imported := serve(
+		http.MethodPost,
+		"/exchange-rates",
+		"text/csv",
+		"base,quote,month,rate\nUSD,RUB,01-2025,90\nUSD,RUB,03-2025,80\n",
+	)
+	require.Equal(t, http.StatusOK, imported.Code, imported.Body.String())
+	require.JSONEq(t, `{"imported": 2}`, imported.Body.String())
+
+	userID := uuid.New()
+	created := serve(http.MethodPost, "/subscriptions", "application/json", `{
+		"user_id": "`+userID.String()+`",
+		"service_name": "Music",
+		"price": 999,
+		"currency": "USD",
+		"start_date": "01-2025"
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	total := serve(
+		http.MethodGet,
+		"/subscriptions/total?user_id="+userID.String()+
+			"&start_date=02-2025&end_date=03-2025&currency=RUB",
+		"",
+		"",
+	)
+	require.Equal(t, http.StatusOK, total.Code, total.Body.String())
+
+	var response httpadapter.TotalCostResponse
+	require.NoError(t, json.NewDecoder(total.Body).Decode(&response))
+	require.Equal(t, 999*90+999*80, response.TotalCost)
+	require.Equal(t, httpadapter.Currency("RUB"), *response.Currency)
+	require.Equal(t, []httpadapter.AppliedRate{
+		{Month: "02-2025", From: "USD", To: "RUB", Rate: 90, EffectiveFrom: "01-2025"},
+		{Month: "03-2025", From: "USD", To: "RUB", Rate: 80, EffectiveFrom: "03-2025"},
+	}, *response.Rates)
+}
+
+func TestImportExchangeRatesRequiresTheAdminToken(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	importRates := func(authorization string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(
+			http.MethodPost,
+			"/exchange-rates",
+			strings.NewReader("USD,RUB,01-2025,90\n"),
+		)
+		request.Header.Set("Content-Type", "text/csv")
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		httpadapter.Admin("secret", client.handler).ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	response := importRates("")
+	require.Equal(t, http.StatusUnauthorized, response.Code)
+	require.Contains(t, response.Body.String(), "admin_token_required")
+
+	response = importRates("Bearer secret")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	require.JSONEq(t, `{"imported": 1}`, response.Body.String())
+}
@@ -0,0 +1,45 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastCosts(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+
+	userID := uuid.New().String()
+	now := domain.MonthStart(time.Now())
+	created := serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+userID+`",
+		"service_name": "Music",
+		"price": 100,
+		"start_date": "`+now.AddDate(0, 1, 0).Format("01-2006")+`",
+		"end_date": "`+now.AddDate(0, 2, 0).Format("01-2006")+`"
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	response := serve(http.MethodGet, "/subscriptions/forecast?user_id="+userID, "")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	var forecast httpadapter.ForecastResponse
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&forecast))
+	require.Len(t, forecast.Months, 12)
+	require.Equal(t, now.Format("01-2006"), forecast.Months[0].Month)
+	require.Equal(t, 0, forecast.Months[0].TotalCost)
+	require.Equal(t, 100, forecast.Months[1].TotalCost)
+	require.Equal(t, 200, forecast.TotalCost)
+
+	invalid := serve(http.MethodGet, "/subscriptions/forecast?user_id="+userID+"&months=0", "")
+	require.Equal(t, http.StatusBadRequest, invalid.Code, invalid.Body.String())
+}
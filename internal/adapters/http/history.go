@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+)
+
+func (s *Server) ReadSubscriptionHistory(
+	ctx context.Context,
+	request ReadSubscriptionHistoryRequestObject,
+) (ReadSubscriptionHistoryResponseObject, error) {
+	query, err := toDomainEventQuery(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return ReadSubscriptionHistorydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	query.SubscriptionID = pointer.Ref(uuid.UUID(request.Id))
+
+	page, err := s.subscriptions.History(ctx, query)
+	if err != nil {
+		return ReadSubscriptionHistorydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ReadSubscriptionHistory200JSONResponse(toHTTPEventPage(page)), nil
+}
+
+func (s *Server) ReadUserHistory(
+	ctx context.Context,
+	request ReadUserHistoryRequestObject,
+) (ReadUserHistoryResponseObject, error) {
+	query, err := toDomainEventQuery(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return ReadUserHistorydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	query.UserID = pointer.Ref(uuid.UUID(request.Id))
+
+	page, err := s.subscriptions.History(ctx, query)
+	if err != nil {
+		return ReadUserHistorydefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ReadUserHistory200JSONResponse(toHTTPEventPage(page)), nil
+}
+
+func toDomainEventQuery(limit *int, cursor *string) (domain.EventQuery, error) {
+	query := domain.EventQuery{Limit: 50}
+	if limit != nil {
+		query.Limit = *limit
+	}
+	if query.Limit < 1 || query.Limit > maxPageLimit {
+		return domain.EventQuery{}, domain.NewValidationError(
+			"invalid_history_query",
+			"history parameters are invalid",
+		).WithFields(domain.FieldError{Field: "limit", Message: "must be between 1 and 100"})
+	}
+
+	if cursor != nil {
+		before, err := decodeEventCursor(*cursor)
+		if err != nil {
+			return domain.EventQuery{}, err
+		}
+		query.Before = &before
+	}
+
+	return query, nil
+}
+
+func toHTTPEventPage(page domain.EventPage) SubscriptionEventPage {
+	resp := SubscriptionEventPage{
+		Items:   make([]SubscriptionEvent, 0, len(page.Events)),
+		HasMore: page.HasMore,
+	}
+	for _, event := range page.Events {
+		resp.Items = append(resp.Items, toHTTPEvent(event))
+	}
+	if page.Next != nil {
+		resp.NextCursor = pointer.Ref(encodeEventCursor(*page.Next))
+	}
+
+	return resp
+}
+
+func toHTTPEvent(event domain.SubscriptionEvent) SubscriptionEvent {
+	resp := SubscriptionEvent{
+		Id:             event.ID,
+		SubscriptionId: event.SubscriptionID,
+		UserId:         event.UserID,
+		Type:           SubscriptionEventType(event.Type),
+		Actor:          event.Actor,
+		OccurredAt:     event.OccurredAt,
+	}
+	if event.RequestID != "" {
+		resp.RequestId = &event.RequestID
+	}
+	if event.Before != nil {
+		resp.Before = pointer.Ref(toHTTPSubscription(*event.Before))
+	}
+	if event.After != nil {
+		resp.After = pointer.Ref(toHTTPSubscription(*event.After))
+	}
+
+	return resp
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// applyMergePatch applies an RFC 7396 JSON merge patch to the request
+// representation of a subscription and decodes the result back.
+func applyMergePatch(
+	target CreateSubscriptionRequest,
+	patch json.RawMessage,
+) (CreateSubscriptionRequest, error) {
+	var patchDoc map[string]any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil || patchDoc == nil {
+		return CreateSubscriptionRequest{}, errors.New("patch must be a JSON object")
+	}
+
+	raw, err := json.Marshal(target)
+	if err != nil {
+		return CreateSubscriptionRequest{}, err
+	}
+
+	var targetDoc map[string]any
+	if err := json.Unmarshal(raw, &targetDoc); err != nil {
+		return CreateSubscriptionRequest{}, err
+	}
+
+	merged, err := json.Marshal(mergeDocuments(targetDoc, patchDoc))
+	if err != nil {
+		return CreateSubscriptionRequest{}, err
+	}
+
+	var result CreateSubscriptionRequest
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return CreateSubscriptionRequest{}, err
+	}
+
+	return result, nil
+}
+
+func mergeDocuments(target, patch map[string]any) map[string]any {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		patchObject, ok := value.(map[string]any)
+		if !ok {
+			target[key] = value
+			continue
+		}
+
+		targetObject, ok := target[key].(map[string]any)
+		if !ok {
+			targetObject = map[string]any{}
+		}
+		target[key] = mergeDocuments(targetObject, patchObject)
+	}
+
+	return target
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+const (
+	requestIDHeader     = "X-Request-ID"
+	actorHeader         = "X-Actor"
+	authorizationHeader = "Authorization"
+)
+
+// adminPathPrefixes are where the endpoints answering for or changing the
+// data of every user live.
+var adminPathPrefixes = []string{"/analytics/", "/exchange-rates"}
+
+// catalogPath is where the service catalog lives. It is shared by every user,
+// so only reading it is open.
+const catalogPath = "/services"
+
+// RequestID takes the request ID from the X-Request-ID header or generates a
+// new one, stores it in the request context and echoes it in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(log.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// Actor stores who made the request, as named by the X-Actor header, in the
+// request context. Requests without the header are recorded as anonymous.
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := strings.TrimSpace(r.Header.Get(actorHeader))
+		if actor == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(log.WithActor(r.Context(), actor)))
+	})
+}
+
+// Admin lets requests to the analytics, the exchange rates and changes to the
+// catalog through only with token as their bearer token, these endpoints
+// report on or change the data of every user at once. An empty token closes
+// them to everyone.
+func Admin(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAdminRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		given, ok := strings.CutPrefix(r.Header.Get(authorizationHeader), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeProblem(w, newProblem(
+				r.Context(),
+				http.StatusUnauthorized,
+				"admin_token_required",
+				"endpoint requires the admin token",
+				nil,
+			))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isAdminRequest(r *http.Request) bool {
+	for _, prefix := range adminPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+
+	catalog := r.URL.Path == catalogPath || strings.HasPrefix(r.URL.Path, catalogPath+"/")
+	return catalog && r.Method != http.MethodGet && r.Method != http.MethodHead
+}
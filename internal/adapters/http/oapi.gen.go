@@ -9,55 +9,663 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	AdminTokenScopes = "adminToken.Scopes"
+)
+
+// Defines values for BillingPeriod.
+const (
+	BillingPeriodMonthly   BillingPeriod = "monthly"
+	BillingPeriodQuarterly BillingPeriod = "quarterly"
+	BillingPeriodWeekly    BillingPeriod = "weekly"
+	BillingPeriodYearly    BillingPeriod = "yearly"
+)
+
+// Defines values for BudgetPeriod.
+const (
+	BudgetPeriodMonthly BudgetPeriod = "monthly"
+	BudgetPeriodYearly  BudgetPeriod = "yearly"
+)
+
+// Defines values for DiscountKind.
+const (
+	Fixed   DiscountKind = "fixed"
+	Percent DiscountKind = "percent"
+	Trial   DiscountKind = "trial"
+)
+
+// Defines values for SubscriptionEventType.
+const (
+	Canceled SubscriptionEventType = "canceled"
+	Created  SubscriptionEventType = "created"
+	Deleted  SubscriptionEventType = "deleted"
+	Renewed  SubscriptionEventType = "renewed"
+	Restored SubscriptionEventType = "restored"
+	Updated  SubscriptionEventType = "updated"
+)
+
+// Defines values for ReadAllSubscriptionsParamsStatus.
+const (
+	Active ReadAllSubscriptionsParamsStatus = "active"
+	Ended  ReadAllSubscriptionsParamsStatus = "ended"
+	Future ReadAllSubscriptionsParamsStatus = "future"
+)
+
+// Defines values for ReadAllSubscriptionsParamsSort.
+const (
+	ReadAllSubscriptionsParamsSortEndDate          ReadAllSubscriptionsParamsSort = "end_date"
+	ReadAllSubscriptionsParamsSortMinusEndDate     ReadAllSubscriptionsParamsSort = "-end_date"
+	ReadAllSubscriptionsParamsSortMinusPrice       ReadAllSubscriptionsParamsSort = "-price"
+	ReadAllSubscriptionsParamsSortMinusServiceName ReadAllSubscriptionsParamsSort = "-service_name"
+	ReadAllSubscriptionsParamsSortMinusStartDate   ReadAllSubscriptionsParamsSort = "-start_date"
+	ReadAllSubscriptionsParamsSortPrice            ReadAllSubscriptionsParamsSort = "price"
+	ReadAllSubscriptionsParamsSortServiceName      ReadAllSubscriptionsParamsSort = "service_name"
+	ReadAllSubscriptionsParamsSortStartDate        ReadAllSubscriptionsParamsSort = "start_date"
+)
+
+// Defines values for CalculateTotalCostParamsGroupBy.
+const (
+	CalculateTotalCostParamsGroupByCategory    CalculateTotalCostParamsGroupBy = "category"
+	CalculateTotalCostParamsGroupByServiceName CalculateTotalCostParamsGroupBy = "service_name"
+	CalculateTotalCostParamsGroupByTag         CalculateTotalCostParamsGroupBy = "tag"
+)
+
+// ActivityResponse defines model for ActivityResponse.
+type ActivityResponse struct {
+	Months []MonthlyActivity `json:"months"`
+}
+
+// AppliedRate defines model for AppliedRate.
+type AppliedRate struct {
+	// EffectiveFrom Month the stored rate took effect, it may be stored as the inverse pair
+	EffectiveFrom string `json:"effective_from"`
+
+	// From ISO 4217 currency code, subscriptions default to RUB
+	From Currency `json:"from"`
+
+	// Month Month of the converted charges
+	Month string `json:"month"`
+
+	// Rate Units of to one unit of from buys
+	Rate float64 `json:"rate"`
+
+	// To ISO 4217 currency code, subscriptions default to RUB
+	To Currency `json:"to"`
+}
+
+// BillingPeriod How often the price is charged
+type BillingPeriod string
+
+// Budget defines model for Budget.
+type Budget struct {
+	// Category Only counts the subscriptions of the category
+	Category *string `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency          `json:"currency,omitempty"`
+	Id       openapi_types.UUID `json:"id"`
+
+	// Limit Limit of the spend in every period in minor units of currency.
+	// Charges in other currencies are converted at the exchange rate of
+	// their month, as with the currency of /subscriptions/total.
+	Limit int `json:"limit"`
+
+	// Period Calendar month or year the limit of a budget starts over in
+	Period BudgetPeriod `json:"period"`
+
+	// ServiceName Only counts the subscriptions to the service, catalog names and
+	// aliases are resolved. Cannot be combined with category.
+	ServiceName *string            `json:"service_name,omitempty"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+// BudgetList defines model for BudgetList.
+type BudgetList struct {
+	Items []Budget `json:"items"`
+}
+
+// BudgetPeriod Calendar month or year the limit of a budget starts over in
+type BudgetPeriod string
+
+// BudgetRequest defines model for BudgetRequest.
+type BudgetRequest struct {
+	// Category Only counts the subscriptions of the category
+	Category *string `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// Limit Limit of the spend in every period in minor units of currency.
+	// Charges in other currencies are converted at the exchange rate of
+	// their month, as with the currency of /subscriptions/total.
+	Limit int `json:"limit"`
+
+	// Period Calendar month or year the limit of a budget starts over in
+	Period BudgetPeriod `json:"period"`
+
+	// ServiceName Only counts the subscriptions to the service, catalog names and
+	// aliases are resolved. Cannot be combined with category.
+	ServiceName *string `json:"service_name,omitempty"`
+}
+
+// BudgetStatus defines model for BudgetStatus.
+type BudgetStatus struct {
+	Budget      Budget `json:"budget"`
+	OverBudget  bool   `json:"over_budget"`
+	PeriodEnd   string `json:"period_end"`
+	PeriodStart string `json:"period_start"`
+
+	// Projected Charged in the rest of the period by the subscriptions known so far
+	Projected int `json:"projected"`
+
+	// Remaining Limit less spent and projected, negative when over budget
+	Remaining int `json:"remaining"`
+
+	// Spent Charged from the start of the period up to the month
+	Spent int `json:"spent"`
+}
+
+// BudgetStatusList defines model for BudgetStatusList.
+type BudgetStatusList struct {
+	Items []BudgetStatus `json:"items"`
+}
+
+// CancelSubscriptionRequest defines model for CancelSubscriptionRequest.
+type CancelSubscriptionRequest struct {
+	// Effective Last month of the subscription, it is still billed
+	Effective string `json:"effective"`
+}
+
+// CostGroup defines model for CostGroup.
+type CostGroup struct {
+	// Key Category or tag name, empty for uncategorized or untagged subscriptions
+	Key       string `json:"key"`
+	TotalCost int    `json:"total_cost"`
+}
+
+// CreateBudgetRequest defines model for CreateBudgetRequest.
+type CreateBudgetRequest struct {
+	// Category Only counts the subscriptions of the category
+	Category *string `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// Limit Limit of the spend in every period in minor units of currency.
+	// Charges in other currencies are converted at the exchange rate of
+	// their month, as with the currency of /subscriptions/total.
+	Limit int `json:"limit"`
+
+	// Period Calendar month or year the limit of a budget starts over in
+	Period BudgetPeriod `json:"period"`
+
+	// ServiceName Only counts the subscriptions to the service, catalog names and
+	// aliases are resolved. Cannot be combined with category.
+	ServiceName *string            `json:"service_name,omitempty"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
 // CreateSubscriptionRequest defines model for CreateSubscriptionRequest.
 type CreateSubscriptionRequest struct {
-	EndDate     *string            `json:"end_date"`
-	Price       int                `json:"price"`
-	ServiceName string             `json:"service_name"`
-	StartDate   string             `json:"start_date"`
-	UserId      openapi_types.UUID `json:"user_id"`
+	// BillingAnchor Month of a charge, defaults to the start month
+	BillingAnchor *string `json:"billing_anchor,omitempty"`
+
+	// BillingPeriod How often the price is charged
+	BillingPeriod *BillingPeriod `json:"billing_period,omitempty"`
+
+	// Category Defaults to the category of the catalog service
+	Category *string `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// Discounts Trial and discount periods, replaced as a whole on update
+	Discounts *[]Discount `json:"discounts,omitempty"`
+	EndDate   *string     `json:"end_date"`
+
+	// Price Price per billing period in minor units of currency, defaults to
+	// the default price of the catalog service. Breaking change in 2.0.0:
+	// prices used to be whole rubles.
+	Price *int `json:"price,omitempty"`
+
+	// ServiceName Catalog names and aliases are resolved to the catalog name
+	ServiceName string `json:"service_name"`
+	StartDate   string `json:"start_date"`
+
+	// Tags Names of the user's tags, tags the user does not have yet are
+	// created. Replaced as a whole on update.
+	Tags   *[]string          `json:"tags,omitempty"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// CreateTagRequest defines model for CreateTagRequest.
+type CreateTagRequest struct {
+	Name   string             `json:"name"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// Currency ISO 4217 currency code, subscriptions default to RUB
+type Currency = string
+
+// Discount defines model for Discount.
+type Discount struct {
+	// EndMonth Last discounted month, within the subscription
+	EndMonth string `json:"end_month"`
+
+	// Kind Trials are free, percent and fixed discounts lower the price
+	Kind       DiscountKind `json:"kind"`
+	StartMonth string       `json:"start_month"`
+
+	// Value Percent off or minor units off every charge, unused for trials
+	Value *int `json:"value,omitempty"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
+// DiscountKind Trials are free, percent and fixed discounts lower the price
+type DiscountKind string
+
+// ExchangeRatesImport defines model for ExchangeRatesImport.
+type ExchangeRatesImport struct {
+	// Imported Number of rates stored
+	Imported int `json:"imported"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// ForecastResponse defines model for ForecastResponse.
+type ForecastResponse struct {
+	Months []MonthlyCost `json:"months"`
+
+	// TotalCost Sum of the projected months
+	TotalCost int `json:"total_cost"`
+}
+
+// MergeTagsRequest defines model for MergeTagsRequest.
+type MergeTagsRequest struct {
+	// Into Tag of the same user that takes over the subscriptions
+	Into openapi_types.UUID `json:"into"`
+}
+
+// MonthlyActivity defines model for MonthlyActivity.
+type MonthlyActivity struct {
+	// Active Subscriptions touching the month
+	Active int `json:"active"`
+
+	// Cancelled Subscriptions ending in the month
+	Cancelled int    `json:"cancelled"`
+	Month     string `json:"month"`
+
+	// New Subscriptions starting in the month
+	New int `json:"new"`
+}
+
+// MonthlyCost defines model for MonthlyCost.
+type MonthlyCost struct {
+	Month     string        `json:"month"`
+	Services  []ServiceCost `json:"services"`
+	TotalCost int           `json:"total_cost"`
+}
+
+// MonthlyCostsResponse defines model for MonthlyCostsResponse.
+type MonthlyCostsResponse struct {
+	Months []MonthlyCost `json:"months"`
+}
+
+// PriceChange defines model for PriceChange.
+type PriceChange struct {
+	// EffectiveFrom First month billed at this price
+	EffectiveFrom string `json:"effective_from"`
+
+	// Price Price per billing period in minor units of the subscription currency
+	Price int `json:"price"`
+}
+
+// PriceHistory defines model for PriceHistory.
+type PriceHistory struct {
+	Items []PriceChange `json:"items"`
+}
+
+// Problem RFC 7807 problem details
+type Problem struct {
+	// Code Stable machine-readable error code.
+	// previous_subscription_not_ended is returned for any subscription
+	// overlapping another one of the same user to the same service.
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Per-field validation failures
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Request ID of the failed request
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
+// RenameTagRequest defines model for RenameTagRequest.
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// RenewSubscriptionRequest Exactly one of months and open_ended
+type RenewSubscriptionRequest struct {
+	// Months Months added past the current end date
+	Months *int `json:"months,omitempty"`
+
+	// OpenEnded Remove the end date
+	OpenEnded *bool `json:"open_ended,omitempty"`
+}
+
+// RevenueResponse defines model for RevenueResponse.
+type RevenueResponse struct {
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency Currency      `json:"currency"`
+	Months   []MonthlyCost `json:"months"`
+}
+
+// Service defines model for Service.
+type Service struct {
+	// Aliases Other spellings resolved to the service
+	Aliases []string `json:"aliases"`
+
+	// BillingPeriod How often the price is charged
+	BillingPeriod BillingPeriod `json:"billing_period"`
+	Category      string        `json:"category"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency Currency `json:"currency"`
+
+	// DefaultPrice Price per billing period for subscriptions created without one
+	DefaultPrice *int               `json:"default_price"`
+	Id           openapi_types.UUID `json:"id"`
+	Name         string             `json:"name"`
+}
+
+// ServiceCost defines model for ServiceCost.
+type ServiceCost struct {
+	Cost        int    `json:"cost"`
+	ServiceName string `json:"service_name"`
+}
+
+// ServiceList defines model for ServiceList.
+type ServiceList struct {
+	Items []Service `json:"items"`
+}
+
+// ServiceRequest defines model for ServiceRequest.
+type ServiceRequest struct {
+	// Aliases Other spellings resolved to the service
+	Aliases *[]string `json:"aliases,omitempty"`
+
+	// BillingPeriod How often the price is charged
+	BillingPeriod *BillingPeriod `json:"billing_period,omitempty"`
+	Category      *string        `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// DefaultPrice Price per billing period for subscriptions created without one
+	DefaultPrice *int   `json:"default_price"`
+	Name         string `json:"name"`
+}
+
 // Subscription defines model for Subscription.
 type Subscription struct {
-	Id     openapi_types.UUID `json:"id"`
-	UserId openapi_types.UUID `json:"user_id"`
+	// BillingAnchor Month of a charge, quarterly and yearly charges repeat from it
+	BillingAnchor *string `json:"billing_anchor,omitempty"`
+
+	// BillingPeriod How often the price is charged
+	BillingPeriod *BillingPeriod `json:"billing_period,omitempty"`
+
+	// BudgetWarnings Only in the response to a creation, the budgets the subscription
+	// counts towards that are over their limit with it
+	BudgetWarnings *[]BudgetStatus `json:"budget_warnings,omitempty"`
+	Category       *string         `json:"category,omitempty"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// DeletedAt When the subscription was deleted, null for live subscriptions
+	DeletedAt *time.Time         `json:"deleted_at"`
+	Discounts *[]Discount        `json:"discounts,omitempty"`
+	EndDate   *string            `json:"end_date"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// NormalizedMonthlyCost Price converted to a monthly amount for comparisons
+	NormalizedMonthlyCost *int `json:"normalized_monthly_cost,omitempty"`
+
+	// Price Price per billing period in minor units of currency. Breaking change
+	// in 2.0.0: prices used to be whole rubles, migration 008 multiplied the
+	// stored ones by 100, so 399 rubles are now 39900.
+	Price int `json:"price"`
+
+	// ServiceId Catalog service the name resolved to, missing for names not in the catalog
+	ServiceId   *openapi_types.UUID `json:"service_id,omitempty"`
+	ServiceName string              `json:"service_name"`
+	StartDate   string              `json:"start_date"`
+	Tags        *[]string           `json:"tags,omitempty"`
+	UserId      openapi_types.UUID  `json:"user_id"`
+}
+
+// SubscriptionEvent defines model for SubscriptionEvent.
+type SubscriptionEvent struct {
+	// Actor X-Actor header of the request, "anonymous" when it was not sent
+	Actor          string                `json:"actor"`
+	After          *Subscription         `json:"after,omitempty"`
+	Before         *Subscription         `json:"before,omitempty"`
+	Id             int64                 `json:"id"`
+	OccurredAt     time.Time             `json:"occurred_at"`
+	RequestId      *string               `json:"request_id,omitempty"`
+	SubscriptionId openapi_types.UUID    `json:"subscription_id"`
+	Type           SubscriptionEventType `json:"type"`
+	UserId         openapi_types.UUID    `json:"user_id"`
+}
 
-	// Price Monthly subscription price
-	Price       int     `json:"price"`
-	ServiceName string  `json:"service_name"`
-	StartDate   string  `json:"start_date"`
-	EndDate     *string `json:"end_date"`
+// SubscriptionEventType defines model for SubscriptionEvent.Type.
+type SubscriptionEventType string
+
+// SubscriptionEventPage defines model for SubscriptionEventPage.
+type SubscriptionEventPage struct {
+	HasMore bool                `json:"has_more"`
+	Items   []SubscriptionEvent `json:"items"`
+
+	// NextCursor Token for the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
 }
 
+// SubscriptionPatch defines model for SubscriptionPatch.
+type SubscriptionPatch = json.RawMessage
+
 // SuccessResponse defines model for SuccessResponse.
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
 
+// Tag defines model for Tag.
+type Tag struct {
+	Id   openapi_types.UUID `json:"id"`
+	Name string             `json:"name"`
+
+	// Subscriptions Number of live subscriptions with the tag
+	Subscriptions int                `json:"subscriptions"`
+	UserId        openapi_types.UUID `json:"user_id"`
+}
+
+// TagList defines model for TagList.
+type TagList struct {
+	Items []Tag `json:"items"`
+}
+
+// TopServicesResponse defines model for TopServicesResponse.
+type TopServicesResponse struct {
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency Currency      `json:"currency"`
+	Items    []ServiceCost `json:"items"`
+}
+
+// TotalCostItem defines model for TotalCostItem.
+type TotalCostItem struct {
+	// BillingPeriod How often the price is charged
+	BillingPeriod BillingPeriod `json:"billing_period"`
+
+	// Cost Cost of the charges, converted when the total is
+	Cost int `json:"cost"`
+
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency Currency `json:"currency"`
+
+	// MonthlyPrice Price of one charge in currency
+	MonthlyPrice int `json:"monthly_price"`
+
+	// Months Number of charges within the period
+	Months      int    `json:"months"`
+	ServiceName string `json:"service_name"`
+}
+
 // TotalCostResponse defines model for TotalCostResponse.
 type TotalCostResponse struct {
-	// TotalCost Total subscription cost
+	// Currency ISO 4217 currency code, subscriptions default to RUB
+	Currency *Currency `json:"currency,omitempty"`
+
+	// Groups Present with group_by category or tag, sorted by key
+	Groups *[]CostGroup `json:"groups,omitempty"`
+
+	// Items Present with group_by, one item per service, billing period, currency and price
+	Items *[]TotalCostItem `json:"items,omitempty"`
+
+	// Rates Present with currency, the rate each month of each currency was converted at
+	Rates *[]AppliedRate `json:"rates,omitempty"`
+
+	// TotalCost Total subscription cost in minor units, of currency when converted
+	// and of the one currency of the subscriptions otherwise
 	TotalCost int `json:"total_cost"`
 }
 
+// GetSubscriptionActivityParams defines parameters for GetSubscriptionActivity.
+type GetSubscriptionActivityParams struct {
+	StartDate string `form:"start_date" json:"start_date"`
+
+	// EndDate Defaults to the current month
+	EndDate *string `form:"end_date,omitempty" json:"end_date,omitempty"`
+}
+
+// GetMonthlyRevenueParams defines parameters for GetMonthlyRevenue.
+type GetMonthlyRevenueParams struct {
+	StartDate string `form:"start_date" json:"start_date"`
+
+	// EndDate Defaults to the current month
+	EndDate *string `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Currency Currency the spend is reported in, RUB by default. The charges of every month are converted at the latest exchange rate that took effect in that month or before, as in /subscriptions/total.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetTopServicesParams defines parameters for GetTopServices.
+type GetTopServicesParams struct {
+	StartDate string `form:"start_date" json:"start_date"`
+
+	// EndDate Defaults to the current month
+	EndDate *string `form:"end_date,omitempty" json:"end_date,omitempty"`
+	Limit   *int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Currency Currency the spend is reported in, RUB by default. The charges of every month are converted at the latest exchange rate that took effect in that month or before, as in /subscriptions/total.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// ListServicesParams defines parameters for ListServices.
+type ListServicesParams struct {
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+}
+
 // ReadAllSubscriptionsParams defines parameters for ReadAllSubscriptions.
 type ReadAllSubscriptionsParams struct {
 	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
 	Limit  *int               `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int               `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Offset Offset paging, kept for backward compatibility. Cannot be combined with cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque token from X-Next-Cursor of the previous page, issued for the same sort
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// ServiceName Exact service name
+	ServiceName *string `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// ServiceNamePrefix Service name prefix
+	ServiceNamePrefix *string `form:"service_name_prefix,omitempty" json:"service_name_prefix,omitempty"`
+
+	// Category Exact category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Only subscriptions with this tag, ignoring case and extra spaces
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// ActiveAt Only subscriptions active in this month
+	ActiveAt *string `form:"active_at,omitempty" json:"active_at,omitempty"`
+
+	// Status Status relative to the current month
+	Status   *ReadAllSubscriptionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	MinPrice *int                              `form:"min_price,omitempty" json:"min_price,omitempty"`
+	MaxPrice *int                              `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// StartFrom Earliest start month, inclusive
+	StartFrom *string `form:"start_from,omitempty" json:"start_from,omitempty"`
+
+	// StartTo Latest start month, inclusive
+	StartTo *string `form:"start_to,omitempty" json:"start_to,omitempty"`
+
+	// Sort Sort field, a leading minus sorts descending. Ties are broken by id.
+	Sort *ReadAllSubscriptionsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IncludeTotal Report the total number of matching subscriptions in X-Total-Count
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
+
+	// IncludeDeleted List deleted subscriptions that have not been purged yet
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// ReadAllSubscriptionsParamsStatus defines parameters for ReadAllSubscriptions.
+type ReadAllSubscriptionsParamsStatus string
+
+// ReadAllSubscriptionsParamsSort defines parameters for ReadAllSubscriptions.
+type ReadAllSubscriptionsParamsSort string
+
+// CalculateMonthlyCostsParams defines parameters for CalculateMonthlyCosts.
+type CalculateMonthlyCostsParams struct {
+	UserId      openapi_types.UUID `form:"user_id" json:"user_id"`
+	ServiceName *string            `form:"service_name,omitempty" json:"service_name,omitempty"`
+	StartDate   string             `form:"start_date" json:"start_date"`
+
+	// EndDate Defaults to the current month
+	EndDate *string `form:"end_date,omitempty" json:"end_date,omitempty"`
+}
+
+// ForecastCostsParams defines parameters for ForecastCosts.
+type ForecastCostsParams struct {
+	UserId      openapi_types.UUID `form:"user_id" json:"user_id"`
+	ServiceName *string            `form:"service_name,omitempty" json:"service_name,omitempty"`
+	Months      *int               `form:"months,omitempty" json:"months,omitempty"`
 }
 
 // CalculateTotalCostParams defines parameters for CalculateTotalCost.
@@ -66,19 +674,136 @@ type CalculateTotalCostParams struct {
 	ServiceName *string            `form:"service_name,omitempty" json:"service_name,omitempty"`
 	StartDate   string             `form:"start_date" json:"start_date"`
 	EndDate     *string            `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// GroupBy service_name adds a per-service breakdown of the total to the response, category and tag roll it up into groups instead. A subscription counts in every one of its tags. Groups cannot be combined with currency.
+	GroupBy *CalculateTotalCostParamsGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// Currency Converts the charges of every month to this currency at the latest exchange rate that took effect in that month or before. Without it every subscription running in the period must be in one currency, amounts in different currencies are rejected with mixed_currencies instead of being added up.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
 }
 
+// CalculateTotalCostParamsGroupBy defines parameters for CalculateTotalCost.
+type CalculateTotalCostParamsGroupBy string
+
+// ReadSubscriptionHistoryParams defines parameters for ReadSubscriptionHistory.
+type ReadSubscriptionHistoryParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque token from next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListTagsParams defines parameters for ListTags.
+type ListTagsParams struct {
+	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
+}
+
+// GetBudgetStatusParams defines parameters for GetBudgetStatus.
+type GetBudgetStatusParams struct {
+	// Month Month the status is taken at in MM-YYYY format, defaults to the current month
+	Month *string `form:"month,omitempty" json:"month,omitempty"`
+}
+
+// ReadUserHistoryParams defines parameters for ReadUserHistory.
+type ReadUserHistoryParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque token from next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateBudgetJSONRequestBody defines body for CreateBudget for application/json ContentType.
+type CreateBudgetJSONRequestBody = CreateBudgetRequest
+
+// ReplaceBudgetJSONRequestBody defines body for ReplaceBudget for application/json ContentType.
+type ReplaceBudgetJSONRequestBody = BudgetRequest
+
+// CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
+type CreateServiceJSONRequestBody = ServiceRequest
+
+// ReplaceServiceJSONRequestBody defines body for ReplaceService for application/json ContentType.
+type ReplaceServiceJSONRequestBody = ServiceRequest
+
 // CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
 type CreateSubscriptionJSONRequestBody = CreateSubscriptionRequest
 
+// PatchSubscriptionApplicationMergePatchPlusJSONRequestBody defines body for PatchSubscription for application/merge-patch+json ContentType.
+type PatchSubscriptionApplicationMergePatchPlusJSONRequestBody = SubscriptionPatch
+
+// ReplaceSubscriptionJSONRequestBody defines body for ReplaceSubscription for application/json ContentType.
+type ReplaceSubscriptionJSONRequestBody = CreateSubscriptionRequest
+
+// CancelSubscriptionJSONRequestBody defines body for CancelSubscription for application/json ContentType.
+type CancelSubscriptionJSONRequestBody = CancelSubscriptionRequest
+
+// ScheduleSubscriptionPriceJSONRequestBody defines body for ScheduleSubscriptionPrice for application/json ContentType.
+type ScheduleSubscriptionPriceJSONRequestBody = PriceChange
+
+// RenewSubscriptionJSONRequestBody defines body for RenewSubscription for application/json ContentType.
+type RenewSubscriptionJSONRequestBody = RenewSubscriptionRequest
+
+// CreateTagJSONRequestBody defines body for CreateTag for application/json ContentType.
+type CreateTagJSONRequestBody = CreateTagRequest
+
+// RenameTagJSONRequestBody defines body for RenameTag for application/json ContentType.
+type RenameTagJSONRequestBody = RenameTagRequest
+
+// MergeTagsJSONRequestBody defines body for MergeTags for application/json ContentType.
+type MergeTagsJSONRequestBody = MergeTagsRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Subscription activity
+	// (GET /analytics/activity)
+	GetSubscriptionActivity(w http.ResponseWriter, r *http.Request, params GetSubscriptionActivityParams)
+	// Monthly recurring revenue
+	// (GET /analytics/revenue)
+	GetMonthlyRevenue(w http.ResponseWriter, r *http.Request, params GetMonthlyRevenueParams)
+	// Top services by spend
+	// (GET /analytics/top-services)
+	GetTopServices(w http.ResponseWriter, r *http.Request, params GetTopServicesParams)
+	// Create a budget
+	// (POST /budgets)
+	CreateBudget(w http.ResponseWriter, r *http.Request)
+	// Delete a budget
+	// (DELETE /budgets/{id})
+	DeleteBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a budget
+	// (GET /budgets/{id})
+	GetBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Replace the limit, period and scope of a budget
+	// (PUT /budgets/{id})
+	ReplaceBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Import exchange rates
+	// (POST /exchange-rates)
+	ImportExchangeRates(w http.ResponseWriter, r *http.Request)
+	// List the catalog
+	// (GET /services)
+	ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams)
+	// Add a service to the catalog
+	// (POST /services)
+	CreateService(w http.ResponseWriter, r *http.Request)
+	// Remove a service from the catalog
+	// (DELETE /services/{id})
+	DeleteService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a catalog service
+	// (GET /services/{id})
+	GetService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Replace a catalog service
+	// (PUT /services/{id})
+	ReplaceService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
 	// List of subscriptions
 	// (GET /subscriptions)
 	ReadAllSubscriptions(w http.ResponseWriter, r *http.Request, params ReadAllSubscriptionsParams)
 	// Create subscription
 	// (POST /subscriptions)
 	CreateSubscription(w http.ResponseWriter, r *http.Request)
+	// Monthly cost breakdown
+	// (GET /subscriptions/costs/monthly)
+	CalculateMonthlyCosts(w http.ResponseWriter, r *http.Request, params CalculateMonthlyCostsParams)
+	// Spending forecast
+	// (GET /subscriptions/forecast)
+	ForecastCosts(w http.ResponseWriter, r *http.Request, params ForecastCostsParams)
 	// Calculate total subscription cost
 	// (GET /subscriptions/total)
 	CalculateTotalCost(w http.ResponseWriter, r *http.Request, params CalculateTotalCostParams)
@@ -88,6 +813,54 @@ type ServerInterface interface {
 	// Get subscription by ID
 	// (GET /subscriptions/{id})
 	GetSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Partially update subscription
+	// (PATCH /subscriptions/{id})
+	PatchSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Replace subscription
+	// (PUT /subscriptions/{id})
+	ReplaceSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Cancel a subscription
+	// (POST /subscriptions/{id}/cancel)
+	CancelSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Change history of a subscription
+	// (GET /subscriptions/{id}/history)
+	ReadSubscriptionHistory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReadSubscriptionHistoryParams)
+	// Price history of a subscription
+	// (GET /subscriptions/{id}/prices)
+	ReadSubscriptionPrices(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Schedule a price change
+	// (POST /subscriptions/{id}/prices)
+	ScheduleSubscriptionPrice(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Renew a subscription
+	// (POST /subscriptions/{id}/renew)
+	RenewSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Restore a deleted subscription
+	// (POST /subscriptions/{id}/restore)
+	RestoreSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List the tags of a user
+	// (GET /tags)
+	ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams)
+	// Create a tag
+	// (POST /tags)
+	CreateTag(w http.ResponseWriter, r *http.Request)
+	// Delete a tag
+	// (DELETE /tags/{id})
+	DeleteTag(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Rename a tag
+	// (PUT /tags/{id})
+	RenameTag(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Merge a tag into another one
+	// (POST /tags/{id}/merge)
+	MergeTags(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List the budgets of a user
+	// (GET /users/{id}/budgets)
+	ListBudgets(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Spend of every budget of a user against its limit
+	// (GET /users/{id}/budgets/status)
+	GetBudgetStatus(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetBudgetStatusParams)
+	// Change history of all subscriptions of a user
+	// (GET /users/{id}/history)
+	ReadUserHistory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReadUserHistoryParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -99,47 +872,45 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// ReadAllSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) ReadAllSubscriptions(w http.ResponseWriter, r *http.Request) {
+// GetSubscriptionActivity operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptionActivity(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ReadAllSubscriptionsParams
+	var params GetSubscriptionActivityParams
 
-	// ------------- Required query parameter "user_id" -------------
+	// ------------- Required query parameter "start_date" -------------
 
-	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("start_date"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "start_date"})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, true, "start_date", r.URL.Query(), &params.StartDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_date", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "end_date" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadAllSubscriptions(w, r, params)
+		siw.Handler.GetSubscriptionActivity(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -149,51 +920,76 @@ func (siw *ServerInterfaceWrapper) ReadAllSubscriptions(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// CreateSubscription operation middleware
-func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+// GetMonthlyRevenue operation middleware
+func (siw *ServerInterfaceWrapper) GetMonthlyRevenue(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateSubscription(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	ctx := r.Context()
 
-	handler.ServeHTTP(w, r)
-}
-
-// CalculateTotalCost operation middleware
-func (siw *ServerInterfaceWrapper) CalculateTotalCost(w http.ResponseWriter, r *http.Request) {
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-	var err error
+	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CalculateTotalCostParams
+	var params GetMonthlyRevenueParams
 
-	// ------------- Required query parameter "user_id" -------------
+	// ------------- Required query parameter "start_date" -------------
 
-	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("start_date"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "start_date"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	err = runtime.BindQueryParameter("form", true, true, "start_date", r.URL.Query(), &params.StartDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_date", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "service_name" -------------
+	// ------------- Optional query parameter "end_date" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "service_name", r.URL.Query(), &params.ServiceName)
+	err = runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
 		return
 	}
 
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMonthlyRevenue(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTopServices operation middleware
+func (siw *ServerInterfaceWrapper) GetTopServices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTopServicesParams
+
 	// ------------- Required query parameter "start_date" -------------
 
 	if paramValue := r.URL.Query().Get("start_date"); paramValue != "" {
@@ -217,8 +1013,24 @@ func (siw *ServerInterfaceWrapper) CalculateTotalCost(w http.ResponseWriter, r *
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CalculateTotalCost(w, r, params)
+		siw.Handler.GetTopServices(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -228,8 +1040,22 @@ func (siw *ServerInterfaceWrapper) CalculateTotalCost(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteSubscription operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+// CreateBudget operation middleware
+func (siw *ServerInterfaceWrapper) CreateBudget(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBudget(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBudget operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBudget(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -243,7 +1069,7 @@ func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteSubscription(w, r, id)
+		siw.Handler.DeleteBudget(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -253,8 +1079,8 @@ func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetSubscription operation middleware
-func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+// GetBudget operation middleware
+func (siw *ServerInterfaceWrapper) GetBudget(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -268,7 +1094,7 @@ func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSubscription(w, r, id)
+		siw.Handler.GetBudget(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -278,368 +1104,4178 @@ func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ReplaceBudget operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceBudget(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceBudget(w, r, id)
+	}))
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// ImportExchangeRates operation middleware
+func (siw *ServerInterfaceWrapper) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	ctx := r.Context()
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	r = r.WithContext(ctx)
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportExchangeRates(w, r)
+	}))
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// ListServices operation middleware
+func (siw *ServerInterfaceWrapper) ListServices(w http.ResponseWriter, r *http.Request) {
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var err error
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServicesParams
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+	// ------------- Optional query parameter "category" -------------
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListServices(w, r, params)
+	}))
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+// CreateService operation middleware
+func (siw *ServerInterfaceWrapper) CreateService(w http.ResponseWriter, r *http.Request) {
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+	ctx := r.Context()
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-	m.HandleFunc("GET "+options.BaseURL+"/subscriptions", wrapper.ReadAllSubscriptions)
-	m.HandleFunc("POST "+options.BaseURL+"/subscriptions", wrapper.CreateSubscription)
-	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/total", wrapper.CalculateTotalCost)
-	m.HandleFunc("DELETE "+options.BaseURL+"/subscriptions/{id}", wrapper.DeleteSubscription)
-	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/{id}", wrapper.GetSubscription)
+	r = r.WithContext(ctx)
 
-	return m
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateService(w, r)
+	}))
 
-type ReadAllSubscriptionsRequestObject struct {
-	Params ReadAllSubscriptionsParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ReadAllSubscriptionsResponseObject interface {
-	VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type ReadAllSubscriptions200JSONResponse []Subscription
-
-func (response ReadAllSubscriptions200JSONResponse) VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// DeleteService operation middleware
+func (siw *ServerInterfaceWrapper) DeleteService(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type ReadAllSubscriptions500JSONResponse ErrorResponse
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-func (response ReadAllSubscriptions500JSONResponse) VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type CreateSubscriptionRequestObject struct {
-	Body *CreateSubscriptionJSONRequestBody
-}
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-type CreateSubscriptionResponseObject interface {
-	VisitCreateSubscriptionResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type CreateSubscription201JSONResponse Subscription
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteService(w, r, id)
+	}))
 
-func (response CreateSubscription201JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateSubscription400JSONResponse ErrorResponse
+// GetService operation middleware
+func (siw *ServerInterfaceWrapper) GetService(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateSubscription400JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetService(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplaceService operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceService(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceService(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadAllSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ReadAllSubscriptions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadAllSubscriptionsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", r.URL.Query(), &params.ServiceName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_name_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name_prefix", r.URL.Query(), &params.ServiceNamePrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "active_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active_at", r.URL.Query(), &params.ActiveAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "active_at", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", r.URL.Query(), &params.MinPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", r.URL.Query(), &params.MaxPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "start_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_from", r.URL.Query(), &params.StartFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "start_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_to", r.URL.Query(), &params.StartTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_total", r.URL.Query(), &params.IncludeTotal)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_total", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadAllSubscriptions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSubscription(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CalculateMonthlyCosts operation middleware
+func (siw *ServerInterfaceWrapper) CalculateMonthlyCosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CalculateMonthlyCostsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", r.URL.Query(), &params.ServiceName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := r.URL.Query().Get("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "start_date"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start_date", r.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CalculateMonthlyCosts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ForecastCosts operation middleware
+func (siw *ServerInterfaceWrapper) ForecastCosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ForecastCostsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", r.URL.Query(), &params.ServiceName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "months" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "months", r.URL.Query(), &params.Months)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "months", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ForecastCosts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CalculateTotalCost operation middleware
+func (siw *ServerInterfaceWrapper) CalculateTotalCost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CalculateTotalCostParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", r.URL.Query(), &params.ServiceName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_name", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := r.URL.Query().Get("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "start_date"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start_date", r.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start_date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CalculateTotalCost(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchSubscription operation middleware
+func (siw *ServerInterfaceWrapper) PatchSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplaceSubscription operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadSubscriptionHistory operation middleware
+func (siw *ServerInterfaceWrapper) ReadSubscriptionHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadSubscriptionHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadSubscriptionHistory(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadSubscriptionPrices operation middleware
+func (siw *ServerInterfaceWrapper) ReadSubscriptionPrices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadSubscriptionPrices(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ScheduleSubscriptionPrice operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleSubscriptionPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ScheduleSubscriptionPrice(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenewSubscription operation middleware
+func (siw *ServerInterfaceWrapper) RenewSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenewSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreSubscription operation middleware
+func (siw *ServerInterfaceWrapper) RestoreSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreSubscription(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTags operation middleware
+func (siw *ServerInterfaceWrapper) ListTags(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTagsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTags(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTag operation middleware
+func (siw *ServerInterfaceWrapper) CreateTag(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTag(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTag operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTag(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameTag operation middleware
+func (siw *ServerInterfaceWrapper) RenameTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameTag(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergeTags operation middleware
+func (siw *ServerInterfaceWrapper) MergeTags(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeTags(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBudgets operation middleware
+func (siw *ServerInterfaceWrapper) ListBudgets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBudgets(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBudgetStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetBudgetStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBudgetStatusParams
+
+	// ------------- Optional query parameter "month" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBudgetStatus(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadUserHistory operation middleware
+func (siw *ServerInterfaceWrapper) ReadUserHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadUserHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadUserHistory(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/analytics/activity", wrapper.GetSubscriptionActivity)
+	m.HandleFunc("GET "+options.BaseURL+"/analytics/revenue", wrapper.GetMonthlyRevenue)
+	m.HandleFunc("GET "+options.BaseURL+"/analytics/top-services", wrapper.GetTopServices)
+	m.HandleFunc("POST "+options.BaseURL+"/budgets", wrapper.CreateBudget)
+	m.HandleFunc("DELETE "+options.BaseURL+"/budgets/{id}", wrapper.DeleteBudget)
+	m.HandleFunc("GET "+options.BaseURL+"/budgets/{id}", wrapper.GetBudget)
+	m.HandleFunc("PUT "+options.BaseURL+"/budgets/{id}", wrapper.ReplaceBudget)
+	m.HandleFunc("POST "+options.BaseURL+"/exchange-rates", wrapper.ImportExchangeRates)
+	m.HandleFunc("GET "+options.BaseURL+"/services", wrapper.ListServices)
+	m.HandleFunc("POST "+options.BaseURL+"/services", wrapper.CreateService)
+	m.HandleFunc("DELETE "+options.BaseURL+"/services/{id}", wrapper.DeleteService)
+	m.HandleFunc("GET "+options.BaseURL+"/services/{id}", wrapper.GetService)
+	m.HandleFunc("PUT "+options.BaseURL+"/services/{id}", wrapper.ReplaceService)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions", wrapper.ReadAllSubscriptions)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions", wrapper.CreateSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/costs/monthly", wrapper.CalculateMonthlyCosts)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/forecast", wrapper.ForecastCosts)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/total", wrapper.CalculateTotalCost)
+	m.HandleFunc("DELETE "+options.BaseURL+"/subscriptions/{id}", wrapper.DeleteSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/{id}", wrapper.GetSubscription)
+	m.HandleFunc("PATCH "+options.BaseURL+"/subscriptions/{id}", wrapper.PatchSubscription)
+	m.HandleFunc("PUT "+options.BaseURL+"/subscriptions/{id}", wrapper.ReplaceSubscription)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/{id}/cancel", wrapper.CancelSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/{id}/history", wrapper.ReadSubscriptionHistory)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/{id}/prices", wrapper.ReadSubscriptionPrices)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/{id}/prices", wrapper.ScheduleSubscriptionPrice)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/{id}/renew", wrapper.RenewSubscription)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/{id}/restore", wrapper.RestoreSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/tags", wrapper.ListTags)
+	m.HandleFunc("POST "+options.BaseURL+"/tags", wrapper.CreateTag)
+	m.HandleFunc("DELETE "+options.BaseURL+"/tags/{id}", wrapper.DeleteTag)
+	m.HandleFunc("PUT "+options.BaseURL+"/tags/{id}", wrapper.RenameTag)
+	m.HandleFunc("POST "+options.BaseURL+"/tags/{id}/merge", wrapper.MergeTags)
+	m.HandleFunc("GET "+options.BaseURL+"/users/{id}/budgets", wrapper.ListBudgets)
+	m.HandleFunc("GET "+options.BaseURL+"/users/{id}/budgets/status", wrapper.GetBudgetStatus)
+	m.HandleFunc("GET "+options.BaseURL+"/users/{id}/history", wrapper.ReadUserHistory)
+
+	return m
+}
+
+type GetSubscriptionActivityRequestObject struct {
+	Params GetSubscriptionActivityParams
+}
+
+type GetSubscriptionActivityResponseObject interface {
+	VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionActivity200JSONResponse ActivityResponse
+
+func (response GetSubscriptionActivity200JSONResponse) VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionActivity400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetSubscriptionActivity400ApplicationProblemPlusJSONResponse) VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionActivity401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetSubscriptionActivity401ApplicationProblemPlusJSONResponse) VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionActivity500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetSubscriptionActivity500ApplicationProblemPlusJSONResponse) VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionActivitydefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetSubscriptionActivitydefaultApplicationProblemPlusJSONResponse) VisitGetSubscriptionActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetMonthlyRevenueRequestObject struct {
+	Params GetMonthlyRevenueParams
+}
+
+type GetMonthlyRevenueResponseObject interface {
+	VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error
+}
+
+type GetMonthlyRevenue200JSONResponse RevenueResponse
+
+func (response GetMonthlyRevenue200JSONResponse) VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMonthlyRevenue400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetMonthlyRevenue400ApplicationProblemPlusJSONResponse) VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMonthlyRevenue401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetMonthlyRevenue401ApplicationProblemPlusJSONResponse) VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMonthlyRevenue500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetMonthlyRevenue500ApplicationProblemPlusJSONResponse) VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMonthlyRevenuedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetMonthlyRevenuedefaultApplicationProblemPlusJSONResponse) VisitGetMonthlyRevenueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTopServicesRequestObject struct {
+	Params GetTopServicesParams
+}
+
+type GetTopServicesResponseObject interface {
+	VisitGetTopServicesResponse(w http.ResponseWriter) error
+}
+
+type GetTopServices200JSONResponse TopServicesResponse
+
+func (response GetTopServices200JSONResponse) VisitGetTopServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTopServices400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetTopServices400ApplicationProblemPlusJSONResponse) VisitGetTopServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTopServices401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetTopServices401ApplicationProblemPlusJSONResponse) VisitGetTopServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTopServices500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetTopServices500ApplicationProblemPlusJSONResponse) VisitGetTopServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTopServicesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetTopServicesdefaultApplicationProblemPlusJSONResponse) VisitGetTopServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateBudgetRequestObject struct {
+	Body *CreateBudgetJSONRequestBody
+}
+
+type CreateBudgetResponseObject interface {
+	VisitCreateBudgetResponse(w http.ResponseWriter) error
+}
+
+type CreateBudget201JSONResponse Budget
+
+func (response CreateBudget201JSONResponse) VisitCreateBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBudget400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBudget400ApplicationProblemPlusJSONResponse) VisitCreateBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBudget500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBudget500ApplicationProblemPlusJSONResponse) VisitCreateBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBudgetdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateBudgetdefaultApplicationProblemPlusJSONResponse) VisitCreateBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteBudgetRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteBudgetResponseObject interface {
+	VisitDeleteBudgetResponse(w http.ResponseWriter) error
+}
+
+type DeleteBudget200JSONResponse SuccessResponse
+
+func (response DeleteBudget200JSONResponse) VisitDeleteBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteBudget404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteBudget404ApplicationProblemPlusJSONResponse) VisitDeleteBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteBudget500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteBudget500ApplicationProblemPlusJSONResponse) VisitDeleteBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteBudgetdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteBudgetdefaultApplicationProblemPlusJSONResponse) VisitDeleteBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetBudgetRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetBudgetResponseObject interface {
+	VisitGetBudgetResponse(w http.ResponseWriter) error
+}
+
+type GetBudget200JSONResponse Budget
+
+func (response GetBudget200JSONResponse) VisitGetBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudget404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBudget404ApplicationProblemPlusJSONResponse) VisitGetBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudget500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBudget500ApplicationProblemPlusJSONResponse) VisitGetBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudgetdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetBudgetdefaultApplicationProblemPlusJSONResponse) VisitGetBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReplaceBudgetRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *ReplaceBudgetJSONRequestBody
+}
+
+type ReplaceBudgetResponseObject interface {
+	VisitReplaceBudgetResponse(w http.ResponseWriter) error
+}
+
+type ReplaceBudget200JSONResponse Budget
+
+func (response ReplaceBudget200JSONResponse) VisitReplaceBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceBudget400ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceBudget400ApplicationProblemPlusJSONResponse) VisitReplaceBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceBudget404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceBudget404ApplicationProblemPlusJSONResponse) VisitReplaceBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceBudget500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceBudget500ApplicationProblemPlusJSONResponse) VisitReplaceBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceBudgetdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReplaceBudgetdefaultApplicationProblemPlusJSONResponse) VisitReplaceBudgetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ImportExchangeRatesRequestObject struct {
+	Body io.Reader
+}
+
+type ImportExchangeRatesResponseObject interface {
+	VisitImportExchangeRatesResponse(w http.ResponseWriter) error
+}
+
+type ImportExchangeRates200JSONResponse ExchangeRatesImport
+
+func (response ImportExchangeRates200JSONResponse) VisitImportExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportExchangeRates400ApplicationProblemPlusJSONResponse Problem
+
+func (response ImportExchangeRates400ApplicationProblemPlusJSONResponse) VisitImportExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportExchangeRates401ApplicationProblemPlusJSONResponse Problem
+
+func (response ImportExchangeRates401ApplicationProblemPlusJSONResponse) VisitImportExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportExchangeRates500ApplicationProblemPlusJSONResponse Problem
+
+func (response ImportExchangeRates500ApplicationProblemPlusJSONResponse) VisitImportExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportExchangeRatesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ImportExchangeRatesdefaultApplicationProblemPlusJSONResponse) VisitImportExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListServicesRequestObject struct {
+	Params ListServicesParams
+}
+
+type ListServicesResponseObject interface {
+	VisitListServicesResponse(w http.ResponseWriter) error
+}
+
+type ListServices200JSONResponse ServiceList
+
+func (response ListServices200JSONResponse) VisitListServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServices500ApplicationProblemPlusJSONResponse Problem
+
+func (response ListServices500ApplicationProblemPlusJSONResponse) VisitListServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServicesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListServicesdefaultApplicationProblemPlusJSONResponse) VisitListServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateServiceRequestObject struct {
+	Body *CreateServiceJSONRequestBody
+}
+
+type CreateServiceResponseObject interface {
+	VisitCreateServiceResponse(w http.ResponseWriter) error
+}
+
+type CreateService201JSONResponse Service
+
+func (response CreateService201JSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateService400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService400ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateService401ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService401ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateService409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService409ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateService500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService500ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateServicedefaultApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteServiceRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteServiceResponseObject interface {
+	VisitDeleteServiceResponse(w http.ResponseWriter) error
+}
+
+type DeleteService200JSONResponse SuccessResponse
+
+func (response DeleteService200JSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteService401ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteService401ApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteService404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteService404ApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteService409ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteService409ApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteService500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteService500ApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteServicedefaultApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetServiceRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetServiceResponseObject interface {
+	VisitGetServiceResponse(w http.ResponseWriter) error
+}
+
+type GetService200JSONResponse Service
+
+func (response GetService200JSONResponse) VisitGetServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetService404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetService404ApplicationProblemPlusJSONResponse) VisitGetServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetService500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetService500ApplicationProblemPlusJSONResponse) VisitGetServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetServicedefaultApplicationProblemPlusJSONResponse) VisitGetServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReplaceServiceRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *ReplaceServiceJSONRequestBody
+}
+
+type ReplaceServiceResponseObject interface {
+	VisitReplaceServiceResponse(w http.ResponseWriter) error
+}
+
+type ReplaceService200JSONResponse Service
+
+func (response ReplaceService200JSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceService400ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceService400ApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceService401ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceService401ApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceService404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceService404ApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceService409ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceService409ApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceService500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceService500ApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReplaceServicedefaultApplicationProblemPlusJSONResponse) VisitReplaceServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReadAllSubscriptionsRequestObject struct {
+	Params ReadAllSubscriptionsParams
+}
+
+type ReadAllSubscriptionsResponseObject interface {
+	VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error
+}
+
+type ReadAllSubscriptions200ResponseHeaders struct {
+	XNextCursor string
+	XTotalCount int
+}
+
+type ReadAllSubscriptions200JSONResponse struct {
+	Body    []Subscription
+	Headers ReadAllSubscriptions200ResponseHeaders
+}
+
+func (response ReadAllSubscriptions200JSONResponse) VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReadAllSubscriptions500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadAllSubscriptions500ApplicationProblemPlusJSONResponse) VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadAllSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadAllSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitReadAllSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateSubscriptionRequestObject struct {
+	Body *CreateSubscriptionJSONRequestBody
+}
+
+type CreateSubscriptionResponseObject interface {
+	VisitCreateSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type CreateSubscription201JSONResponse Subscription
+
+func (response CreateSubscription201JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateSubscription400ApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateSubscription409ApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateSubscription500ApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CalculateMonthlyCostsRequestObject struct {
+	Params CalculateMonthlyCostsParams
+}
+
+type CalculateMonthlyCostsResponseObject interface {
+	VisitCalculateMonthlyCostsResponse(w http.ResponseWriter) error
+}
+
+type CalculateMonthlyCosts200JSONResponse MonthlyCostsResponse
+
+func (response CalculateMonthlyCosts200JSONResponse) VisitCalculateMonthlyCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateMonthlyCosts400ApplicationProblemPlusJSONResponse Problem
+
+func (response CalculateMonthlyCosts400ApplicationProblemPlusJSONResponse) VisitCalculateMonthlyCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateMonthlyCosts500ApplicationProblemPlusJSONResponse Problem
+
+func (response CalculateMonthlyCosts500ApplicationProblemPlusJSONResponse) VisitCalculateMonthlyCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateMonthlyCostsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CalculateMonthlyCostsdefaultApplicationProblemPlusJSONResponse) VisitCalculateMonthlyCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ForecastCostsRequestObject struct {
+	Params ForecastCostsParams
+}
+
+type ForecastCostsResponseObject interface {
+	VisitForecastCostsResponse(w http.ResponseWriter) error
+}
+
+type ForecastCosts200JSONResponse ForecastResponse
+
+func (response ForecastCosts200JSONResponse) VisitForecastCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForecastCosts400ApplicationProblemPlusJSONResponse Problem
+
+func (response ForecastCosts400ApplicationProblemPlusJSONResponse) VisitForecastCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForecastCosts500ApplicationProblemPlusJSONResponse Problem
+
+func (response ForecastCosts500ApplicationProblemPlusJSONResponse) VisitForecastCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForecastCostsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ForecastCostsdefaultApplicationProblemPlusJSONResponse) VisitForecastCostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CalculateTotalCostRequestObject struct {
+	Params CalculateTotalCostParams
+}
+
+type CalculateTotalCostResponseObject interface {
+	VisitCalculateTotalCostResponse(w http.ResponseWriter) error
+}
+
+type CalculateTotalCost200JSONResponse TotalCostResponse
+
+func (response CalculateTotalCost200JSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateTotalCost400ApplicationProblemPlusJSONResponse Problem
+
+func (response CalculateTotalCost400ApplicationProblemPlusJSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateTotalCost500ApplicationProblemPlusJSONResponse Problem
+
+func (response CalculateTotalCost500ApplicationProblemPlusJSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateTotalCostdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CalculateTotalCostdefaultApplicationProblemPlusJSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteSubscriptionRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteSubscriptionResponseObject interface {
+	VisitDeleteSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type DeleteSubscription200JSONResponse SuccessResponse
+
+func (response DeleteSubscription200JSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteSubscription404ApplicationProblemPlusJSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteSubscription500ApplicationProblemPlusJSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSubscriptionRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetSubscriptionResponseObject interface {
+	VisitGetSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type GetSubscription200JSONResponse Subscription
+
+func (response GetSubscription200JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetSubscription404ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetSubscription500ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchSubscriptionRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PatchSubscriptionApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchSubscriptionResponseObject interface {
+	VisitPatchSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type PatchSubscription200JSONResponse Subscription
+
+func (response PatchSubscription200JSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscription400ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchSubscription400ApplicationProblemPlusJSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchSubscription404ApplicationProblemPlusJSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchSubscription409ApplicationProblemPlusJSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchSubscription500ApplicationProblemPlusJSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response PatchSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitPatchSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReplaceSubscriptionRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *ReplaceSubscriptionJSONRequestBody
+}
+
+type ReplaceSubscriptionResponseObject interface {
+	VisitReplaceSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type ReplaceSubscription200JSONResponse Subscription
+
+func (response ReplaceSubscription200JSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceSubscription400ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription400ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription404ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription409ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription500ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CancelSubscriptionRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *CancelSubscriptionJSONRequestBody
+}
+
+type CancelSubscriptionResponseObject interface {
+	VisitCancelSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type CancelSubscription200JSONResponse Subscription
+
+func (response CancelSubscription200JSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription400ApplicationProblemPlusJSONResponse Problem
+
+func (response CancelSubscription400ApplicationProblemPlusJSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response CancelSubscription404ApplicationProblemPlusJSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response CancelSubscription409ApplicationProblemPlusJSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response CancelSubscription500ApplicationProblemPlusJSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CancelSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReadSubscriptionHistoryRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params ReadSubscriptionHistoryParams
+}
+
+type ReadSubscriptionHistoryResponseObject interface {
+	VisitReadSubscriptionHistoryResponse(w http.ResponseWriter) error
+}
+
+type ReadSubscriptionHistory200JSONResponse SubscriptionEventPage
+
+func (response ReadSubscriptionHistory200JSONResponse) VisitReadSubscriptionHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionHistory400ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadSubscriptionHistory400ApplicationProblemPlusJSONResponse) VisitReadSubscriptionHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionHistory500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadSubscriptionHistory500ApplicationProblemPlusJSONResponse) VisitReadSubscriptionHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionHistorydefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadSubscriptionHistorydefaultApplicationProblemPlusJSONResponse) VisitReadSubscriptionHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReadSubscriptionPricesRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type ReadSubscriptionPricesResponseObject interface {
+	VisitReadSubscriptionPricesResponse(w http.ResponseWriter) error
+}
+
+type ReadSubscriptionPrices200JSONResponse PriceHistory
+
+func (response ReadSubscriptionPrices200JSONResponse) VisitReadSubscriptionPricesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionPrices404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadSubscriptionPrices404ApplicationProblemPlusJSONResponse) VisitReadSubscriptionPricesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionPrices500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadSubscriptionPrices500ApplicationProblemPlusJSONResponse) VisitReadSubscriptionPricesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadSubscriptionPricesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadSubscriptionPricesdefaultApplicationProblemPlusJSONResponse) VisitReadSubscriptionPricesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ScheduleSubscriptionPriceRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *ScheduleSubscriptionPriceJSONRequestBody
+}
+
+type ScheduleSubscriptionPriceResponseObject interface {
+	VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error
+}
+
+type ScheduleSubscriptionPrice200JSONResponse PriceHistory
+
+func (response ScheduleSubscriptionPrice200JSONResponse) VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleSubscriptionPrice400ApplicationProblemPlusJSONResponse Problem
+
+func (response ScheduleSubscriptionPrice400ApplicationProblemPlusJSONResponse) VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleSubscriptionPrice404ApplicationProblemPlusJSONResponse Problem
+
+func (response ScheduleSubscriptionPrice404ApplicationProblemPlusJSONResponse) VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleSubscriptionPrice500ApplicationProblemPlusJSONResponse Problem
+
+func (response ScheduleSubscriptionPrice500ApplicationProblemPlusJSONResponse) VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleSubscriptionPricedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ScheduleSubscriptionPricedefaultApplicationProblemPlusJSONResponse) VisitScheduleSubscriptionPriceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RenewSubscriptionRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *RenewSubscriptionJSONRequestBody
+}
+
+type RenewSubscriptionResponseObject interface {
+	VisitRenewSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type RenewSubscription200JSONResponse Subscription
+
+func (response RenewSubscription200JSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenewSubscription400ApplicationProblemPlusJSONResponse Problem
+
+func (response RenewSubscription400ApplicationProblemPlusJSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenewSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response RenewSubscription404ApplicationProblemPlusJSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenewSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response RenewSubscription409ApplicationProblemPlusJSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenewSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response RenewSubscription500ApplicationProblemPlusJSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenewSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RenewSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitRenewSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RestoreSubscriptionRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type RestoreSubscriptionResponseObject interface {
+	VisitRestoreSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type RestoreSubscription200JSONResponse Subscription
+
+func (response RestoreSubscription200JSONResponse) VisitRestoreSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response RestoreSubscription404ApplicationProblemPlusJSONResponse) VisitRestoreSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response RestoreSubscription409ApplicationProblemPlusJSONResponse) VisitRestoreSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreSubscription500ApplicationProblemPlusJSONResponse Problem
+
+func (response RestoreSubscription500ApplicationProblemPlusJSONResponse) VisitRestoreSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RestoreSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitRestoreSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTagsRequestObject struct {
+	Params ListTagsParams
+}
+
+type ListTagsResponseObject interface {
+	VisitListTagsResponse(w http.ResponseWriter) error
+}
+
+type ListTags200JSONResponse TagList
+
+func (response ListTags200JSONResponse) VisitListTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTags500ApplicationProblemPlusJSONResponse Problem
+
+func (response ListTags500ApplicationProblemPlusJSONResponse) VisitListTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTagsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListTagsdefaultApplicationProblemPlusJSONResponse) VisitListTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateTagRequestObject struct {
+	Body *CreateTagJSONRequestBody
+}
+
+type CreateTagResponseObject interface {
+	VisitCreateTagResponse(w http.ResponseWriter) error
+}
+
+type CreateTag201JSONResponse Tag
+
+func (response CreateTag201JSONResponse) VisitCreateTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTag400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateTag400ApplicationProblemPlusJSONResponse) VisitCreateTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTag409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateTag409ApplicationProblemPlusJSONResponse) VisitCreateTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTag500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateTag500ApplicationProblemPlusJSONResponse) VisitCreateTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTagdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateTagdefaultApplicationProblemPlusJSONResponse) VisitCreateTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteTagRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteTagResponseObject interface {
+	VisitDeleteTagResponse(w http.ResponseWriter) error
+}
+
+type DeleteTag200JSONResponse SuccessResponse
+
+func (response DeleteTag200JSONResponse) VisitDeleteTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTag404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteTag404ApplicationProblemPlusJSONResponse) VisitDeleteTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTag500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteTag500ApplicationProblemPlusJSONResponse) VisitDeleteTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTagdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteTagdefaultApplicationProblemPlusJSONResponse) VisitDeleteTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RenameTagRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *RenameTagJSONRequestBody
+}
+
+type RenameTagResponseObject interface {
+	VisitRenameTagResponse(w http.ResponseWriter) error
+}
+
+type RenameTag200JSONResponse Tag
+
+func (response RenameTag200JSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag400ApplicationProblemPlusJSONResponse Problem
+
+func (response RenameTag400ApplicationProblemPlusJSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag404ApplicationProblemPlusJSONResponse Problem
+
+func (response RenameTag404ApplicationProblemPlusJSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag409ApplicationProblemPlusJSONResponse Problem
+
+func (response RenameTag409ApplicationProblemPlusJSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag500ApplicationProblemPlusJSONResponse Problem
+
+func (response RenameTag500ApplicationProblemPlusJSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTagdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RenameTagdefaultApplicationProblemPlusJSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type MergeTagsRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *MergeTagsJSONRequestBody
+}
+
+type MergeTagsResponseObject interface {
+	VisitMergeTagsResponse(w http.ResponseWriter) error
+}
+
+type MergeTags200JSONResponse Tag
+
+func (response MergeTags200JSONResponse) VisitMergeTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTags400ApplicationProblemPlusJSONResponse Problem
+
+func (response MergeTags400ApplicationProblemPlusJSONResponse) VisitMergeTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTags404ApplicationProblemPlusJSONResponse Problem
+
+func (response MergeTags404ApplicationProblemPlusJSONResponse) VisitMergeTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTags500ApplicationProblemPlusJSONResponse Problem
+
+func (response MergeTags500ApplicationProblemPlusJSONResponse) VisitMergeTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTagsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response MergeTagsdefaultApplicationProblemPlusJSONResponse) VisitMergeTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListBudgetsRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type ListBudgetsResponseObject interface {
+	VisitListBudgetsResponse(w http.ResponseWriter) error
+}
+
+type ListBudgets200JSONResponse BudgetList
+
+func (response ListBudgets200JSONResponse) VisitListBudgetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListBudgets500ApplicationProblemPlusJSONResponse Problem
+
+func (response ListBudgets500ApplicationProblemPlusJSONResponse) VisitListBudgetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListBudgetsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListBudgetsdefaultApplicationProblemPlusJSONResponse) VisitListBudgetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetBudgetStatusRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetBudgetStatusParams
+}
+
+type GetBudgetStatusResponseObject interface {
+	VisitGetBudgetStatusResponse(w http.ResponseWriter) error
+}
+
+type GetBudgetStatus200JSONResponse BudgetStatusList
+
+func (response GetBudgetStatus200JSONResponse) VisitGetBudgetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudgetStatus400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBudgetStatus400ApplicationProblemPlusJSONResponse) VisitGetBudgetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudgetStatus500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBudgetStatus500ApplicationProblemPlusJSONResponse) VisitGetBudgetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBudgetStatusdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetBudgetStatusdefaultApplicationProblemPlusJSONResponse) VisitGetBudgetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReadUserHistoryRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params ReadUserHistoryParams
+}
+
+type ReadUserHistoryResponseObject interface {
+	VisitReadUserHistoryResponse(w http.ResponseWriter) error
+}
+
+type ReadUserHistory200JSONResponse SubscriptionEventPage
+
+func (response ReadUserHistory200JSONResponse) VisitReadUserHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadUserHistory400ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadUserHistory400ApplicationProblemPlusJSONResponse) VisitReadUserHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadUserHistory500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadUserHistory500ApplicationProblemPlusJSONResponse) VisitReadUserHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadUserHistorydefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadUserHistorydefaultApplicationProblemPlusJSONResponse) VisitReadUserHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Subscription activity
+	// (GET /analytics/activity)
+	GetSubscriptionActivity(ctx context.Context, request GetSubscriptionActivityRequestObject) (GetSubscriptionActivityResponseObject, error)
+	// Monthly recurring revenue
+	// (GET /analytics/revenue)
+	GetMonthlyRevenue(ctx context.Context, request GetMonthlyRevenueRequestObject) (GetMonthlyRevenueResponseObject, error)
+	// Top services by spend
+	// (GET /analytics/top-services)
+	GetTopServices(ctx context.Context, request GetTopServicesRequestObject) (GetTopServicesResponseObject, error)
+	// Create a budget
+	// (POST /budgets)
+	CreateBudget(ctx context.Context, request CreateBudgetRequestObject) (CreateBudgetResponseObject, error)
+	// Delete a budget
+	// (DELETE /budgets/{id})
+	DeleteBudget(ctx context.Context, request DeleteBudgetRequestObject) (DeleteBudgetResponseObject, error)
+	// Get a budget
+	// (GET /budgets/{id})
+	GetBudget(ctx context.Context, request GetBudgetRequestObject) (GetBudgetResponseObject, error)
+	// Replace the limit, period and scope of a budget
+	// (PUT /budgets/{id})
+	ReplaceBudget(ctx context.Context, request ReplaceBudgetRequestObject) (ReplaceBudgetResponseObject, error)
+	// Import exchange rates
+	// (POST /exchange-rates)
+	ImportExchangeRates(ctx context.Context, request ImportExchangeRatesRequestObject) (ImportExchangeRatesResponseObject, error)
+	// List the catalog
+	// (GET /services)
+	ListServices(ctx context.Context, request ListServicesRequestObject) (ListServicesResponseObject, error)
+	// Add a service to the catalog
+	// (POST /services)
+	CreateService(ctx context.Context, request CreateServiceRequestObject) (CreateServiceResponseObject, error)
+	// Remove a service from the catalog
+	// (DELETE /services/{id})
+	DeleteService(ctx context.Context, request DeleteServiceRequestObject) (DeleteServiceResponseObject, error)
+	// Get a catalog service
+	// (GET /services/{id})
+	GetService(ctx context.Context, request GetServiceRequestObject) (GetServiceResponseObject, error)
+	// Replace a catalog service
+	// (PUT /services/{id})
+	ReplaceService(ctx context.Context, request ReplaceServiceRequestObject) (ReplaceServiceResponseObject, error)
+	// List of subscriptions
+	// (GET /subscriptions)
+	ReadAllSubscriptions(ctx context.Context, request ReadAllSubscriptionsRequestObject) (ReadAllSubscriptionsResponseObject, error)
+	// Create subscription
+	// (POST /subscriptions)
+	CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error)
+	// Monthly cost breakdown
+	// (GET /subscriptions/costs/monthly)
+	CalculateMonthlyCosts(ctx context.Context, request CalculateMonthlyCostsRequestObject) (CalculateMonthlyCostsResponseObject, error)
+	// Spending forecast
+	// (GET /subscriptions/forecast)
+	ForecastCosts(ctx context.Context, request ForecastCostsRequestObject) (ForecastCostsResponseObject, error)
+	// Calculate total subscription cost
+	// (GET /subscriptions/total)
+	CalculateTotalCost(ctx context.Context, request CalculateTotalCostRequestObject) (CalculateTotalCostResponseObject, error)
+	// Delete subscription
+	// (DELETE /subscriptions/{id})
+	DeleteSubscription(ctx context.Context, request DeleteSubscriptionRequestObject) (DeleteSubscriptionResponseObject, error)
+	// Get subscription by ID
+	// (GET /subscriptions/{id})
+	GetSubscription(ctx context.Context, request GetSubscriptionRequestObject) (GetSubscriptionResponseObject, error)
+	// Partially update subscription
+	// (PATCH /subscriptions/{id})
+	PatchSubscription(ctx context.Context, request PatchSubscriptionRequestObject) (PatchSubscriptionResponseObject, error)
+	// Replace subscription
+	// (PUT /subscriptions/{id})
+	ReplaceSubscription(ctx context.Context, request ReplaceSubscriptionRequestObject) (ReplaceSubscriptionResponseObject, error)
+	// Cancel a subscription
+	// (POST /subscriptions/{id}/cancel)
+	CancelSubscription(ctx context.Context, request CancelSubscriptionRequestObject) (CancelSubscriptionResponseObject, error)
+	// Change history of a subscription
+	// (GET /subscriptions/{id}/history)
+	ReadSubscriptionHistory(ctx context.Context, request ReadSubscriptionHistoryRequestObject) (ReadSubscriptionHistoryResponseObject, error)
+	// Price history of a subscription
+	// (GET /subscriptions/{id}/prices)
+	ReadSubscriptionPrices(ctx context.Context, request ReadSubscriptionPricesRequestObject) (ReadSubscriptionPricesResponseObject, error)
+	// Schedule a price change
+	// (POST /subscriptions/{id}/prices)
+	ScheduleSubscriptionPrice(ctx context.Context, request ScheduleSubscriptionPriceRequestObject) (ScheduleSubscriptionPriceResponseObject, error)
+	// Renew a subscription
+	// (POST /subscriptions/{id}/renew)
+	RenewSubscription(ctx context.Context, request RenewSubscriptionRequestObject) (RenewSubscriptionResponseObject, error)
+	// Restore a deleted subscription
+	// (POST /subscriptions/{id}/restore)
+	RestoreSubscription(ctx context.Context, request RestoreSubscriptionRequestObject) (RestoreSubscriptionResponseObject, error)
+	// List the tags of a user
+	// (GET /tags)
+	ListTags(ctx context.Context, request ListTagsRequestObject) (ListTagsResponseObject, error)
+	// Create a tag
+	// (POST /tags)
+	CreateTag(ctx context.Context, request CreateTagRequestObject) (CreateTagResponseObject, error)
+	// Delete a tag
+	// (DELETE /tags/{id})
+	DeleteTag(ctx context.Context, request DeleteTagRequestObject) (DeleteTagResponseObject, error)
+	// Rename a tag
+	// (PUT /tags/{id})
+	RenameTag(ctx context.Context, request RenameTagRequestObject) (RenameTagResponseObject, error)
+	// Merge a tag into another one
+	// (POST /tags/{id}/merge)
+	MergeTags(ctx context.Context, request MergeTagsRequestObject) (MergeTagsResponseObject, error)
+	// List the budgets of a user
+	// (GET /users/{id}/budgets)
+	ListBudgets(ctx context.Context, request ListBudgetsRequestObject) (ListBudgetsResponseObject, error)
+	// Spend of every budget of a user against its limit
+	// (GET /users/{id}/budgets/status)
+	GetBudgetStatus(ctx context.Context, request GetBudgetStatusRequestObject) (GetBudgetStatusResponseObject, error)
+	// Change history of all subscriptions of a user
+	// (GET /users/{id}/history)
+	ReadUserHistory(ctx context.Context, request ReadUserHistoryRequestObject) (ReadUserHistoryResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetSubscriptionActivity operation middleware
+func (sh *strictHandler) GetSubscriptionActivity(w http.ResponseWriter, r *http.Request, params GetSubscriptionActivityParams) {
+	var request GetSubscriptionActivityRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionActivity(ctx, request.(GetSubscriptionActivityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionActivity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSubscriptionActivityResponseObject); ok {
+		if err := validResponse.VisitGetSubscriptionActivityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMonthlyRevenue operation middleware
+func (sh *strictHandler) GetMonthlyRevenue(w http.ResponseWriter, r *http.Request, params GetMonthlyRevenueParams) {
+	var request GetMonthlyRevenueRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMonthlyRevenue(ctx, request.(GetMonthlyRevenueRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMonthlyRevenue")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMonthlyRevenueResponseObject); ok {
+		if err := validResponse.VisitGetMonthlyRevenueResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTopServices operation middleware
+func (sh *strictHandler) GetTopServices(w http.ResponseWriter, r *http.Request, params GetTopServicesParams) {
+	var request GetTopServicesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTopServices(ctx, request.(GetTopServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTopServices")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTopServicesResponseObject); ok {
+		if err := validResponse.VisitGetTopServicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateBudget operation middleware
+func (sh *strictHandler) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	var request CreateBudgetRequestObject
+
+	var body CreateBudgetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateBudget(ctx, request.(CreateBudgetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateBudget")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateBudgetResponseObject); ok {
+		if err := validResponse.VisitCreateBudgetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteBudget operation middleware
+func (sh *strictHandler) DeleteBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteBudgetRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteBudget(ctx, request.(DeleteBudgetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteBudget")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteBudgetResponseObject); ok {
+		if err := validResponse.VisitDeleteBudgetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBudget operation middleware
+func (sh *strictHandler) GetBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetBudgetRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBudget(ctx, request.(GetBudgetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBudget")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBudgetResponseObject); ok {
+		if err := validResponse.VisitGetBudgetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceBudget operation middleware
+func (sh *strictHandler) ReplaceBudget(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ReplaceBudgetRequestObject
+
+	request.Id = id
+
+	var body ReplaceBudgetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceBudget(ctx, request.(ReplaceBudgetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceBudget")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceBudgetResponseObject); ok {
+		if err := validResponse.VisitReplaceBudgetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportExchangeRates operation middleware
+func (sh *strictHandler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var request ImportExchangeRatesRequestObject
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportExchangeRates(ctx, request.(ImportExchangeRatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportExchangeRates")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportExchangeRatesResponseObject); ok {
+		if err := validResponse.VisitImportExchangeRatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListServices operation middleware
+func (sh *strictHandler) ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams) {
+	var request ListServicesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListServices(ctx, request.(ListServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListServices")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListServicesResponseObject); ok {
+		if err := validResponse.VisitListServicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateService operation middleware
+func (sh *strictHandler) CreateService(w http.ResponseWriter, r *http.Request) {
+	var request CreateServiceRequestObject
+
+	var body CreateServiceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateService(ctx, request.(CreateServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateService")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateServiceResponseObject); ok {
+		if err := validResponse.VisitCreateServiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteService operation middleware
+func (sh *strictHandler) DeleteService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteServiceRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteService(ctx, request.(DeleteServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteService")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteServiceResponseObject); ok {
+		if err := validResponse.VisitDeleteServiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetService operation middleware
+func (sh *strictHandler) GetService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetServiceRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetService(ctx, request.(GetServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetService")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetServiceResponseObject); ok {
+		if err := validResponse.VisitGetServiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceService operation middleware
+func (sh *strictHandler) ReplaceService(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ReplaceServiceRequestObject
+
+	request.Id = id
+
+	var body ReplaceServiceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceService(ctx, request.(ReplaceServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceService")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceServiceResponseObject); ok {
+		if err := validResponse.VisitReplaceServiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReadAllSubscriptions operation middleware
+func (sh *strictHandler) ReadAllSubscriptions(w http.ResponseWriter, r *http.Request, params ReadAllSubscriptionsParams) {
+	var request ReadAllSubscriptionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadAllSubscriptions(ctx, request.(ReadAllSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadAllSubscriptions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadAllSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitReadAllSubscriptionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateSubscription500JSONResponse ErrorResponse
+// CreateSubscription operation middleware
+func (sh *strictHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var request CreateSubscriptionRequestObject
 
-func (response CreateSubscription500JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var body CreateSubscriptionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSubscription(ctx, request.(CreateSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateSubscriptionResponseObject); ok {
+		if err := validResponse.VisitCreateSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CalculateMonthlyCosts operation middleware
+func (sh *strictHandler) CalculateMonthlyCosts(w http.ResponseWriter, r *http.Request, params CalculateMonthlyCostsParams) {
+	var request CalculateMonthlyCostsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CalculateMonthlyCosts(ctx, request.(CalculateMonthlyCostsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CalculateMonthlyCosts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CalculateMonthlyCostsResponseObject); ok {
+		if err := validResponse.VisitCalculateMonthlyCostsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ForecastCosts operation middleware
+func (sh *strictHandler) ForecastCosts(w http.ResponseWriter, r *http.Request, params ForecastCostsParams) {
+	var request ForecastCostsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ForecastCosts(ctx, request.(ForecastCostsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ForecastCosts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ForecastCostsResponseObject); ok {
+		if err := validResponse.VisitForecastCostsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CalculateTotalCost operation middleware
+func (sh *strictHandler) CalculateTotalCost(w http.ResponseWriter, r *http.Request, params CalculateTotalCostParams) {
+	var request CalculateTotalCostRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CalculateTotalCost(ctx, request.(CalculateTotalCostRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CalculateTotalCost")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CalculateTotalCostResponseObject); ok {
+		if err := validResponse.VisitCalculateTotalCostResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteSubscription operation middleware
+func (sh *strictHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteSubscriptionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSubscription(ctx, request.(DeleteSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteSubscriptionResponseObject); ok {
+		if err := validResponse.VisitDeleteSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSubscription operation middleware
+func (sh *strictHandler) GetSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetSubscriptionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscription(ctx, request.(GetSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSubscriptionResponseObject); ok {
+		if err := validResponse.VisitGetSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchSubscription operation middleware
+func (sh *strictHandler) PatchSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request PatchSubscriptionRequestObject
+
+	request.Id = id
+
+	var body PatchSubscriptionApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchSubscription(ctx, request.(PatchSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchSubscriptionResponseObject); ok {
+		if err := validResponse.VisitPatchSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceSubscription operation middleware
+func (sh *strictHandler) ReplaceSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ReplaceSubscriptionRequestObject
+
+	request.Id = id
+
+	var body ReplaceSubscriptionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceSubscription(ctx, request.(ReplaceSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceSubscriptionResponseObject); ok {
+		if err := validResponse.VisitReplaceSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelSubscription operation middleware
+func (sh *strictHandler) CancelSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request CancelSubscriptionRequestObject
+
+	request.Id = id
+
+	var body CancelSubscriptionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelSubscription(ctx, request.(CancelSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelSubscriptionResponseObject); ok {
+		if err := validResponse.VisitCancelSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReadSubscriptionHistory operation middleware
+func (sh *strictHandler) ReadSubscriptionHistory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReadSubscriptionHistoryParams) {
+	var request ReadSubscriptionHistoryRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadSubscriptionHistory(ctx, request.(ReadSubscriptionHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadSubscriptionHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadSubscriptionHistoryResponseObject); ok {
+		if err := validResponse.VisitReadSubscriptionHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CalculateTotalCostRequestObject struct {
-	Params CalculateTotalCostParams
-}
+// ReadSubscriptionPrices operation middleware
+func (sh *strictHandler) ReadSubscriptionPrices(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ReadSubscriptionPricesRequestObject
 
-type CalculateTotalCostResponseObject interface {
-	VisitCalculateTotalCostResponse(w http.ResponseWriter) error
-}
+	request.Id = id
 
-type CalculateTotalCost200JSONResponse TotalCostResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadSubscriptionPrices(ctx, request.(ReadSubscriptionPricesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadSubscriptionPrices")
+	}
 
-func (response CalculateTotalCost200JSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadSubscriptionPricesResponseObject); ok {
+		if err := validResponse.VisitReadSubscriptionPricesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CalculateTotalCost400JSONResponse ErrorResponse
+// ScheduleSubscriptionPrice operation middleware
+func (sh *strictHandler) ScheduleSubscriptionPrice(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ScheduleSubscriptionPriceRequestObject
 
-func (response CalculateTotalCost400JSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ScheduleSubscriptionPriceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type CalculateTotalCost500JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ScheduleSubscriptionPrice(ctx, request.(ScheduleSubscriptionPriceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ScheduleSubscriptionPrice")
+	}
 
-func (response CalculateTotalCost500JSONResponse) VisitCalculateTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ScheduleSubscriptionPriceResponseObject); ok {
+		if err := validResponse.VisitScheduleSubscriptionPriceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteSubscriptionRequestObject struct {
-	Id openapi_types.UUID `json:"id"`
-}
+// RenewSubscription operation middleware
+func (sh *strictHandler) RenewSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request RenewSubscriptionRequestObject
 
-type DeleteSubscriptionResponseObject interface {
-	VisitDeleteSubscriptionResponse(w http.ResponseWriter) error
-}
+	request.Id = id
 
-type DeleteSubscription200JSONResponse SuccessResponse
+	var body RenewSubscriptionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response DeleteSubscription200JSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RenewSubscription(ctx, request.(RenewSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RenewSubscription")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteSubscription404JSONResponse ErrorResponse
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RenewSubscriptionResponseObject); ok {
+		if err := validResponse.VisitRenewSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response DeleteSubscription404JSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// RestoreSubscription operation middleware
+func (sh *strictHandler) RestoreSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request RestoreSubscriptionRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
 
-type DeleteSubscription500JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreSubscription(ctx, request.(RestoreSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreSubscription")
+	}
 
-func (response DeleteSubscription500JSONResponse) VisitDeleteSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreSubscriptionResponseObject); ok {
+		if err := validResponse.VisitRestoreSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetSubscriptionRequestObject struct {
-	Id openapi_types.UUID `json:"id"`
-}
+// ListTags operation middleware
+func (sh *strictHandler) ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams) {
+	var request ListTagsRequestObject
 
-type GetSubscriptionResponseObject interface {
-	VisitGetSubscriptionResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type GetSubscription200JSONResponse Subscription
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTags(ctx, request.(ListTagsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTags")
+	}
 
-func (response GetSubscription200JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTagsResponseObject); ok {
+		if err := validResponse.VisitListTagsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetSubscription404JSONResponse ErrorResponse
+// CreateTag operation middleware
+func (sh *strictHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var request CreateTagRequestObject
 
-func (response GetSubscription404JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateTagJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetSubscription500JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTag(ctx, request.(CreateTagRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTag")
+	}
 
-func (response GetSubscription500JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTagResponseObject); ok {
+		if err := validResponse.VisitCreateTagResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// List of subscriptions
-	// (GET /subscriptions)
-	ReadAllSubscriptions(ctx context.Context, request ReadAllSubscriptionsRequestObject) (ReadAllSubscriptionsResponseObject, error)
-	// Create subscription
-	// (POST /subscriptions)
-	CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error)
-	// Calculate total subscription cost
-	// (GET /subscriptions/total)
-	CalculateTotalCost(ctx context.Context, request CalculateTotalCostRequestObject) (CalculateTotalCostResponseObject, error)
-	// Delete subscription
-	// (DELETE /subscriptions/{id})
-	DeleteSubscription(ctx context.Context, request DeleteSubscriptionRequestObject) (DeleteSubscriptionResponseObject, error)
-	// Get subscription by ID
-	// (GET /subscriptions/{id})
-	GetSubscription(ctx context.Context, request GetSubscriptionRequestObject) (GetSubscriptionResponseObject, error)
-}
+// DeleteTag operation middleware
+func (sh *strictHandler) DeleteTag(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteTagRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	request.Id = id
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTag(ctx, request.(DeleteTagRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTag")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTagResponseObject); ok {
+		if err := validResponse.VisitDeleteTagResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// RenameTag operation middleware
+func (sh *strictHandler) RenameTag(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request RenameTagRequestObject
 
-// ReadAllSubscriptions operation middleware
-func (sh *strictHandler) ReadAllSubscriptions(w http.ResponseWriter, r *http.Request, params ReadAllSubscriptionsParams) {
-	var request ReadAllSubscriptionsRequestObject
+	request.Id = id
 
-	request.Params = params
+	var body RenameTagJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadAllSubscriptions(ctx, request.(ReadAllSubscriptionsRequestObject))
+		return sh.ssi.RenameTag(ctx, request.(RenameTagRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadAllSubscriptions")
+		handler = middleware(handler, "RenameTag")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadAllSubscriptionsResponseObject); ok {
-		if err := validResponse.VisitReadAllSubscriptionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(RenameTagResponseObject); ok {
+		if err := validResponse.VisitRenameTagResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -647,11 +5283,13 @@ func (sh *strictHandler) ReadAllSubscriptions(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// CreateSubscription operation middleware
-func (sh *strictHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
-	var request CreateSubscriptionRequestObject
+// MergeTags operation middleware
+func (sh *strictHandler) MergeTags(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request MergeTagsRequestObject
 
-	var body CreateSubscriptionJSONRequestBody
+	request.Id = id
+
+	var body MergeTagsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -659,18 +5297,18 @@ func (sh *strictHandler) CreateSubscription(w http.ResponseWriter, r *http.Reque
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateSubscription(ctx, request.(CreateSubscriptionRequestObject))
+		return sh.ssi.MergeTags(ctx, request.(MergeTagsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateSubscription")
+		handler = middleware(handler, "MergeTags")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateSubscriptionResponseObject); ok {
-		if err := validResponse.VisitCreateSubscriptionResponse(w); err != nil {
+	} else if validResponse, ok := response.(MergeTagsResponseObject); ok {
+		if err := validResponse.VisitMergeTagsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -678,25 +5316,25 @@ func (sh *strictHandler) CreateSubscription(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// CalculateTotalCost operation middleware
-func (sh *strictHandler) CalculateTotalCost(w http.ResponseWriter, r *http.Request, params CalculateTotalCostParams) {
-	var request CalculateTotalCostRequestObject
+// ListBudgets operation middleware
+func (sh *strictHandler) ListBudgets(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ListBudgetsRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CalculateTotalCost(ctx, request.(CalculateTotalCostRequestObject))
+		return sh.ssi.ListBudgets(ctx, request.(ListBudgetsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CalculateTotalCost")
+		handler = middleware(handler, "ListBudgets")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CalculateTotalCostResponseObject); ok {
-		if err := validResponse.VisitCalculateTotalCostResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListBudgetsResponseObject); ok {
+		if err := validResponse.VisitListBudgetsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -704,25 +5342,26 @@ func (sh *strictHandler) CalculateTotalCost(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// DeleteSubscription operation middleware
-func (sh *strictHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
-	var request DeleteSubscriptionRequestObject
+// GetBudgetStatus operation middleware
+func (sh *strictHandler) GetBudgetStatus(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetBudgetStatusParams) {
+	var request GetBudgetStatusRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteSubscription(ctx, request.(DeleteSubscriptionRequestObject))
+		return sh.ssi.GetBudgetStatus(ctx, request.(GetBudgetStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteSubscription")
+		handler = middleware(handler, "GetBudgetStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteSubscriptionResponseObject); ok {
-		if err := validResponse.VisitDeleteSubscriptionResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetBudgetStatusResponseObject); ok {
+		if err := validResponse.VisitGetBudgetStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -730,25 +5369,26 @@ func (sh *strictHandler) DeleteSubscription(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// GetSubscription operation middleware
-func (sh *strictHandler) GetSubscription(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
-	var request GetSubscriptionRequestObject
+// ReadUserHistory operation middleware
+func (sh *strictHandler) ReadUserHistory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReadUserHistoryParams) {
+	var request ReadUserHistoryRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSubscription(ctx, request.(GetSubscriptionRequestObject))
+		return sh.ssi.ReadUserHistory(ctx, request.(ReadUserHistoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSubscription")
+		handler = middleware(handler, "ReadUserHistory")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetSubscriptionResponseObject); ok {
-		if err := validResponse.VisitGetSubscriptionResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadUserHistoryResponseObject); ok {
+		if err := validResponse.VisitReadUserHistoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) ReadSubscriptionPrices(
+	ctx context.Context,
+	request ReadSubscriptionPricesRequestObject,
+) (ReadSubscriptionPricesResponseObject, error) {
+	history, err := s.subscriptions.PriceHistory(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return ReadSubscriptionPricesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ReadSubscriptionPrices200JSONResponse(toHTTPPriceHistory(history)), nil
+}
+
+func (s *Server) ScheduleSubscriptionPrice(
+	ctx context.Context,
+	request ScheduleSubscriptionPriceRequestObject,
+) (ScheduleSubscriptionPriceResponseObject, error) {
+	effective, err := parseMonth("effective_from", request.Body.EffectiveFrom)
+	if err != nil {
+		return ScheduleSubscriptionPricedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	history, err := s.subscriptions.SchedulePriceChange(ctx, domain.PriceChange{
+		SubscriptionID: uuid.UUID(request.Id),
+		EffectiveFrom:  effective,
+		Price:          request.Body.Price,
+	})
+	if err != nil {
+		return ScheduleSubscriptionPricedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ScheduleSubscriptionPrice200JSONResponse(toHTTPPriceHistory(history)), nil
+}
+
+func toHTTPPriceHistory(history []domain.PriceChange) PriceHistory {
+	items := make([]PriceChange, 0, len(history))
+	for _, change := range history {
+		items = append(items, PriceChange{
+			EffectiveFrom: change.EffectiveFrom.Format("01-2006"),
+			Price:         change.Price,
+		})
+	}
+
+	return PriceHistory{Items: items}
+}
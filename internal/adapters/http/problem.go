@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+)
+
+const problemTypePrefix = "/problems/"
+
+// problemResponse has the same layout as every generated
+// <Operation>defaultApplicationProblemPlusJSONResponse, so handlers can
+// convert it directly.
+type problemResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func errorStatus(kind domain.ErrorKind) int {
+	switch kind {
+	case domain.ErrorKindValidation:
+		return http.StatusBadRequest
+	case domain.ErrorKindNotFound:
+		return http.StatusNotFound
+	case domain.ErrorKindConflict:
+		return http.StatusConflict
+	case domain.ErrorKindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toProblemResponse(ctx context.Context, err error) problemResponse {
+	domainErr, ok := domain.AsError(err)
+	if !ok || domainErr.Kind == domain.ErrorKindInternal {
+		slog.ErrorContext(ctx, "Request failed.", log.ErrorAttr(err), log.RequestID(ctx))
+
+		return newProblem(ctx, http.StatusInternalServerError, "internal_error", "", nil)
+	}
+
+	status := errorStatus(domainErr.Kind)
+	if status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "Request failed.", log.ErrorAttr(err), log.RequestID(ctx))
+	}
+
+	return newProblem(ctx, status, domainErr.Code, domainErr.Message, domainErr.Fields)
+}
+
+func newProblem(
+	ctx context.Context,
+	status int,
+	code string,
+	detail string,
+	fields []domain.FieldError,
+) problemResponse {
+	problem := Problem{
+		Type:   problemTypePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+
+	if detail != "" {
+		problem.Detail = pointer.Ref(detail)
+	}
+
+	if requestID := log.RequestIDValue(ctx); requestID != "" {
+		problem.Instance = pointer.Ref(requestID)
+	}
+
+	if len(fields) > 0 {
+		errs := make([]FieldError, 0, len(fields))
+		for _, field := range fields {
+			errs = append(errs, FieldError{Field: field.Field, Message: field.Message})
+		}
+		problem.Errors = &errs
+	}
+
+	return problemResponse{Body: problem, StatusCode: status}
+}
+
+func writeProblem(w http.ResponseWriter, response problemResponse) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	_ = json.NewEncoder(w).Encode(response.Body)
+}
+
+// ParamErrorHandler renders parameter binding failures of the generated
+// wrappers as problem details instead of plain text.
+func ParamErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		requiredErr *RequiredParamError
+		formatErr   *InvalidParamFormatError
+		unmarshal   *UnmarshalingParamError
+		tooManyErr  *TooManyValuesForParamError
+		headerErr   *RequiredHeaderError
+		cookieErr   *UnescapedCookieParamError
+	)
+
+	code := "invalid_parameter"
+	var field domain.FieldError
+	switch {
+	case errors.As(err, &requiredErr):
+		code = "missing_parameter"
+		field = domain.FieldError{Field: requiredErr.ParamName, Message: "parameter is required"}
+	case errors.As(err, &formatErr):
+		field = domain.FieldError{Field: formatErr.ParamName, Message: formatErr.Err.Error()}
+	case errors.As(err, &unmarshal):
+		field = domain.FieldError{Field: unmarshal.ParamName, Message: unmarshal.Err.Error()}
+	case errors.As(err, &tooManyErr):
+		field = domain.FieldError{Field: tooManyErr.ParamName, Message: "too many values"}
+	case errors.As(err, &headerErr):
+		code = "missing_parameter"
+		field = domain.FieldError{Field: headerErr.ParamName, Message: "header is required"}
+	case errors.As(err, &cookieErr):
+		field = domain.FieldError{Field: cookieErr.ParamName, Message: cookieErr.Err.Error()}
+	default:
+		writeProblem(w, newProblem(r.Context(), http.StatusBadRequest, code, err.Error(), nil))
+		return
+	}
+
+	writeProblem(w, newProblem(
+		r.Context(),
+		http.StatusBadRequest,
+		code,
+		err.Error(),
+		[]domain.FieldError{field},
+	))
+}
+
+// RequestErrorHandler renders request body decoding failures of the strict
+// handler as problem details.
+func RequestErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(
+		w,
+		newProblem(r.Context(), http.StatusBadRequest, "invalid_body", err.Error(), nil),
+	)
+}
+
+// ResponseErrorHandler renders unexpected handler failures as problem details.
+func ResponseErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, toProblemResponse(r.Context(), err))
+}
@@ -0,0 +1,64 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamErrorHandlerRendersProblem(t *testing.T) {
+	t.Parallel()
+
+	handler := httpadapter.RequestID(httpadapter.HandlerWithOptions(
+		httpadapter.NewStrictHandler(httpadapter.NewServer(nil), nil),
+		httpadapter.StdHTTPServerOptions{ErrorHandlerFunc: httpadapter.ParamErrorHandler},
+	))
+
+	tests := []struct {
+		name  string
+		url   string
+		code  string
+		field string
+	}{
+		{
+			name:  "required parameter",
+			url:   "/subscriptions/total?start_date=01-2025",
+			code:  "missing_parameter",
+			field: "user_id",
+		},
+		{
+			name:  "invalid parameter format",
+			url:   "/subscriptions?user_id=not-a-uuid",
+			code:  "invalid_parameter",
+			field: "user_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			request.Header.Set("X-Request-ID", "request-1")
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+			require.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"))
+
+			var problem httpadapter.Problem
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem))
+			require.Equal(t, tt.code, problem.Code)
+			require.Equal(t, http.StatusBadRequest, problem.Status)
+			require.Equal(t, "request-1", *problem.Instance)
+			require.NotNil(t, problem.Errors)
+			require.Equal(t, tt.field, (*problem.Errors)[0].Field)
+		})
+	}
+}
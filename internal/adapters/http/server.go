@@ -2,17 +2,24 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
-	"strings"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 var _ StrictServerInterface = (*Server)(nil)
 
+const maxPageLimit = 100
+
 type Server struct {
 	subscriptions domain.SubscriptionInterface
 }
@@ -30,22 +37,44 @@ func (s *Server) CreateSubscription(
 	request CreateSubscriptionRequestObject,
 ) (CreateSubscriptionResponseObject, error) {
 	subscription, err := toDomainSubscription(request.Body)
+	if err == nil {
+		subscription, err = s.withDefaultPrice(ctx, subscription, request.Body)
+	}
 	if err != nil {
-		return CreateSubscription400JSONResponse{
-			Message: "Invalid request data: " + err.Error(),
-		}, nil
+		return CreateSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 
 	subscription.ID = uuid.New()
 
 	err = s.subscriptions.Create(ctx, subscription)
 	if err != nil {
-		return CreateSubscription500JSONResponse{
-			Message: "Failed to create subscription",
-		}, nil
+		return CreateSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	// The stored name is the catalog name the given one resolved to.
+	subscription, err = s.subscriptions.ReadByID(ctx, subscription.ID)
+	if err != nil {
+		return CreateSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	response := toHTTPSubscription(subscription)
+	// The subscription is created either way, a failed budget check only
+	// leaves the warnings out.
+	warnings, err := s.subscriptions.BudgetWarnings(ctx, subscription)
+	if err != nil {
+		slog.WarnContext(ctx, "Budget check failed.", log.ErrorAttr(err), log.RequestID(ctx))
+	} else if len(warnings) > 0 {
+		items := toHTTPBudgetStatuses(warnings)
+		response.BudgetWarnings = &items
 	}
 
-	return CreateSubscription201JSONResponse(toHTTPSubscription(subscription)), nil
+	return CreateSubscription201JSONResponse(response), nil
 }
 
 func (s *Server) DeleteSubscription(
@@ -54,35 +83,167 @@ func (s *Server) DeleteSubscription(
 ) (DeleteSubscriptionResponseObject, error) {
 	err := s.subscriptions.Delete(ctx, uuid.UUID(request.Id))
 	if err != nil {
-		// Check if error message contains "not found"
-		if strings.Contains(err.Error(), "not found") {
-			return DeleteSubscription404JSONResponse{
-				Message: "Subscription not found",
-			}, nil
-		}
-		slog.Error("Failed to delete subscription", "error", err, "id", request.Id)
-		return DeleteSubscription500JSONResponse{
-			Message: "Failed to delete subscription",
-		}, nil
+		return DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 	return DeleteSubscription200JSONResponse{
 		Message: "Subscription deleted successfully",
 	}, nil
 }
 
+func (s *Server) RestoreSubscription(
+	ctx context.Context,
+	request RestoreSubscriptionRequestObject,
+) (RestoreSubscriptionResponseObject, error) {
+	subscription, err := s.subscriptions.Restore(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return RestoreSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return RestoreSubscription200JSONResponse(toHTTPSubscription(subscription)), nil
+}
+
+func (s *Server) CancelSubscription(
+	ctx context.Context,
+	request CancelSubscriptionRequestObject,
+) (CancelSubscriptionResponseObject, error) {
+	effective, err := parseMonth("effective", request.Body.Effective)
+	if err != nil {
+		return CancelSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	subscription, err := s.subscriptions.Cancel(ctx, uuid.UUID(request.Id), effective)
+	if err != nil {
+		return CancelSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return CancelSubscription200JSONResponse(toHTTPSubscription(subscription)), nil
+}
+
+func (s *Server) RenewSubscription(
+	ctx context.Context,
+	request RenewSubscriptionRequestObject,
+) (RenewSubscriptionResponseObject, error) {
+	openEnded := request.Body.OpenEnded != nil && *request.Body.OpenEnded
+	if openEnded == (request.Body.Months != nil) {
+		return RenewSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, domain.NewValidationError(
+				"invalid_renewal",
+				"renewal term is invalid",
+			).WithFields(domain.FieldError{
+				Field:   "months",
+				Message: "set either months or open_ended",
+			})),
+		), nil
+	}
+
+	subscription, err := s.subscriptions.Renew(ctx, uuid.UUID(request.Id), request.Body.Months)
+	if err != nil {
+		return RenewSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return RenewSubscription200JSONResponse(toHTTPSubscription(subscription)), nil
+}
+
 func (s *Server) GetSubscription(
 	ctx context.Context,
 	request GetSubscriptionRequestObject,
 ) (GetSubscriptionResponseObject, error) {
 	sub, err := s.subscriptions.ReadByID(ctx, uuid.UUID(request.Id))
 	if err != nil {
-		return GetSubscription404JSONResponse{
-			Message: "Subscription not found",
-		}, nil
+		return GetSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 	return GetSubscription200JSONResponse(toHTTPSubscription(sub)), nil
 }
 
+func (s *Server) ReplaceSubscription(
+	ctx context.Context,
+	request ReplaceSubscriptionRequestObject,
+) (ReplaceSubscriptionResponseObject, error) {
+	subscription, err := toDomainSubscription(request.Body)
+	if err == nil {
+		subscription, err = s.withDefaultPrice(ctx, subscription, request.Body)
+	}
+	if err != nil {
+		return ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	subscription.ID = uuid.UUID(request.Id)
+
+	err = s.subscriptions.Update(ctx, subscription)
+	if err != nil {
+		return ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	// The stored name is the catalog name the given one resolved to.
+	subscription, err = s.subscriptions.ReadByID(ctx, subscription.ID)
+	if err != nil {
+		return ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	return ReplaceSubscription200JSONResponse(toHTTPSubscription(subscription)), nil
+}
+
+func (s *Server) PatchSubscription(
+	ctx context.Context,
+	request PatchSubscriptionRequestObject,
+) (PatchSubscriptionResponseObject, error) {
+	current, err := s.subscriptions.ReadByID(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return PatchSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	patched, err := applyMergePatch(toHTTPSubscriptionRequest(current), *request.Body)
+	if err != nil {
+		return PatchSubscriptiondefaultApplicationProblemPlusJSONResponse(toProblemResponse(
+			ctx,
+			domain.NewValidationError("invalid_patch", "invalid merge patch: "+err.Error()),
+		)), nil
+	}
+
+	subscription, err := toDomainSubscription(&patched)
+	if err != nil {
+		return PatchSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	subscription.ID = current.ID
+
+	err = s.subscriptions.Update(ctx, subscription)
+	if err != nil {
+		return PatchSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	// The stored name is the catalog name the given one resolved to.
+	subscription, err = s.subscriptions.ReadByID(ctx, subscription.ID)
+	if err != nil {
+		return PatchSubscriptiondefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	return PatchSubscription200JSONResponse(toHTTPSubscription(subscription)), nil
+}
+
 func (s *Server) ReadAllSubscriptions(
 	ctx context.Context,
 	request ReadAllSubscriptionsRequestObject,
@@ -94,96 +255,186 @@ func (s *Server) ReadAllSubscriptions(
 		}
 	}()
 
-	// Set defaults for optional parameters
-	limit := 50
-	offset := 0
-
-	if request.Params.Limit != nil {
-		limit = *request.Params.Limit
-	}
-
-	if request.Params.Offset != nil {
-		offset = *request.Params.Offset
+	filter, pagination, err := toDomainListing(request.Params)
+	if err != nil {
+		return ReadAllSubscriptionsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 
 	slog.Info("ReadAllSubscriptions called",
 		"user_id", request.Params.UserId,
-		"limit", limit,
-		"offset", offset)
+		"limit", pagination.Limit,
+		"offset", pagination.Offset,
+		"sort", formatSort(pagination.Sort),
+		"cursor", request.Params.Cursor)
 
-	list, err := s.subscriptions.ReadAllByUserID(
-		ctx,
-		uuid.UUID(request.Params.UserId),
-		limit,
-		offset,
-	)
+	page, err := s.subscriptions.ReadAll(ctx, filter, pagination)
 	if err != nil {
-		slog.Error("Failed to read subscriptions", "error", err)
-		return ReadAllSubscriptions500JSONResponse{
-			Message: "Failed to retrieve subscriptions",
-		}, nil
+		return ReadAllSubscriptionsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 
-	resp := make([]Subscription, 0, len(list))
-	for _, sub := range list {
-		resp = append(resp, toHTTPSubscription(sub))
+	resp := readAllSubscriptionsResponse{
+		subscriptions: make([]Subscription, 0, len(page.Subscriptions)),
+		total:         page.Total,
+	}
+	for _, sub := range page.Subscriptions {
+		resp.subscriptions = append(resp.subscriptions, toHTTPSubscription(sub))
 	}
+	if page.Next != nil {
+		resp.next = pointer.Ref(encodeCursor(*page.Next))
+	}
+
+	slog.Info("ReadAllSubscriptions success", "count", len(resp.subscriptions))
+
+	return resp, nil
+}
 
-	slog.Info("ReadAllSubscriptions success", "count", len(resp))
+// readAllSubscriptionsResponse keeps the body a bare array, as offset clients
+// expect, and sets X-Total-Count only when the total was requested and
+// X-Next-Cursor only when there is a next page. The generated response would
+// always send both.
+type readAllSubscriptionsResponse struct {
+	subscriptions []Subscription
+	total         *int
+	next          *string
+}
+
+func (r readAllSubscriptionsResponse) VisitReadAllSubscriptionsResponse(
+	w http.ResponseWriter,
+) error {
+	w.Header().Set("Content-Type", "application/json")
+	if r.total != nil {
+		w.Header().Set("X-Total-Count", strconv.Itoa(*r.total))
+	}
+	if r.next != nil {
+		w.Header().Set("X-Next-Cursor", *r.next)
+	}
+	w.WriteHeader(http.StatusOK)
 
-	return ReadAllSubscriptions200JSONResponse(resp), nil
+	return json.NewEncoder(w).Encode(r.subscriptions)
 }
 
 func (s *Server) CalculateTotalCost(
 	ctx context.Context,
 	request CalculateTotalCostRequestObject,
 ) (CalculateTotalCostResponseObject, error) {
+	var serviceName domain.ServiceName
+	if request.Params.ServiceName != nil {
+		serviceName = *request.Params.ServiceName
+	}
+
 	slog.Info("CalculateTotalCost called",
 		"user_id", request.Params.UserId,
-		"service_name", *request.Params.ServiceName,
+		"service_name", serviceName,
 		"start_date", request.Params.StartDate,
 		"end_date", request.Params.EndDate)
 
-	start, err := time.Parse("01-2006", request.Params.StartDate)
+	start, err := parseMonth("start_date", request.Params.StartDate)
 	if err != nil {
-		slog.Error(
-			"Invalid start_date format",
-			"error",
-			err,
-			"start_date",
-			request.Params.StartDate,
-		)
-		return CalculateTotalCost400JSONResponse{
-			Message: "Invalid start_date format. Expected MM-YYYY (e.g., 07-2025)",
-		}, nil
+		return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 
 	var end *time.Time
 	if request.Params.EndDate != nil {
-		t, err := time.Parse("01-2006", *request.Params.EndDate)
+		t, err := parseMonth("end_date", *request.Params.EndDate)
 		if err != nil {
-			slog.Error("Invalid end_date format", "error", err, "end_date", *request.Params.EndDate)
-			return CalculateTotalCost400JSONResponse{
-				Message: "Invalid end_date format. Expected MM-YYYY (e.g., 12-2025)",
-			}, nil
+			return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+				toProblemResponse(ctx, err),
+			), nil
 		}
 		end = &t
 	}
 
 	slog.Info("Parsed dates", "start", start, "end", end)
 
+	invalidGroupBy := func(message string) CalculateTotalCostResponseObject {
+		return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, domain.NewValidationError(
+				"invalid_group_by",
+				"unsupported grouping",
+			).WithFields(domain.FieldError{Field: "group_by", Message: message})),
+		)
+	}
+
+	if request.Params.GroupBy != nil {
+		switch *request.Params.GroupBy {
+		case CalculateTotalCostParamsGroupByServiceName:
+		case CalculateTotalCostParamsGroupByCategory, CalculateTotalCostParamsGroupByTag:
+			if request.Params.Currency != nil {
+				return invalidGroupBy("cannot be combined with currency"), nil
+			}
+			return s.totalCostByGroup(ctx, request.Params, serviceName, start, end), nil
+		default:
+			return invalidGroupBy("must be service_name, category or tag"), nil
+		}
+	}
+
+	if request.Params.Currency != nil {
+		converted, err := s.subscriptions.ConvertedTotalCost(
+			ctx,
+			request.Params.UserId,
+			serviceName,
+			start,
+			end,
+			domain.Currency(*request.Params.Currency),
+		)
+		if err != nil {
+			return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+				toProblemResponse(ctx, err),
+			), nil
+		}
+
+		currency := Currency(converted.Currency)
+		rates := toHTTPAppliedRates(converted.Rates)
+		response := CalculateTotalCost200JSONResponse{
+			TotalCost: converted.Total,
+			Currency:  &currency,
+			Rates:     &rates,
+		}
+		if request.Params.GroupBy != nil {
+			items := toHTTPTotalCostItems(converted.Items)
+			response.Items = &items
+		}
+		return response, nil
+	}
+
+	if request.Params.GroupBy != nil {
+		breakdown, err := s.subscriptions.TotalSubscriptionsCostByService(
+			ctx,
+			request.Params.UserId,
+			serviceName,
+			start,
+			end,
+		)
+		if err != nil {
+			return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+				toProblemResponse(ctx, err),
+			), nil
+		}
+
+		items := toHTTPTotalCostItems(breakdown.Items)
+		return CalculateTotalCost200JSONResponse{
+			TotalCost: breakdown.Total,
+			Items:     &items,
+		}, nil
+	}
+
 	totalCost, err := s.subscriptions.TotalSubscriptionsCost(
 		ctx,
 		request.Params.UserId,
-		*request.Params.ServiceName,
+		serviceName,
 		start,
 		end,
 	)
 	if err != nil {
-		slog.Error("Failed to calculate total cost", "error", err)
-		return CalculateTotalCost500JSONResponse{
-			Message: "Failed to calculate total cost",
-		}, nil
+		return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
 	}
 
 	slog.Info("CalculateTotalCost result", "total_cost", totalCost)
@@ -193,27 +444,336 @@ func (s *Server) CalculateTotalCost(
 	}, nil
 }
 
+// totalCostByGroup answers CalculateTotalCost grouped by category or tag.
+func (s *Server) totalCostByGroup(
+	ctx context.Context,
+	params CalculateTotalCostParams,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) CalculateTotalCostResponseObject {
+	grouped, err := s.subscriptions.TotalSubscriptionsCostByGroup(
+		ctx,
+		params.UserId,
+		serviceName,
+		start,
+		end,
+		domain.CostGroup(*params.GroupBy),
+	)
+	if err != nil {
+		return CalculateTotalCostdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		)
+	}
+
+	groups := make([]CostGroup, 0, len(grouped.Groups))
+	for _, group := range grouped.Groups {
+		groups = append(groups, CostGroup{Key: group.Key, TotalCost: group.Cost})
+	}
+	return CalculateTotalCost200JSONResponse{
+		TotalCost: grouped.Total,
+		Groups:    &groups,
+	}
+}
+
+func (s *Server) CalculateMonthlyCosts(
+	ctx context.Context,
+	request CalculateMonthlyCostsRequestObject,
+) (CalculateMonthlyCostsResponseObject, error) {
+	var serviceName domain.ServiceName
+	if request.Params.ServiceName != nil {
+		serviceName = *request.Params.ServiceName
+	}
+
+	start, err := parseMonth("start_date", request.Params.StartDate)
+	if err != nil {
+		return CalculateMonthlyCostsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	var end *time.Time
+	if request.Params.EndDate != nil {
+		t, err := parseMonth("end_date", *request.Params.EndDate)
+		if err != nil {
+			return CalculateMonthlyCostsdefaultApplicationProblemPlusJSONResponse(
+				toProblemResponse(ctx, err),
+			), nil
+		}
+		end = &t
+	}
+
+	months, err := s.subscriptions.MonthlySubscriptionsCost(
+		ctx,
+		request.Params.UserId,
+		serviceName,
+		start,
+		end,
+	)
+	if err != nil {
+		return CalculateMonthlyCostsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	resp := MonthlyCostsResponse{Months: make([]MonthlyCost, 0, len(months))}
+	for _, month := range months {
+		resp.Months = append(resp.Months, toHTTPMonthlyCost(month))
+	}
+
+	return CalculateMonthlyCosts200JSONResponse(resp), nil
+}
+
+func (s *Server) ForecastCosts(
+	ctx context.Context,
+	request ForecastCostsRequestObject,
+) (ForecastCostsResponseObject, error) {
+	var serviceName domain.ServiceName
+	if request.Params.ServiceName != nil {
+		serviceName = *request.Params.ServiceName
+	}
+
+	length := 12
+	if request.Params.Months != nil {
+		length = *request.Params.Months
+	}
+
+	months, err := s.subscriptions.ForecastSubscriptionsCost(
+		ctx,
+		request.Params.UserId,
+		serviceName,
+		time.Now(),
+		length,
+	)
+	if err != nil {
+		return ForecastCostsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	resp := ForecastResponse{Months: make([]MonthlyCost, 0, len(months))}
+	for _, month := range months {
+		resp.Months = append(resp.Months, toHTTPMonthlyCost(month))
+		resp.TotalCost += month.Total
+	}
+
+	return ForecastCosts200JSONResponse(resp), nil
+}
+
+func toDomainListing(
+	params ReadAllSubscriptionsParams,
+) (domain.SubscriptionFilter, domain.Pagination, error) {
+	filter := domain.SubscriptionFilter{
+		UserID:            uuid.UUID(params.UserId),
+		ServiceName:       params.ServiceName,
+		ServiceNamePrefix: params.ServiceNamePrefix,
+		Category:          params.Category,
+		Tag:               params.Tag,
+		MinPrice:          params.MinPrice,
+		MaxPrice:          params.MaxPrice,
+	}
+	pagination := domain.Pagination{Limit: 50, Sort: domain.DefaultSubscriptionSort}
+
+	// Set defaults for optional parameters
+	if params.Limit != nil {
+		pagination.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		pagination.Offset = *params.Offset
+	}
+	if params.IncludeTotal != nil {
+		pagination.IncludeTotal = *params.IncludeTotal
+	}
+	if params.IncludeDeleted != nil {
+		filter.IncludeDeleted = *params.IncludeDeleted
+	}
+
+	var fields []domain.FieldError
+	invalid := func(field, message string) {
+		fields = append(fields, domain.FieldError{Field: field, Message: message})
+	}
+
+	if pagination.Limit < 1 || pagination.Limit > maxPageLimit {
+		invalid("limit", "must be between 1 and 100")
+	}
+	if pagination.Offset < 0 {
+		invalid("offset", "must not be negative")
+	}
+
+	months := []struct {
+		field  string
+		value  *string
+		target **time.Time
+	}{
+		{"active_at", params.ActiveAt, &filter.ActiveAt},
+		{"start_from", params.StartFrom, &filter.StartFrom},
+		{"start_to", params.StartTo, &filter.StartTo},
+	}
+	for _, month := range months {
+		if month.value == nil {
+			continue
+		}
+		t, err := time.Parse("01-2006", *month.value)
+		if err != nil {
+			invalid(month.field, "expected MM-YYYY (e.g., 07-2025)")
+			continue
+		}
+		*month.target = &t
+	}
+
+	if params.Status != nil {
+		status := domain.SubscriptionStatus(*params.Status)
+		switch status {
+		case domain.SubscriptionStatusActive,
+			domain.SubscriptionStatusEnded,
+			domain.SubscriptionStatusFuture:
+			filter.Status = &status
+		default:
+			invalid("status", "must be one of active, ended, future")
+		}
+	}
+
+	if params.Sort != nil {
+		sort, ok := parseSort(string(*params.Sort))
+		if !ok {
+			invalid("sort", "unknown sort field")
+		}
+		pagination.Sort = sort
+	}
+
+	if len(fields) > 0 {
+		return filter, pagination, domain.NewValidationError(
+			"invalid_listing",
+			"listing parameters are invalid",
+		).WithFields(fields...)
+	}
+
+	if params.Cursor != nil {
+		cursor, err := decodeCursor(*params.Cursor)
+		if err != nil {
+			return filter, pagination, err
+		}
+		pagination.After = &cursor
+	}
+
+	return filter, pagination, nil
+}
+
+func parseMonth(field, value string) (time.Time, error) {
+	t, err := time.Parse("01-2006", value)
+	if err != nil {
+		return time.Time{}, invalidMonthError(field)
+	}
+
+	return t, nil
+}
+
+func invalidMonthError(fields ...string) error {
+	fieldErrors := make([]domain.FieldError, 0, len(fields))
+	for _, field := range fields {
+		fieldErrors = append(fieldErrors, domain.FieldError{
+			Field:   field,
+			Message: "expected MM-YYYY (e.g., 07-2025)",
+		})
+	}
+
+	return domain.NewValidationError("invalid_date", "dates must be in MM-YYYY format").
+		WithFields(fieldErrors...)
+}
+
 func toDomainSubscription(req *CreateSubscriptionJSONRequestBody) (domain.Subscription, error) {
+	var invalid []string
+
 	start, err := time.Parse("01-2006", req.StartDate)
 	if err != nil {
-		return domain.Subscription{}, err
+		invalid = append(invalid, "start_date")
 	}
 
 	var end *time.Time
 	if req.EndDate != nil {
 		t, err := time.Parse("01-2006", *req.EndDate)
 		if err != nil {
-			return domain.Subscription{}, err
+			invalid = append(invalid, "end_date")
 		}
 		end = &t
 	}
 
+	var anchor time.Time
+	if req.BillingAnchor != nil {
+		anchor, err = time.Parse("01-2006", *req.BillingAnchor)
+		if err != nil {
+			invalid = append(invalid, "billing_anchor")
+		}
+	}
+
+	var discounts []domain.Discount
+	if req.Discounts != nil {
+		for i, discount := range *req.Discounts {
+			field := fmt.Sprintf("discounts[%d]", i)
+			startMonth, err := time.Parse("01-2006", discount.StartMonth)
+			if err != nil {
+				invalid = append(invalid, field+".start_month")
+			}
+			endMonth, err := time.Parse("01-2006", discount.EndMonth)
+			if err != nil {
+				invalid = append(invalid, field+".end_month")
+			}
+
+			var value int
+			if discount.Value != nil {
+				value = *discount.Value
+			}
+
+			discounts = append(discounts, domain.Discount{
+				Kind:       domain.DiscountKind(discount.Kind),
+				Value:      value,
+				StartMonth: startMonth,
+				EndMonth:   endMonth,
+			})
+		}
+	}
+
+	if len(invalid) > 0 {
+		return domain.Subscription{}, invalidMonthError(invalid...)
+	}
+
+	var period domain.BillingPeriod
+	if req.BillingPeriod != nil {
+		period = domain.BillingPeriod(*req.BillingPeriod)
+	}
+
+	var currency domain.Currency
+	if req.Currency != nil {
+		currency = domain.Currency(*req.Currency)
+	}
+
+	var price int
+	if req.Price != nil {
+		price = *req.Price
+	}
+
+	var category string
+	if req.Category != nil {
+		category = *req.Category
+	}
+
+	var tags []string
+	if req.Tags != nil {
+		tags = *req.Tags
+	}
+
 	return domain.Subscription{
-		Name:      req.ServiceName,
-		Cost:      req.Price,
-		UserID:    uuid.UUID(req.UserId),
-		StartDate: start,
-		EndDate:   end,
+		Name:          req.ServiceName,
+		Cost:          price,
+		UserID:        uuid.UUID(req.UserId),
+		StartDate:     start,
+		EndDate:       end,
+		BillingPeriod: period,
+		BillingAnchor: anchor,
+		Currency:      currency,
+		Discounts:     discounts,
+		Category:      category,
+		Tags:          tags,
 	}, nil
 }
 
@@ -226,12 +786,89 @@ func toHTTPSubscription(s domain.Subscription) Subscription {
 		end = &str
 	}
 
+	s = s.WithBillingDefaults()
+	period := BillingPeriod(s.BillingPeriod)
+	anchor := s.BillingAnchor.Format("01-2006")
+	currency := Currency(s.Currency)
+	normalized := s.NormalizedMonthlyCost()
+
+	discounts := make([]Discount, 0, len(s.Discounts))
+	for _, discount := range s.Discounts {
+		discounts = append(discounts, Discount{
+			Kind:       DiscountKind(discount.Kind),
+			Value:      pointer.Ref(discount.Value),
+			StartMonth: discount.StartMonth.Format("01-2006"),
+			EndMonth:   discount.EndMonth.Format("01-2006"),
+		})
+	}
+
+	tags := s.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	return Subscription{
-		Id:          openapi_types.UUID(s.ID),
-		ServiceName: s.Name,
-		Price:       s.Cost,
-		UserId:      openapi_types.UUID(s.UserID),
-		StartDate:   start,
-		EndDate:     end,
+		Id:                    openapi_types.UUID(s.ID),
+		ServiceName:           s.Name,
+		Price:                 s.Cost,
+		UserId:                openapi_types.UUID(s.UserID),
+		StartDate:             start,
+		EndDate:               end,
+		BillingPeriod:         &period,
+		BillingAnchor:         &anchor,
+		Currency:              &currency,
+		NormalizedMonthlyCost: &normalized,
+		ServiceId:             s.ServiceID,
+		DeletedAt:             s.DeletedAt,
+		Discounts:             &discounts,
+		Category:              &s.Category,
+		Tags:                  &tags,
+	}
+}
+
+func toHTTPSubscriptionRequest(s domain.Subscription) CreateSubscriptionRequest {
+	subscription := toHTTPSubscription(s)
+
+	return CreateSubscriptionRequest{
+		UserId:        subscription.UserId,
+		ServiceName:   subscription.ServiceName,
+		Price:         &subscription.Price,
+		StartDate:     subscription.StartDate,
+		EndDate:       subscription.EndDate,
+		BillingPeriod: subscription.BillingPeriod,
+		BillingAnchor: subscription.BillingAnchor,
+		Currency:      subscription.Currency,
+		Discounts:     subscription.Discounts,
+		Category:      subscription.Category,
+		Tags:          subscription.Tags,
+	}
+}
+
+func toHTTPTotalCostItems(breakdown []domain.ServiceTotalCost) []TotalCostItem {
+	items := make([]TotalCostItem, 0, len(breakdown))
+	for _, item := range breakdown {
+		items = append(items, TotalCostItem{
+			ServiceName:   item.Name,
+			BillingPeriod: BillingPeriod(item.BillingPeriod),
+			Currency:      Currency(item.Currency),
+			Months:        item.Charges,
+			MonthlyPrice:  item.Price,
+			Cost:          item.Cost,
+		})
+	}
+
+	return items
+}
+
+func toHTTPMonthlyCost(month domain.MonthlyCost) MonthlyCost {
+	services := make([]ServiceCost, 0, len(month.Services))
+	for _, service := range month.Services {
+		services = append(services, ServiceCost{ServiceName: service.Name, Cost: service.Cost})
+	}
+
+	return MonthlyCost{
+		Month:     month.Month.Format("01-2006"),
+		TotalCost: month.Total,
+		Services:  services,
 	}
 }
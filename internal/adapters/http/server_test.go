@@ -0,0 +1,40 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/memory"
+)
+
+// apiClient sends requests to the API of a server on its own in-memory
+// storage.
+type apiClient struct {
+	handler http.Handler
+}
+
+func newAPIClient() apiClient {
+	return apiClient{handler: httpadapter.Handler(httpadapter.NewStrictHandler(
+		httpadapter.NewServer(domain.NewSubscriptionService(
+			memory.NewProvider(),
+			memory.NewRepositories(),
+		)),
+		nil,
+	))}
+}
+
+// serve sends a JSON body.
+func (c apiClient) serve(method, url, body string) *httptest.ResponseRecorder {
+	return c.serveContent(method, url, "application/json", body)
+}
+
+func (c apiClient) serveContent(method, url, contentType, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, url, strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+	c.handler.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) CreateService(
+	ctx context.Context,
+	request CreateServiceRequestObject,
+) (CreateServiceResponseObject, error) {
+	service := toDomainService(request.Body)
+	service.ID = uuid.New()
+
+	created, err := s.subscriptions.CreateService(ctx, service)
+	if err != nil {
+		return CreateServicedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return CreateService201JSONResponse(toHTTPService(created)), nil
+}
+
+func (s *Server) ListServices(
+	ctx context.Context,
+	request ListServicesRequestObject,
+) (ListServicesResponseObject, error) {
+	services, err := s.subscriptions.ListServices(ctx, domain.ServiceFilter{
+		Category: request.Params.Category,
+	})
+	if err != nil {
+		return ListServicesdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	items := make([]Service, 0, len(services))
+	for _, service := range services {
+		items = append(items, toHTTPService(service))
+	}
+	return ListServices200JSONResponse{Items: items}, nil
+}
+
+func (s *Server) GetService(
+	ctx context.Context,
+	request GetServiceRequestObject,
+) (GetServiceResponseObject, error) {
+	service, err := s.subscriptions.ReadService(ctx, uuid.UUID(request.Id))
+	if err != nil {
+		return GetServicedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return GetService200JSONResponse(toHTTPService(service)), nil
+}
+
+func (s *Server) ReplaceService(
+	ctx context.Context,
+	request ReplaceServiceRequestObject,
+) (ReplaceServiceResponseObject, error) {
+	service := toDomainService(request.Body)
+	service.ID = uuid.UUID(request.Id)
+
+	updated, err := s.subscriptions.UpdateService(ctx, service)
+	if err != nil {
+		return ReplaceServicedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return ReplaceService200JSONResponse(toHTTPService(updated)), nil
+}
+
+func (s *Server) DeleteService(
+	ctx context.Context,
+	request DeleteServiceRequestObject,
+) (DeleteServiceResponseObject, error) {
+	if err := s.subscriptions.DeleteService(ctx, uuid.UUID(request.Id)); err != nil {
+		return DeleteServicedefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return DeleteService200JSONResponse{Message: "Service deleted successfully"}, nil
+}
+
+// withDefaultPrice prices a subscription requested without a price at the
+// default price of its catalog service, taking the currency and billing
+// period of the service too unless the request sets them.
+func (s *Server) withDefaultPrice(
+	ctx context.Context,
+	subscription domain.Subscription,
+	req *CreateSubscriptionRequest,
+) (domain.Subscription, error) {
+	if req.Price != nil {
+		return subscription, nil
+	}
+
+	service, err := s.subscriptions.ResolveService(ctx, req.ServiceName)
+	if err != nil && !errors.Is(err, domain.ErrServiceNotFound) {
+		return domain.Subscription{}, err
+	}
+	if err != nil || service.DefaultPrice == nil {
+		return domain.Subscription{}, domain.NewValidationError(
+			"invalid_subscription",
+			"subscription is invalid",
+		).WithFields(domain.FieldError{
+			Field:   "price",
+			Message: "price is required unless the catalog service has a default price",
+		})
+	}
+
+	subscription.Cost = *service.DefaultPrice
+	if req.Currency == nil {
+		subscription.Currency = service.Currency
+	}
+	if req.BillingPeriod == nil {
+		subscription.BillingPeriod = service.BillingPeriod
+	}
+
+	return subscription, nil
+}
+
+func toDomainService(req *ServiceRequest) domain.Service {
+	service := domain.Service{
+		Name:         req.Name,
+		DefaultPrice: req.DefaultPrice,
+	}
+	if req.Aliases != nil {
+		service.Aliases = *req.Aliases
+	}
+	if req.Category != nil {
+		service.Category = *req.Category
+	}
+	if req.Currency != nil {
+		service.Currency = domain.Currency(*req.Currency)
+	}
+	if req.BillingPeriod != nil {
+		service.BillingPeriod = domain.BillingPeriod(*req.BillingPeriod)
+	}
+
+	return service
+}
+
+func toHTTPService(service domain.Service) Service {
+	aliases := service.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return Service{
+		Id:            openapi_types.UUID(service.ID),
+		Name:          service.Name,
+		Aliases:       aliases,
+		Category:      service.Category,
+		DefaultPrice:  service.DefaultPrice,
+		Currency:      Currency(service.Currency),
+		BillingPeriod: BillingPeriod(service.BillingPeriod),
+	}
+}
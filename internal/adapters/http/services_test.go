@@ -0,0 +1,95 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionPricedFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+
+	service := serve(http.MethodPost, "/services", `{
+		"name": "Yandex  Plus",
+		"aliases": ["Yandex+"],
+		"category": "bundle",
+		"default_price": 39900,
+		"billing_period": "yearly"
+	}`)
+	require.Equal(t, http.StatusCreated, service.Code, service.Body.String())
+
+	var catalog httpadapter.Service
+	require.NoError(t, json.NewDecoder(service.Body).Decode(&catalog))
+	require.Equal(t, "Yandex Plus", catalog.Name)
+	require.Equal(t, []string{"Yandex+"}, catalog.Aliases)
+
+	userID := uuid.New()
+	created := serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+userID.String()+`",
+		"service_name": "yandex+",
+		"start_date": "01-2025"
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var subscription httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(created.Body).Decode(&subscription))
+	require.Equal(t, "Yandex Plus", subscription.ServiceName)
+	require.Equal(t, 39900, subscription.Price)
+	require.Equal(t, httpadapter.BillingPeriod("yearly"), *subscription.BillingPeriod)
+	require.Equal(t, catalog.Id, *subscription.ServiceId)
+
+	unpriced := serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+userID.String()+`",
+		"service_name": "Music",
+		"start_date": "01-2025"
+	}`)
+	require.Equal(t, http.StatusBadRequest, unpriced.Code, unpriced.Body.String())
+	require.Contains(t, unpriced.Body.String(), `"field":"price"`)
+
+	deleted := serve(http.MethodDelete, "/services/"+catalog.Id.String(), "")
+	require.Equal(t, http.StatusConflict, deleted.Code, deleted.Body.String())
+}
+
+func TestCatalogChangesRequireTheAdminToken(t *testing.T) {
+	t.Parallel()
+
+	handler := httpadapter.Admin("secret", newAPIClient().handler)
+	serve := func(method, target, body, authorization string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, target, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	id := uuid.NewString()
+	for _, request := range []struct{ method, target, body string }{
+		{http.MethodPost, "/services", `{"name": "Music"}`},
+		{http.MethodPut, "/services/" + id, `{"name": "Music"}`},
+		{http.MethodDelete, "/services/" + id, ""},
+	} {
+		response := serve(request.method, request.target, request.body, "Bearer wrong")
+		require.Equal(t, http.StatusUnauthorized, response.Code, request.method)
+		require.Contains(t, response.Body.String(), "admin_token_required")
+	}
+
+	created := serve(http.MethodPost, "/services", `{"name": "Music"}`, "Bearer secret")
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	// Reading the catalog stays open.
+	listed := serve(http.MethodGet, "/services", "", "")
+	require.Equal(t, http.StatusOK, listed.Code, listed.Body.String())
+	require.Contains(t, listed.Body.String(), "Music")
+}
@@ -0,0 +1,235 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const mergePatch = "application/merge-patch+json"
+
+func TestPatchSubscriptionMergesFields(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	created := client.serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+uuid.NewString()+`",
+		"service_name": "Music",
+		"price": 100,
+		"start_date": "01-2025",
+		"end_date": "06-2025",
+		"tags": ["family"]
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var subscription httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(created.Body).Decode(&subscription))
+	url := "/subscriptions/" + subscription.Id.String()
+
+	patch := func(body string) httpadapter.Subscription {
+		t.Helper()
+
+		patched := client.serveContent(http.MethodPatch, url, mergePatch, body)
+		require.Equal(t, http.StatusOK, patched.Code, patched.Body.String())
+
+		var subscription httpadapter.Subscription
+		require.NoError(t, json.NewDecoder(patched.Body).Decode(&subscription))
+		return subscription
+	}
+
+	// Absent fields are left as they are.
+	repriced := patch(`{"price": 200}`)
+	require.Equal(t, 200, repriced.Price)
+	require.Equal(t, "Music", repriced.ServiceName)
+	require.Equal(t, "01-2025", repriced.StartDate)
+	require.Equal(t, "06-2025", *repriced.EndDate)
+	require.Equal(t, []string{"family"}, *repriced.Tags)
+
+	// Null clears a field.
+	unbounded := patch(`{"end_date": null, "tags": null}`)
+	require.Nil(t, unbounded.EndDate)
+	require.Empty(t, unbounded.Tags)
+	require.Equal(t, 200, unbounded.Price)
+
+	read := client.serve(http.MethodGet, url, "")
+	require.Equal(t, http.StatusOK, read.Code, read.Body.String())
+	var stored httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(read.Body).Decode(&stored))
+	require.Nil(t, stored.EndDate)
+	require.Equal(t, 200, stored.Price)
+
+	invalid := client.serveContent(http.MethodPatch, url, mergePatch, `["price"]`)
+	require.Equal(t, http.StatusBadRequest, invalid.Code, invalid.Body.String())
+}
+
+func TestReplaceSubscriptionRequiresEveryField(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	userID := uuid.NewString()
+	created := client.serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+userID+`",
+		"service_name": "Music",
+		"price": 100,
+		"start_date": "01-2025",
+		"end_date": "06-2025"
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var subscription httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(created.Body).Decode(&subscription))
+	url := "/subscriptions/" + subscription.Id.String()
+
+	incomplete := client.serve(http.MethodPut, url, `{
+		"user_id": "`+userID+`",
+		"service_name": "Music",
+		"price": 150
+	}`)
+	require.Equal(t, http.StatusBadRequest, incomplete.Code, incomplete.Body.String())
+
+	// Fields left out of a replacement are cleared, not kept.
+	replaced := client.serve(http.MethodPut, url, `{
+		"user_id": "`+userID+`",
+		"service_name": "Music",
+		"price": 150,
+		"start_date": "02-2025"
+	}`)
+	require.Equal(t, http.StatusOK, replaced.Code, replaced.Body.String())
+	require.NoError(t, json.NewDecoder(replaced.Body).Decode(&subscription))
+	require.Equal(t, 150, subscription.Price)
+	require.Equal(t, "02-2025", subscription.StartDate)
+	require.Nil(t, subscription.EndDate)
+}
+
+func TestUpdateUnknownSubscription(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	url := "/subscriptions/" + uuid.NewString()
+
+	replaced := client.serve(http.MethodPut, url, `{
+		"user_id": "`+uuid.NewString()+`",
+		"service_name": "Music",
+		"price": 100,
+		"start_date": "01-2025"
+	}`)
+	require.Equal(t, http.StatusNotFound, replaced.Code, replaced.Body.String())
+
+	patched := client.serveContent(http.MethodPatch, url, mergePatch, `{"price": 200}`)
+	require.Equal(t, http.StatusNotFound, patched.Code, patched.Body.String())
+}
+
+func TestOverlapKeepsTheFormerCode(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+	create := func(userID, start string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/subscriptions", `{
+			"user_id": "`+userID+`",
+			"service_name": "Music",
+			"price": 100,
+			"start_date": "`+start+`"
+		}`)
+	}
+
+	userID := uuid.NewString()
+	created := create(userID, "01-2025")
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	overlapping := create(userID, "03-2025")
+	require.Equal(t, http.StatusConflict, overlapping.Code, overlapping.Body.String())
+	var problem httpadapter.Problem
+	require.NoError(t, json.NewDecoder(overlapping.Body).Decode(&problem))
+	require.Equal(t, "previous_subscription_not_ended", problem.Code)
+}
+
+func TestNormalizedCostFollowsPriceChanges(t *testing.T) {
+	t.Parallel()
+
+	client := newAPIClient()
+	userID := uuid.NewString()
+	created := client.serve(http.MethodPost, "/subscriptions", `{
+		"user_id": "`+userID+`",
+		"service_name": "Music",
+		"price": 100,
+		"start_date": "01-2025"
+	}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var subscription httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(created.Body).Decode(&subscription))
+	url := "/subscriptions/" + subscription.Id.String()
+
+	scheduled := client.serve(http.MethodPost, url+"/prices", `{
+		"effective_from": "02-2025",
+		"price": 150
+	}`)
+	require.Equal(t, http.StatusOK, scheduled.Code, scheduled.Body.String())
+
+	read := client.serve(http.MethodGet, url, "")
+	require.Equal(t, http.StatusOK, read.Code, read.Body.String())
+	require.NoError(t, json.NewDecoder(read.Body).Decode(&subscription))
+	require.Equal(t, 150, *subscription.NormalizedMonthlyCost)
+
+	listed := client.serve(http.MethodGet, "/subscriptions?user_id="+userID, "")
+	require.Equal(t, http.StatusOK, listed.Code, listed.Body.String())
+	var page []httpadapter.Subscription
+	require.NoError(t, json.NewDecoder(listed.Body).Decode(&page))
+	require.Len(t, page, 1)
+	require.Equal(t, 150, *page[0].NormalizedMonthlyCost)
+}
+
+func TestListSubscriptionsPages(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+	userID := uuid.NewString()
+	for _, name := range []string{"Music", "Video", "Cloud"} {
+		created := serve(http.MethodPost, "/subscriptions", `{
+			"user_id": "`+userID+`",
+			"service_name": "`+name+`",
+			"price": 100,
+			"start_date": "01-2025"
+		}`)
+		require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	}
+
+	list := func(query string) ([]httpadapter.Subscription, string) {
+		t.Helper()
+
+		listed := serve(
+			http.MethodGet,
+			"/subscriptions?user_id="+userID+"&sort=service_name&limit=2"+query,
+			"",
+		)
+		require.Equal(t, http.StatusOK, listed.Code, listed.Body.String())
+		var page []httpadapter.Subscription
+		require.NoError(t, json.NewDecoder(listed.Body).Decode(&page))
+		return page, listed.Header().Get("X-Next-Cursor")
+	}
+	names := func(page []httpadapter.Subscription) []string {
+		names := make([]string, 0, len(page))
+		for _, subscription := range page {
+			names = append(names, subscription.ServiceName)
+		}
+		return names
+	}
+
+	// Offset pages stay bare arrays.
+	page, _ := list("&offset=2")
+	require.Equal(t, []string{"Video"}, names(page))
+
+	page, next := list("")
+	require.Equal(t, []string{"Cloud", "Music"}, names(page))
+	require.NotEmpty(t, next)
+
+	page, next = list("&cursor=" + next)
+	require.Equal(t, []string{"Video"}, names(page))
+	require.Empty(t, next)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func (s *Server) CreateTag(
+	ctx context.Context,
+	request CreateTagRequestObject,
+) (CreateTagResponseObject, error) {
+	created, err := s.subscriptions.CreateTag(ctx, domain.Tag{
+		ID:     uuid.New(),
+		UserID: uuid.UUID(request.Body.UserId),
+		Name:   request.Body.Name,
+	})
+	if err != nil {
+		return CreateTagdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return CreateTag201JSONResponse(toHTTPTag(created)), nil
+}
+
+func (s *Server) ListTags(
+	ctx context.Context,
+	request ListTagsRequestObject,
+) (ListTagsResponseObject, error) {
+	tags, err := s.subscriptions.ListTags(ctx, uuid.UUID(request.Params.UserId))
+	if err != nil {
+		return ListTagsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+
+	items := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, toHTTPTag(tag))
+	}
+	return ListTags200JSONResponse{Items: items}, nil
+}
+
+func (s *Server) RenameTag(
+	ctx context.Context,
+	request RenameTagRequestObject,
+) (RenameTagResponseObject, error) {
+	renamed, err := s.subscriptions.RenameTag(ctx, uuid.UUID(request.Id), request.Body.Name)
+	if err != nil {
+		return RenameTagdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return RenameTag200JSONResponse(toHTTPTag(renamed)), nil
+}
+
+func (s *Server) DeleteTag(
+	ctx context.Context,
+	request DeleteTagRequestObject,
+) (DeleteTagResponseObject, error) {
+	if err := s.subscriptions.DeleteTag(ctx, uuid.UUID(request.Id)); err != nil {
+		return DeleteTagdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return DeleteTag200JSONResponse{Message: "Tag deleted successfully"}, nil
+}
+
+func (s *Server) MergeTags(
+	ctx context.Context,
+	request MergeTagsRequestObject,
+) (MergeTagsResponseObject, error) {
+	merged, err := s.subscriptions.MergeTags(
+		ctx,
+		uuid.UUID(request.Id),
+		uuid.UUID(request.Body.Into),
+	)
+	if err != nil {
+		return MergeTagsdefaultApplicationProblemPlusJSONResponse(
+			toProblemResponse(ctx, err),
+		), nil
+	}
+	return MergeTags200JSONResponse(toHTTPTag(merged)), nil
+}
+
+func toHTTPTag(tag domain.Tag) Tag {
+	return Tag{
+		Id:            tag.ID,
+		UserId:        tag.UserID,
+		Name:          tag.Name,
+		Subscriptions: tag.Subscriptions,
+	}
+}
@@ -0,0 +1,66 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	httpadapter "github.com/Vera-Kovaleva/subscriptions-service/internal/adapters/http"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotalCostGroupedByTag(t *testing.T) {
+	t.Parallel()
+
+	serve := newAPIClient().serve
+
+	userID := uuid.New().String()
+	for _, body := range []string{
+		`{"service_name": "Music", "price": 100, "tags": ["Family", "work"]}`,
+		`{"service_name": "Video", "price": 300, "category": "video", "tags": ["family"]}`,
+	} {
+		body = strings.Replace(body, "{", `{"user_id": "`+userID+`", "start_date": "01-2025", `, 1)
+		created := serve(http.MethodPost, "/subscriptions", body)
+		require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	}
+
+	listed := serve(http.MethodGet, "/tags?user_id="+userID, "")
+	require.Equal(t, http.StatusOK, listed.Code, listed.Body.String())
+
+	var tags httpadapter.TagList
+	require.NoError(t, json.NewDecoder(listed.Body).Decode(&tags))
+	require.Len(t, tags.Items, 2)
+	require.Equal(t, "Family", tags.Items[0].Name)
+	require.Equal(t, 2, tags.Items[0].Subscriptions)
+
+	total := serve(
+		http.MethodGet,
+		"/subscriptions/total?user_id="+userID+"&start_date=01-2025&end_date=02-2025&group_by=tag",
+		"",
+	)
+	require.Equal(t, http.StatusOK, total.Code, total.Body.String())
+
+	var cost httpadapter.TotalCostResponse
+	require.NoError(t, json.NewDecoder(total.Body).Decode(&cost))
+	require.Equal(t, 800, cost.TotalCost)
+	require.Equal(t, []httpadapter.CostGroup{
+		{Key: "Family", TotalCost: 800},
+		{Key: "work", TotalCost: 200},
+	}, *cost.Groups)
+
+	filtered := serve(http.MethodGet, "/subscriptions?user_id="+userID+"&tag=WORK", "")
+	require.Equal(t, http.StatusOK, filtered.Code, filtered.Body.String())
+	require.Contains(t, filtered.Body.String(), `"service_name":"Music"`)
+	require.NotContains(t, filtered.Body.String(), `"service_name":"Video"`)
+
+	converted := serve(
+		http.MethodGet,
+		"/subscriptions/total?user_id="+userID+"&start_date=01-2025&group_by=category&currency=USD",
+		"",
+	)
+	require.Equal(t, http.StatusBadRequest, converted.Code, converted.Body.String())
+	require.Contains(t, converted.Body.String(), `"field":"group_by"`)
+}
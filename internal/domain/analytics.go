@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// maxTopServices bounds the number of services TopServices returns.
+const maxTopServices = 100
+
+var (
+	ErrServiceMonthlyRevenue = errors.Join(
+		errServiceSubscription,
+		errors.New("monthly revenue failed"),
+	)
+	ErrServiceSubscriptionActivity = errors.Join(
+		errServiceSubscription,
+		errors.New("subscription activity failed"),
+	)
+	ErrServiceTopServices = errors.Join(
+		errServiceSubscription,
+		errors.New("top services failed"),
+	)
+)
+
+// MonthlyRevenue is the spend of every user month by month in currency, the
+// costs of every month are converted as in ConvertedTotalCost.
+func (s *SubscriptionService) MonthlyRevenue(
+	ctx context.Context,
+	start time.Time,
+	end *time.Time,
+	currency Currency,
+) ([]MonthlyCost, error) {
+	slog.DebugContext(ctx, "Service: calculating monthly revenue.", log.RequestID(ctx))
+	if err := validateTargetCurrency(currency); err != nil {
+		return nil, errors.Join(ErrServiceMonthlyRevenue, err)
+	}
+	period, err := analyticsPeriod(start, end)
+	if err != nil {
+		return nil, errors.Join(ErrServiceMonthlyRevenue, err)
+	}
+
+	costs, err := s.revenue(ctx, period, currency)
+	if err != nil {
+		return nil, errors.Join(ErrServiceMonthlyRevenue, err)
+	}
+
+	return fillMonthlyCosts(period.Months(), costs), nil
+}
+
+func (s *SubscriptionService) SubscriptionActivity(
+	ctx context.Context,
+	start time.Time,
+	end *time.Time,
+) ([]MonthlyActivity, error) {
+	slog.DebugContext(ctx, "Service: counting subscription activity.", log.RequestID(ctx))
+	period, err := analyticsPeriod(start, end)
+	if err != nil {
+		return nil, errors.Join(ErrServiceSubscriptionActivity, err)
+	}
+
+	var activity []MonthlyActivity
+	err = s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var dbErr error
+		activity, dbErr = s.analyticsRepo.MonthlyActivity(ctx, c, period.Start, *period.End)
+		return dbErr
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceSubscriptionActivity, err)
+	}
+
+	return activity, nil
+}
+
+// TopServices ranks the services by the spend of every user in currency,
+// highest first and by name between equal ones.
+func (s *SubscriptionService) TopServices(
+	ctx context.Context,
+	start time.Time,
+	end *time.Time,
+	limit int,
+	currency Currency,
+) ([]ServiceCost, error) {
+	slog.DebugContext(ctx, "Service: ranking services by spend.", log.RequestID(ctx))
+	if limit < 1 || limit > maxTopServices {
+		return nil, errors.Join(
+			ErrServiceTopServices,
+			NewValidationError("invalid_limit", "limit is out of range").WithFields(FieldError{
+				Field:   "limit",
+				Message: fmt.Sprintf("must be between 1 and %d", maxTopServices),
+			}),
+		)
+	}
+
+	if err := validateTargetCurrency(currency); err != nil {
+		return nil, errors.Join(ErrServiceTopServices, err)
+	}
+	period, err := analyticsPeriod(start, end)
+	if err != nil {
+		return nil, errors.Join(ErrServiceTopServices, err)
+	}
+
+	costs, err := s.revenue(ctx, period, currency)
+	if err != nil {
+		return nil, errors.Join(ErrServiceTopServices, err)
+	}
+
+	spend := make(map[ServiceName]int)
+	for _, cost := range costs {
+		spend[cost.Name] += cost.Cost
+	}
+	services := make([]ServiceCost, 0, len(spend))
+	for name, cost := range spend {
+		services = append(services, ServiceCost{Name: name, Cost: cost})
+	}
+	slices.SortFunc(services, func(a, b ServiceCost) int {
+		return cmp.Or(cmp.Compare(b.Cost, a.Cost), cmp.Compare(a.Name, b.Name))
+	})
+
+	return services[:min(limit, len(services))], nil
+}
+
+// revenue reads the spend of every user within the period and converts it
+// to currency, rates are only read when some of it is billed in another one.
+func (s *SubscriptionService) revenue(
+	ctx context.Context,
+	period Period,
+	currency Currency,
+) ([]MonthlyServiceCost, error) {
+	var (
+		costs []MonthlyServiceCost
+		rates []ExchangeRate
+	)
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var dbErr error
+		costs, dbErr = s.analyticsRepo.MonthlyRevenue(ctx, c, period.Start, *period.End)
+		if dbErr != nil {
+			return dbErr
+		}
+		if !slices.ContainsFunc(costs, func(cost MonthlyServiceCost) bool {
+			return cost.Currency != currency
+		}) {
+			return nil
+		}
+		rates, dbErr = s.ratesRepo.List(ctx, c, currency, *period.End)
+		return dbErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertMonthlyCosts(costs, rates, currency)
+}
+
+// analyticsPeriod bounds the period at the current month when end is not
+// set and checks it the same way as a monthly cost series.
+func analyticsPeriod(start time.Time, end *time.Time) (Period, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	period := NewPeriod(start, end)
+	if err := validateCostPeriod(period); err != nil {
+		return Period{}, err
+	}
+
+	return period, nil
+}
@@ -0,0 +1,128 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyRevenueAddsUpUserCosts(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	users := []domain.UserID{uuid.New(), uuid.New()}
+	for i, userID := range users {
+		require.NoError(t, service.Create(t.Context(), domain.Subscription{
+			ID:        uuid.New(),
+			Name:      "Music",
+			Cost:      100 * (i + 1),
+			UserID:    userID,
+			StartDate: monthOf(2025, time.February),
+		}))
+	}
+
+	start, end := monthOf(2025, time.January), monthOf(2025, time.March)
+	revenue, err := service.MonthlyRevenue(t.Context(), start, &end, domain.DefaultCurrency)
+	require.NoError(t, err)
+	require.Equal(t, []domain.MonthlyCost{
+		{Month: monthOf(2025, time.January), Services: []domain.ServiceCost{}},
+		{
+			Month:    monthOf(2025, time.February),
+			Total:    300,
+			Services: []domain.ServiceCost{{Name: "Music", Cost: 300}},
+		},
+		{
+			Month:    monthOf(2025, time.March),
+			Total:    300,
+			Services: []domain.ServiceCost{{Name: "Music", Cost: 300}},
+		},
+	}, revenue)
+
+	var perUser int
+	for _, userID := range users {
+		total, err := service.TotalSubscriptionsCost(t.Context(), userID, "", start, &end)
+		require.NoError(t, err)
+		perUser += total
+	}
+	require.Equal(t, revenue[1].Total+revenue[2].Total, perUser)
+}
+
+func TestAnalyticsValidates(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	start := monthOf(2025, time.May)
+
+	_, err := service.SubscriptionActivity(
+		t.Context(),
+		start,
+		pointer.Ref(monthOf(2025, time.April)),
+	)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	for _, limit := range []int{0, 101} {
+		_, err := service.TopServices(t.Context(), start, nil, limit, domain.DefaultCurrency)
+		require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err), limit)
+	}
+
+	_, err = service.MonthlyRevenue(t.Context(), start, nil, "rub")
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
+
+func TestAnalyticsConvertToTheReportCurrency(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	for _, subscription := range []domain.Subscription{
+		{Name: "Music", Cost: 19900, Currency: "RUB"},
+		{Name: "Music", Cost: 299, Currency: "USD"},
+		{Name: "Video", Cost: 999, Currency: "USD"},
+	} {
+		subscription.ID, subscription.UserID = uuid.New(), uuid.New()
+		subscription.StartDate = monthOf(2025, time.January)
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+
+	start, end := monthOf(2025, time.January), monthOf(2025, time.February)
+	_, err := service.MonthlyRevenue(t.Context(), start, &end, domain.DefaultCurrency)
+	require.ErrorIs(t, err, domain.ErrExchangeRateMissing)
+
+	require.NoError(t, service.ImportExchangeRates(t.Context(), []domain.ExchangeRate{
+		{Base: "USD", Quote: "RUB", EffectiveFrom: monthOf(2025, time.January), Rate: 90},
+		{Base: "USD", Quote: "RUB", EffectiveFrom: monthOf(2025, time.February), Rate: 80},
+	}))
+
+	revenue, err := service.MonthlyRevenue(t.Context(), start, &end, domain.DefaultCurrency)
+	require.NoError(t, err)
+	require.Equal(t, []domain.MonthlyCost{
+		{
+			Month: monthOf(2025, time.January),
+			Total: 19900 + 299*90 + 999*90,
+			Services: []domain.ServiceCost{
+				{Name: "Music", Cost: 19900 + 299*90},
+				{Name: "Video", Cost: 999 * 90},
+			},
+		},
+		{
+			Month: monthOf(2025, time.February),
+			Total: 19900 + 299*80 + 999*80,
+			Services: []domain.ServiceCost{
+				{Name: "Music", Cost: 19900 + 299*80},
+				{Name: "Video", Cost: 999 * 80},
+			},
+		},
+	}, revenue)
+
+	// The rate stored the other way round converts to dollars.
+	top, err := service.TopServices(t.Context(), start, &end, 10, "USD")
+	require.NoError(t, err)
+	require.Equal(t, []domain.ServiceCost{
+		{Name: "Video", Cost: 2 * 999},
+		{Name: "Music", Cost: 2*299 + 221 + 249},
+	}, top)
+}
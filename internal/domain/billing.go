@@ -0,0 +1,362 @@
+package domain
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// weeksPerYear is what weekly prices are spread over when normalized.
+const weeksPerYear = 52
+
+// Charge is a single payment for a subscription, made in Month.
+type Charge struct {
+	SubscriptionID SubscriptionID
+	Name           ServiceName
+	BillingPeriod  BillingPeriod
+	Currency       Currency
+	Month          time.Time
+	Amount         int
+}
+
+func (p BillingPeriod) IsValid() bool {
+	switch p {
+	case BillingWeekly, BillingMonthly, BillingQuarterly, BillingYearly:
+		return true
+	}
+
+	return false
+}
+
+// OrMonthly resolves the zero value to monthly billing.
+func (p BillingPeriod) OrMonthly() BillingPeriod {
+	if p == "" {
+		return BillingMonthly
+	}
+
+	return p
+}
+
+// MonthlyEquivalent spreads a price per period over months, rounded to the
+// nearest unit. A year has 52 weeks.
+func (p BillingPeriod) MonthlyEquivalent(price int) int {
+	switch p.OrMonthly() {
+	case BillingWeekly:
+		return int(math.Round(float64(price) * weeksPerYear / 12))
+	case BillingQuarterly:
+		return int(math.Round(float64(price) / 3))
+	case BillingYearly:
+		return int(math.Round(float64(price) / 12))
+	}
+
+	return price
+}
+
+// NormalizedMonthlyCost is the current price as a monthly equivalent, it
+// makes subscriptions billed at different periods comparable.
+func (s Subscription) NormalizedMonthlyCost() int {
+	return s.BillingPeriod.MonthlyEquivalent(s.PriceAt(time.Now()))
+}
+
+// Anchor returns the month quarterly and yearly charges are aligned to, the
+// start month unless BillingAnchor is set.
+func (s Subscription) Anchor() time.Time {
+	if s.BillingAnchor.IsZero() {
+		return MonthStart(s.StartDate)
+	}
+
+	return MonthStart(s.BillingAnchor)
+}
+
+// WithBillingDefaults bills monthly in DefaultCurrency unless told otherwise
+// and anchors charges to the start month unless an anchor month is given.
+// Discounts are copied to start and end on month boundaries in month order.
+func (s Subscription) WithBillingDefaults() Subscription {
+	s.BillingPeriod = s.BillingPeriod.OrMonthly()
+	s.BillingAnchor = s.Anchor()
+	s.Currency = s.Currency.OrDefault()
+	s.Discounts = normalizeDiscounts(s.ID, s.Discounts)
+
+	return s
+}
+
+// chargesIn counts the charges falling in the month, the month must lie
+// within the subscription.
+func (s Subscription) chargesIn(month time.Time) int {
+	switch s.BillingPeriod.OrMonthly() {
+	case BillingWeekly:
+		start := dayStart(s.StartDate)
+		first := month
+		if start.After(first) {
+			first = start
+		}
+		last := month.AddDate(0, 1, -1)
+
+		// Charges on or before a day counted from the start date.
+		chargesUpTo := func(day time.Time) int {
+			return floorDiv(daysBetween(start, day), 7) + 1
+		}
+		return chargesUpTo(last) - chargesUpTo(first.AddDate(0, 0, -1))
+	case BillingQuarterly:
+		return boolToInt(monthsBetween(s.Anchor(), month)%3 == 0)
+	case BillingYearly:
+		return boolToInt(monthsBetween(s.Anchor(), month)%12 == 0)
+	}
+
+	return 1
+}
+
+// Period returns the months the subscription is billed for.
+func (s Subscription) Period() Period {
+	return NewPeriod(s.StartDate, s.EndDate)
+}
+
+// PriceAt returns the price per billing period in effect in the month of t. Price
+// changes take effect from the start month on, earlier ones are ignored.
+func (s Subscription) PriceAt(t time.Time) int {
+	month := MonthStart(t)
+	start := MonthStart(s.StartDate)
+
+	price := s.Cost
+	for _, change := range s.Prices {
+		effective := MonthStart(change.EffectiveFrom)
+		if effective.After(month) {
+			break
+		}
+		if !effective.Before(start) {
+			price = change.Price
+		}
+	}
+
+	return price
+}
+
+// Charges yields every charge of the subscription within period, in month
+// order, with discounts taken off. Only bounded periods can be billed,
+// charges of an open-ended subscription within an open-ended period are not
+// listed.
+func (s Subscription) Charges(period Period) []Charge {
+	billed, ok := s.Period().Intersect(period)
+	if !ok {
+		return nil
+	}
+
+	months := billed.Months()
+	charges := make([]Charge, 0, len(months))
+	for _, month := range months {
+		for range s.chargesIn(month) {
+			charges = append(charges, Charge{
+				SubscriptionID: s.ID,
+				Name:           s.Name,
+				BillingPeriod:  s.BillingPeriod.OrMonthly(),
+				Currency:       s.Currency.OrDefault(),
+				Month:          month,
+				Amount:         s.AmountAt(month),
+			})
+		}
+	}
+
+	return charges
+}
+
+// TotalCost sums every charge of the subscriptions within period.
+func TotalCost(subscriptions []Subscription, period Period) int {
+	var total int
+	for _, subscription := range subscriptions {
+		for _, charge := range subscription.Charges(period) {
+			total += charge.Amount
+		}
+	}
+
+	return total
+}
+
+// CostByService groups the charges within period by service, billing
+// period, currency and price, sorted in that order.
+func CostByService(subscriptions []Subscription, period Period) []ServiceTotalCost {
+	type key struct {
+		name          ServiceName
+		billingPeriod BillingPeriod
+		currency      Currency
+		price         int
+	}
+
+	grouped := make(map[key]*ServiceTotalCost)
+	for _, subscription := range subscriptions {
+		for _, charge := range subscription.Charges(period) {
+			k := key{
+				name:          charge.Name,
+				billingPeriod: charge.BillingPeriod,
+				currency:      charge.Currency,
+				price:         charge.Amount,
+			}
+			item, ok := grouped[k]
+			if !ok {
+				item = &ServiceTotalCost{
+					Name:          charge.Name,
+					BillingPeriod: charge.BillingPeriod,
+					Currency:      charge.Currency,
+					Price:         charge.Amount,
+				}
+				grouped[k] = item
+			}
+			item.Charges++
+			item.Cost += charge.Amount
+		}
+	}
+
+	items := make([]ServiceTotalCost, 0, len(grouped))
+	for _, item := range grouped {
+		items = append(items, *item)
+	}
+	slices.SortFunc(items, compareServiceTotalCosts)
+
+	return items
+}
+
+func (g CostGroup) IsValid() bool {
+	return g == CostGroupCategory || g == CostGroupTag
+}
+
+// CostByGroup sums the charges within period by the category or by every tag
+// of their subscription, sorted by key. Uncategorized and untagged
+// subscriptions are summed under the empty key.
+func CostByGroup(subscriptions []Subscription, period Period, group CostGroup) []GroupCost {
+	grouped := make(map[string]int)
+	for _, subscription := range subscriptions {
+		keys := []string{subscription.Category}
+		if group == CostGroupTag {
+			keys = subscription.Tags
+			if len(keys) == 0 {
+				keys = []string{""}
+			}
+		}
+
+		for _, charge := range subscription.Charges(period) {
+			for _, key := range keys {
+				grouped[key] += charge.Amount
+			}
+		}
+	}
+
+	costs := make([]GroupCost, 0, len(grouped))
+	for key, cost := range grouped {
+		costs = append(costs, GroupCost{Key: key, Cost: cost})
+	}
+	slices.SortFunc(costs, func(a, b GroupCost) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return costs
+}
+
+// MonthlyChargeCounts counts the charges within period month by month,
+// grouped by service, billing period, currency and price and sorted in month
+// order, then in that order.
+func MonthlyChargeCounts(subscriptions []Subscription, period Period) []MonthlyCharges {
+	type key struct {
+		month         time.Time
+		name          ServiceName
+		billingPeriod BillingPeriod
+		currency      Currency
+		price         int
+	}
+
+	grouped := make(map[key]int)
+	for _, subscription := range subscriptions {
+		for _, charge := range subscription.Charges(period) {
+			grouped[key{
+				month:         charge.Month,
+				name:          charge.Name,
+				billingPeriod: charge.BillingPeriod,
+				currency:      charge.Currency,
+				price:         charge.Amount,
+			}]++
+		}
+	}
+
+	counts := make([]MonthlyCharges, 0, len(grouped))
+	for k, charges := range grouped {
+		counts = append(counts, MonthlyCharges{
+			Month:         k.month,
+			Name:          k.name,
+			BillingPeriod: k.billingPeriod,
+			Currency:      k.currency,
+			Price:         k.price,
+			Charges:       charges,
+		})
+	}
+	slices.SortFunc(counts, func(a, b MonthlyCharges) int {
+		return cmp.Or(
+			a.Month.Compare(b.Month),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.BillingPeriod, b.BillingPeriod),
+			cmp.Compare(a.Currency, b.Currency),
+			cmp.Compare(a.Price, b.Price),
+		)
+	})
+
+	return counts
+}
+
+// MonthlyCosts lays the charges within a bounded period out month by month,
+// months without charges are reported with zero totals.
+func MonthlyCosts(subscriptions []Subscription, period Period) []MonthlyCost {
+	type key struct {
+		month time.Time
+		name  ServiceName
+	}
+
+	grouped := make(map[key]int)
+	for _, subscription := range subscriptions {
+		for _, charge := range subscription.Charges(period) {
+			grouped[key{month: charge.Month, name: charge.Name}] += charge.Amount
+		}
+	}
+
+	costs := make([]MonthlyServiceCost, 0, len(grouped))
+	for k, cost := range grouped {
+		costs = append(costs, MonthlyServiceCost{Month: k.month, Name: k.name, Cost: cost})
+	}
+	slices.SortFunc(costs, func(a, b MonthlyServiceCost) int {
+		return cmp.Or(a.Month.Compare(b.Month), cmp.Compare(a.Name, b.Name))
+	})
+
+	return fillMonthlyCosts(period.Months(), costs)
+}
+
+func compareServiceTotalCosts(a, b ServiceTotalCost) int {
+	return cmp.Or(
+		cmp.Compare(a.Name, b.Name),
+		cmp.Compare(a.BillingPeriod, b.BillingPeriod),
+		cmp.Compare(a.Currency, b.Currency),
+		cmp.Compare(a.Price, b.Price),
+	)
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts the days from the day of from to the day of to.
+func daysBetween(from, to time.Time) int {
+	return int(dayStart(to).Sub(dayStart(from)).Hours() / 24)
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,263 @@
+package domain_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const billingPropertyRuns = 500
+
+func TestChargesMatchLegacyMonthCounting(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewPCG(1, 2))
+	for range billingPropertyRuns {
+		subscription := randomSubscription(random, "music")
+		period := randomBoundedPeriod(random)
+
+		charges := subscription.Charges(period)
+		require.Equal(t, legacyMonths(subscription, period), len(charges), subscription, period)
+
+		for i, charge := range charges {
+			require.True(t, period.Contains(charge.Month))
+			require.True(t, subscription.Period().Contains(charge.Month))
+			require.Equal(t, subscription.Cost, charge.Amount)
+			if i > 0 {
+				require.Equal(t, charges[i-1].Month.AddDate(0, 1, 0), charge.Month)
+			}
+		}
+	}
+}
+
+func TestBillingAggregatesAgree(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewPCG(3, 4))
+	for range billingPropertyRuns {
+		subscriptions := randomSubscriptions(random)
+		period := randomBoundedPeriod(random)
+
+		total := domain.TotalCost(subscriptions, period)
+
+		var byService int
+		for _, item := range domain.CostByService(subscriptions, period) {
+			require.Equal(t, item.Charges*item.Price, item.Cost)
+			byService += item.Cost
+		}
+		require.Equal(t, total, byService)
+
+		monthly := domain.MonthlyCosts(subscriptions, period)
+		require.Len(t, monthly, period.MonthCount())
+
+		var byMonth int
+		for _, month := range monthly {
+			var services int
+			for _, service := range month.Services {
+				services += service.Cost
+			}
+			require.Equal(t, month.Total, services)
+			byMonth += month.Total
+		}
+		require.Equal(t, total, byMonth)
+	}
+}
+
+func TestChargesByBillingPeriod(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	year := domain.NewPeriod(
+		start,
+		pointer.Ref(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	chargedMonths := func(subscription domain.Subscription) []time.Month {
+		var months []time.Month
+		for _, charge := range subscription.Charges(year) {
+			months = append(months, charge.Month.Month())
+		}
+		return months
+	}
+
+	tests := []struct {
+		name         string
+		subscription domain.Subscription
+		want         []time.Month
+	}{
+		{
+			name: "quarterly from the start month",
+			subscription: domain.Subscription{
+				StartDate:     time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: domain.BillingQuarterly,
+			},
+			want: []time.Month{time.February, time.May, time.August, time.November},
+		},
+		{
+			name: "quarterly from an anchor before the start",
+			subscription: domain.Subscription{
+				StartDate:     time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: domain.BillingQuarterly,
+				BillingAnchor: time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: []time.Month{time.March, time.June, time.September, time.December},
+		},
+		{
+			name: "yearly from an earlier year",
+			subscription: domain.Subscription{
+				StartDate:     time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: domain.BillingYearly,
+			},
+			want: []time.Month{time.October},
+		},
+		{
+			name: "yearly ending before its charge month",
+			subscription: domain.Subscription{
+				StartDate: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+				EndDate: pointer.Ref(
+					time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC),
+				),
+				BillingPeriod: domain.BillingYearly,
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, chargedMonths(tt.subscription))
+		})
+	}
+
+	t.Run("weekly", func(t *testing.T) {
+		t.Parallel()
+
+		weekly := domain.Subscription{
+			Cost:          10,
+			StartDate:     start,
+			BillingPeriod: domain.BillingWeekly,
+		}
+		months := domain.MonthlyCosts([]domain.Subscription{weekly}, year)
+
+		perMonth := make([]int, 0, len(months))
+		for _, month := range months {
+			perMonth = append(perMonth, month.Total/10)
+		}
+		require.Equal(t, []int{5, 4, 4, 5, 4, 4, 5, 4, 4, 5, 4, 5}, perMonth)
+		require.Equal(t, 530, domain.TotalCost([]domain.Subscription{weekly}, year))
+	})
+}
+
+func TestNormalizedMonthlyCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		period domain.BillingPeriod
+		price  int
+		want   int
+	}{
+		{"", 500, 500},
+		{domain.BillingMonthly, 500, 500},
+		{domain.BillingWeekly, 300, 1300},
+		{domain.BillingQuarterly, 900, 300},
+		{domain.BillingQuarterly, 1000, 333},
+		{domain.BillingYearly, 1200, 100},
+		{domain.BillingYearly, 1000, 83},
+	}
+
+	for _, tt := range tests {
+		subscription := domain.Subscription{
+			Cost:          tt.price,
+			StartDate:     time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod: tt.period,
+		}
+		require.Equal(t, tt.want, subscription.NormalizedMonthlyCost(), tt.period, tt.price)
+	}
+}
+
+func TestPeriodIntersectionProperties(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewPCG(5, 6))
+	for range billingPropertyRuns {
+		a := randomSubscription(random, "a").Period()
+		b := randomBoundedPeriod(random)
+
+		ab, okAB := a.Intersect(b)
+		ba, okBA := b.Intersect(a)
+		require.Equal(t, okAB, okBA)
+		require.Equal(t, ab, ba)
+
+		for _, month := range b.Months() {
+			require.Equal(t, a.Contains(month) && b.Contains(month), okAB && ab.Contains(month))
+		}
+	}
+}
+
+// legacyMonths ports the month arithmetic of the original total cost query.
+func legacyMonths(subscription domain.Subscription, period domain.Period) int {
+	start := subscription.StartDate
+	if period.Start.After(start) {
+		start = period.Start
+	}
+
+	end := *period.End
+	if subscription.EndDate != nil && subscription.EndDate.Before(end) {
+		end = *subscription.EndDate
+	}
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()) + 1
+
+	return max(months, 0)
+}
+
+func randomMonth(random *rand.Rand) time.Time {
+	return time.Date(2022+random.IntN(5), time.Month(1+random.IntN(12)), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func randomSubscription(random *rand.Rand, name domain.ServiceName) domain.Subscription {
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      name,
+		Cost:      random.IntN(2000),
+		UserID:    uuid.New(),
+		StartDate: randomMonth(random),
+	}
+	if random.IntN(3) > 0 {
+		subscription.EndDate = pointer.Ref(subscription.StartDate.AddDate(0, random.IntN(36), 0))
+	}
+
+	return subscription
+}
+
+func randomSubscriptions(random *rand.Rand) []domain.Subscription {
+	names := []domain.ServiceName{"music", "video", "cloud"}
+	periods := []domain.BillingPeriod{
+		domain.BillingWeekly,
+		domain.BillingMonthly,
+		domain.BillingQuarterly,
+		domain.BillingYearly,
+	}
+	subscriptions := make([]domain.Subscription, random.IntN(6))
+	for i := range subscriptions {
+		subscriptions[i] = randomSubscription(random, names[random.IntN(len(names))])
+		subscriptions[i].BillingPeriod = periods[random.IntN(len(periods))]
+		subscriptions[i].BillingAnchor = randomMonth(random)
+	}
+
+	return subscriptions
+}
+
+func randomBoundedPeriod(random *rand.Rand) domain.Period {
+	start := randomMonth(random)
+
+	return domain.NewPeriod(start, pointer.Ref(start.AddDate(0, random.IntN(48), 0)))
+}
@@ -0,0 +1,398 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+var (
+	ErrServiceCreateBudget = errors.Join(
+		errServiceSubscription,
+		errors.New("create budget failed"),
+	)
+	ErrServiceReadBudget = errors.Join(
+		errServiceSubscription,
+		errors.New("read budget failed"),
+	)
+	ErrServiceUpdateBudget = errors.Join(
+		errServiceSubscription,
+		errors.New("update budget failed"),
+	)
+	ErrServiceDeleteBudget = errors.Join(
+		errServiceSubscription,
+		errors.New("delete budget failed"),
+	)
+	ErrServiceListBudgets = errors.Join(
+		errServiceSubscription,
+		errors.New("list budgets failed"),
+	)
+	ErrServiceBudgetStatuses = errors.Join(
+		errServiceSubscription,
+		errors.New("budget statuses failed"),
+	)
+)
+
+func (p BudgetPeriod) IsValid() bool {
+	return p == BudgetMonthly || p == BudgetYearly
+}
+
+// Bounds returns the first and the last month of the period containing the
+// month of t.
+func (p BudgetPeriod) Bounds(t time.Time) (time.Time, time.Time) {
+	month := MonthStart(t)
+	if p == BudgetYearly {
+		start := month.AddDate(0, 1-int(month.Month()), 0)
+		return start, start.AddDate(0, 11, 0)
+	}
+
+	return month, month
+}
+
+// WithDefaults trims the scope of the budget, blank scopes are dropped, and
+// resolves the currency.
+func (b Budget) WithDefaults() Budget {
+	b.Currency = b.Currency.OrDefault()
+	if b.Category != nil {
+		category := strings.TrimSpace(*b.Category)
+		b.Category = &category
+		if category == "" {
+			b.Category = nil
+		}
+	}
+	if b.ServiceName != nil {
+		name := NormalizeServiceName(*b.ServiceName)
+		b.ServiceName = &name
+		if name == "" {
+			b.ServiceName = nil
+		}
+	}
+
+	return b
+}
+
+// Counts reports whether the subscription counts towards the budget.
+func (b Budget) Counts(subscription Subscription) bool {
+	switch {
+	case subscription.UserID != b.UserID:
+		return false
+	case b.Category != nil:
+		return subscription.Category == *b.Category
+	case b.ServiceName != nil:
+		return subscription.Name == *b.ServiceName
+	}
+
+	return true
+}
+
+// Remaining is what is left of the limit once the projected charges are
+// made, it is negative for budgets over their limit.
+func (s BudgetStatus) Remaining() int {
+	return s.Budget.Limit - s.Spent - s.Projected
+}
+
+func (s BudgetStatus) OverBudget() bool {
+	return s.Remaining() < 0
+}
+
+func (s *SubscriptionService) CreateBudget(ctx context.Context, budget Budget) (Budget, error) {
+	slog.DebugContext(ctx, "Service: creating budget.", log.RequestID(ctx))
+	budget = budget.WithDefaults()
+	if err := validateBudget(budget); err != nil {
+		return Budget{}, errors.Join(ErrServiceCreateBudget, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		if budget, err = s.resolveBudgetService(ctx, c, budget); err != nil {
+			return err
+		}
+
+		return s.budgetsRepo.Create(ctx, c, budget)
+	})
+	if err != nil {
+		return Budget{}, errors.Join(ErrServiceCreateBudget, err)
+	}
+
+	return budget, nil
+}
+
+func (s *SubscriptionService) ReadBudget(ctx context.Context, id BudgetID) (Budget, error) {
+	slog.DebugContext(ctx, "Service: reading budget.", log.RequestID(ctx))
+	var budget Budget
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		budget, err = s.budgetsRepo.Read(ctx, c, id)
+		return err
+	})
+	if err != nil {
+		return Budget{}, errors.Join(ErrServiceReadBudget, err)
+	}
+
+	return budget, nil
+}
+
+// UpdateBudget replaces the limit, period and scope of a budget, it stays
+// with its user.
+func (s *SubscriptionService) UpdateBudget(ctx context.Context, budget Budget) (Budget, error) {
+	slog.DebugContext(ctx, "Service: updating budget.", log.RequestID(ctx))
+	budget = budget.WithDefaults()
+	if err := validateBudget(budget); err != nil {
+		return Budget{}, errors.Join(ErrServiceUpdateBudget, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		current, err := s.budgetsRepo.Read(ctx, c, budget.ID)
+		if err != nil {
+			return err
+		}
+		budget.UserID = current.UserID
+		if budget, err = s.resolveBudgetService(ctx, c, budget); err != nil {
+			return err
+		}
+
+		return s.budgetsRepo.Update(ctx, c, budget)
+	})
+	if err != nil {
+		return Budget{}, errors.Join(ErrServiceUpdateBudget, err)
+	}
+
+	return budget, nil
+}
+
+func (s *SubscriptionService) DeleteBudget(ctx context.Context, id BudgetID) error {
+	slog.DebugContext(ctx, "Service: deleting budget.", log.RequestID(ctx))
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		return s.budgetsRepo.Delete(ctx, c, id)
+	})
+	if err != nil {
+		return errors.Join(ErrServiceDeleteBudget, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionService) ListBudgets(ctx context.Context, userID UserID) ([]Budget, error) {
+	slog.DebugContext(ctx, "Service: listing budgets.", log.RequestID(ctx))
+	var budgets []Budget
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		budgets, err = s.budgetsRepo.List(ctx, c, userID)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceListBudgets, err)
+	}
+
+	return budgets, nil
+}
+
+// BudgetStatuses counts the spend of every budget of the user as
+// ConvertedTotalCost does in the currency of the budget, up to the month of
+// at and projected over the rest of the period containing it.
+func (s *SubscriptionService) BudgetStatuses(
+	ctx context.Context,
+	userID UserID,
+	at time.Time,
+) ([]BudgetStatus, error) {
+	slog.DebugContext(ctx, "Service: calculating budget statuses.", log.RequestID(ctx))
+	var statuses []BudgetStatus
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		budgets, err := s.budgetsRepo.List(ctx, c, userID)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]BudgetStatus, 0, len(budgets))
+		for _, budget := range budgets {
+			status, err := s.budgetStatus(ctx, c, budget, at)
+			if err != nil {
+				return err
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceBudgetStatuses, err)
+	}
+
+	return statuses, nil
+}
+
+// BudgetWarnings reports the budgets the stored subscription counts towards
+// that are over their limit, in the period containing the current month or
+// the start month of the subscription, whichever is later.
+func (s *SubscriptionService) BudgetWarnings(
+	ctx context.Context,
+	subscription Subscription,
+) ([]BudgetStatus, error) {
+	slog.DebugContext(ctx, "Service: checking budgets.", log.RequestID(ctx))
+	at := MonthStart(time.Now())
+	if start := MonthStart(subscription.StartDate); start.After(at) {
+		at = start
+	}
+
+	var warnings []BudgetStatus
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		budgets, err := s.budgetsRepo.List(ctx, c, subscription.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, budget := range budgets {
+			start, end := budget.Period.Bounds(at)
+			if !budget.Counts(subscription) ||
+				TotalCost([]Subscription{subscription}, NewPeriod(start, &end)) == 0 {
+				continue
+			}
+
+			status, err := s.budgetStatus(ctx, c, budget, at)
+			if err != nil {
+				return err
+			}
+			if status.OverBudget() {
+				warnings = append(warnings, status)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceBudgetStatuses, err)
+	}
+
+	return warnings, nil
+}
+
+func (s *SubscriptionService) budgetStatus(
+	ctx context.Context,
+	c Connection,
+	budget Budget,
+	at time.Time,
+) (BudgetStatus, error) {
+	at = MonthStart(at)
+	status := BudgetStatus{Budget: budget}
+	status.Start, status.End = budget.Period.Bounds(at)
+
+	var err error
+	if status.Spent, err = s.budgetSpend(ctx, c, budget, status.Start, at); err != nil {
+		return BudgetStatus{}, err
+	}
+	if at.Before(status.End) {
+		status.Projected, err = s.budgetSpend(ctx, c, budget, at.AddDate(0, 1, 0), status.End)
+		if err != nil {
+			return BudgetStatus{}, err
+		}
+	}
+
+	return status, nil
+}
+
+// budgetSpend is the cost of the subscriptions the budget counts from the
+// month of start to the month of end in the currency of the budget. Rates
+// are only read when a charge is in another currency.
+func (s *SubscriptionService) budgetSpend(
+	ctx context.Context,
+	c Connection,
+	budget Budget,
+	start, end time.Time,
+) (int, error) {
+	var charges []MonthlyCharges
+	var err error
+	if budget.Category != nil {
+		charges, err = s.subscriptionRepo.CalculateCategoryCharges(
+			ctx,
+			c,
+			budget.UserID,
+			*budget.Category,
+			start,
+			&end,
+		)
+	} else {
+		var name ServiceName
+		if budget.ServiceName != nil {
+			name = *budget.ServiceName
+		}
+		charges, err = s.subscriptionRepo.CalculateMonthlyCharges(
+			ctx,
+			c,
+			budget.UserID,
+			name,
+			start,
+			&end,
+		)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var rates []ExchangeRate
+	if slices.ContainsFunc(charges, func(charge MonthlyCharges) bool {
+		return charge.Currency != budget.Currency
+	}) {
+		if rates, err = s.ratesRepo.List(ctx, c, budget.Currency, MonthStart(end)); err != nil {
+			return 0, err
+		}
+	}
+
+	converted, err := convertCharges(charges, rates, budget.Currency)
+	if err != nil {
+		return 0, err
+	}
+
+	return converted.Total, nil
+}
+
+// resolveBudgetService stores the service of a budget under its catalog name.
+func (s *SubscriptionService) resolveBudgetService(
+	ctx context.Context,
+	c Connection,
+	budget Budget,
+) (Budget, error) {
+	if budget.ServiceName == nil {
+		return budget, nil
+	}
+
+	name, err := s.canonicalName(ctx, c, *budget.ServiceName)
+	if err != nil {
+		return Budget{}, err
+	}
+	budget.ServiceName = &name
+
+	return budget, nil
+}
+
+func validateBudget(budget Budget) error {
+	var fields []FieldError
+	if !budget.Period.IsValid() {
+		fields = append(fields, FieldError{Field: "period", Message: "must be monthly or yearly"})
+	}
+	if budget.Limit <= 0 {
+		fields = append(fields, FieldError{Field: "limit", Message: "must be positive"})
+	}
+	if !budget.Currency.IsValid() {
+		fields = append(fields, FieldError{
+			Field:   "currency",
+			Message: "must be an ISO 4217 code such as RUB",
+		})
+	}
+	if budget.Category != nil && budget.ServiceName != nil {
+		fields = append(fields, FieldError{
+			Field:   "service_name",
+			Message: "must not be set together with category",
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_budget", "budget is invalid").WithFields(fields...)
+	}
+
+	return nil
+}
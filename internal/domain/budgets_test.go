@@ -0,0 +1,237 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetPeriodBounds(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, time.July, 15, 10, 0, 0, 0, time.UTC)
+
+	start, end := domain.BudgetMonthly.Bounds(at)
+	require.Equal(t, monthOf(2025, time.July), start)
+	require.Equal(t, monthOf(2025, time.July), end)
+
+	start, end = domain.BudgetYearly.Bounds(at)
+	require.Equal(t, monthOf(2025, time.January), start)
+	require.Equal(t, monthOf(2025, time.December), end)
+}
+
+func TestCreateBudgetValidates(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	_, err := service.CreateBudget(t.Context(), domain.Budget{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		Period:      "weekly",
+		Category:    pointer.Ref("video"),
+		ServiceName: pointer.Ref("Kinopoisk"),
+	})
+
+	var invalid *domain.Error
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, "invalid_budget", invalid.Code)
+	fields := make([]string, 0, len(invalid.Fields))
+	for _, field := range invalid.Fields {
+		fields = append(fields, field.Field)
+	}
+	require.Equal(t, []string{"period", "limit", "service_name"}, fields)
+
+	// Blank scopes are dropped and services stored under their catalog name.
+	_, err = service.CreateService(t.Context(), domain.Service{
+		ID:      uuid.New(),
+		Name:    "Yandex Plus",
+		Aliases: []string{"Yandex+"},
+	})
+	require.NoError(t, err)
+	budget, err := service.CreateBudget(t.Context(), domain.Budget{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		Period:      domain.BudgetMonthly,
+		Limit:       1000,
+		Category:    pointer.Ref("  "),
+		ServiceName: pointer.Ref("yandex+"),
+	})
+	require.NoError(t, err)
+	require.Nil(t, budget.Category)
+	require.Equal(t, pointer.Ref("Yandex Plus"), budget.ServiceName)
+}
+
+func TestBudgetStatuses(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	for _, subscription := range []domain.Subscription{
+		{Name: "Music", Cost: 300, Category: "music"},
+		{Name: "Video", Cost: 500, Category: "video"},
+		{
+			Name:          "Cloud",
+			Cost:          1200,
+			BillingPeriod: domain.BillingYearly,
+			BillingAnchor: monthOf(2025, time.October),
+		},
+	} {
+		subscription.ID = uuid.New()
+		subscription.UserID = userID
+		subscription.StartDate = monthOf(2025, time.January)
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+
+	var budgets []domain.Budget
+	for _, budget := range []domain.Budget{
+		{Period: domain.BudgetYearly, Limit: 10000},
+		{Period: domain.BudgetMonthly, Limit: 400, Category: pointer.Ref("video")},
+		{Period: domain.BudgetMonthly, Limit: 400, ServiceName: pointer.Ref("Music")},
+	} {
+		budget.ID = uuid.New()
+		budget.UserID = userID
+		created, err := service.CreateBudget(t.Context(), budget)
+		require.NoError(t, err)
+		budgets = append(budgets, created)
+	}
+
+	statuses, err := service.BudgetStatuses(t.Context(), userID, monthOf(2025, time.March))
+	require.NoError(t, err)
+	require.Equal(t, []domain.BudgetStatus{
+		{
+			// Three months charged, nine more to come and the yearly charge
+			// in October.
+			Budget:    budgets[0],
+			Start:     monthOf(2025, time.January),
+			End:       monthOf(2025, time.December),
+			Spent:     3 * 800,
+			Projected: 9*800 + 1200,
+		},
+		{
+			Budget: budgets[2],
+			Start:  monthOf(2025, time.March),
+			End:    monthOf(2025, time.March),
+			Spent:  300,
+		},
+		{
+			Budget: budgets[1],
+			Start:  monthOf(2025, time.March),
+			End:    monthOf(2025, time.March),
+			Spent:  500,
+		},
+	}, statuses)
+	require.Equal(t, 10000-10800, statuses[0].Remaining())
+	require.True(t, statuses[0].OverBudget())
+	require.False(t, statuses[1].OverBudget())
+	require.True(t, statuses[2].OverBudget())
+}
+
+func TestBudgetStatusesConvertToTheBudgetCurrency(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	for _, subscription := range []domain.Subscription{
+		{Name: "Music", Cost: 1000, Currency: "USD", Category: "music"},
+		{Name: "Video", Cost: 90000, Category: "video"},
+		{Name: "Kinopoisk", Cost: 45000, Category: "video"},
+	} {
+		subscription.ID = uuid.New()
+		subscription.UserID = userID
+		subscription.StartDate = monthOf(2025, time.January)
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+
+	var budgets []domain.Budget
+	for _, budget := range []domain.Budget{
+		{Period: domain.BudgetMonthly, Limit: 2000, Currency: "USD"},
+		{Period: domain.BudgetMonthly, Limit: 1000, Currency: "USD", Category: pointer.Ref("video")},
+		{Period: domain.BudgetMonthly, Limit: 1000, Currency: "USD", Category: pointer.Ref("music")},
+	} {
+		budget.ID = uuid.New()
+		budget.UserID = userID
+		created, err := service.CreateBudget(t.Context(), budget)
+		require.NoError(t, err)
+		budgets = append(budgets, created)
+	}
+
+	_, err := service.BudgetStatuses(t.Context(), userID, monthOf(2025, time.March))
+	require.ErrorIs(t, err, domain.ErrExchangeRateMissing)
+
+	require.NoError(t, service.ImportExchangeRates(t.Context(), []domain.ExchangeRate{
+		{Base: "USD", Quote: "RUB", EffectiveFrom: monthOf(2025, time.January), Rate: 90},
+	}))
+	statuses, err := service.BudgetStatuses(t.Context(), userID, monthOf(2025, time.March))
+	require.NoError(t, err)
+
+	spent := make(map[string]int, len(statuses))
+	for _, status := range statuses {
+		key := "all"
+		if status.Budget.Category != nil {
+			key = *status.Budget.Category
+		}
+		spent[key] = status.Spent
+	}
+	require.Equal(t, map[string]int{
+		"all":   1000 + 1000 + 500,
+		"music": 1000,
+		"video": 1000 + 500,
+	}, spent)
+
+	_, err = service.CreateBudget(t.Context(), domain.Budget{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Period:   domain.BudgetMonthly,
+		Limit:    1000,
+		Currency: "usd",
+	})
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
+
+func TestBudgetWarnings(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	budget, err := service.CreateBudget(t.Context(), domain.Budget{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Period:   domain.BudgetMonthly,
+		Limit:    500,
+		Category: pointer.Ref("video"),
+	})
+	require.NoError(t, err)
+
+	create := func(name string, category string) []domain.BudgetStatus {
+		subscription := domain.Subscription{
+			ID:        uuid.New(),
+			Name:      name,
+			Cost:      300,
+			UserID:    userID,
+			StartDate: domain.MonthStart(time.Now()),
+			Category:  category,
+		}
+		require.NoError(t, service.Create(t.Context(), subscription))
+		stored, err := service.ReadByID(t.Context(), subscription.ID)
+		require.NoError(t, err)
+
+		warnings, err := service.BudgetWarnings(t.Context(), stored)
+		require.NoError(t, err)
+		return warnings
+	}
+
+	require.Empty(t, create("Kinopoisk", "video"))
+	// Not in the category, so it does not count towards the budget.
+	require.Empty(t, create("Music", "music"))
+
+	warnings := create("Netflix", "video")
+	require.Len(t, warnings, 1)
+	require.Equal(t, budget, warnings[0].Budget)
+	require.Equal(t, 600, warnings[0].Spent)
+	require.Equal(t, -100, warnings[0].Remaining())
+}
@@ -0,0 +1,349 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+var (
+	ErrServiceCreateService = errors.Join(
+		errServiceSubscription,
+		errors.New("create catalog service failed"),
+	)
+	ErrServiceReadService = errors.Join(
+		errServiceSubscription,
+		errors.New("read catalog service failed"),
+	)
+	ErrServiceUpdateService = errors.Join(
+		errServiceSubscription,
+		errors.New("update catalog service failed"),
+	)
+	ErrServiceDeleteService = errors.Join(
+		errServiceSubscription,
+		errors.New("delete catalog service failed"),
+	)
+	ErrServiceListServices = errors.Join(
+		errServiceSubscription,
+		errors.New("list catalog services failed"),
+	)
+	ErrServiceResolveService = errors.Join(
+		errServiceSubscription,
+		errors.New("resolve catalog service failed"),
+	)
+)
+
+// NormalizeServiceName trims a service name and collapses the runs of spaces
+// inside it.
+func NormalizeServiceName(name ServiceName) ServiceName {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// ServiceKey is what catalog names and aliases are matched by, the
+// normalized name in lower case.
+func ServiceKey(name ServiceName) string {
+	return strings.ToLower(NormalizeServiceName(name))
+}
+
+// WithDefaults normalizes the name and aliases, drops aliases that repeat the
+// name or each other, sorts the rest and fills in the default currency and
+// billing period.
+func (s Service) WithDefaults() Service {
+	s.Name = NormalizeServiceName(s.Name)
+	s.Category = strings.TrimSpace(s.Category)
+	s.Currency = s.Currency.OrDefault()
+	s.BillingPeriod = s.BillingPeriod.OrMonthly()
+
+	seen := map[string]bool{ServiceKey(s.Name): true}
+	var aliases []string
+	for _, alias := range s.Aliases {
+		alias = NormalizeServiceName(alias)
+		if key := ServiceKey(alias); key != "" && !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.Sort(aliases)
+	s.Aliases = aliases
+
+	return s
+}
+
+// Keys returns the keys of the name and the aliases, the name first.
+func (s Service) Keys() []string {
+	keys := []string{ServiceKey(s.Name)}
+	for _, alias := range s.Aliases {
+		keys = append(keys, ServiceKey(alias))
+	}
+
+	return keys
+}
+
+// CreateService adds a service to the catalog and moves the subscriptions and
+// budgets named after it or one of its aliases under its name.
+func (s *SubscriptionService) CreateService(ctx context.Context, service Service) (Service, error) {
+	slog.DebugContext(ctx, "Service: creating catalog service.", log.RequestID(ctx))
+	service = service.WithDefaults()
+	if err := validateService(service); err != nil {
+		return Service{}, errors.Join(ErrServiceCreateService, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		if err := s.ensureKeysFree(ctx, c, service); err != nil {
+			return err
+		}
+		if err := s.servicesRepo.Create(ctx, c, service); err != nil {
+			return err
+		}
+		if err := s.link(ctx, c, service); err != nil {
+			return err
+		}
+		_, err := s.budgetsRepo.RenameService(ctx, c, service.Keys(), service.Name)
+		return err
+	})
+	if err != nil {
+		return Service{}, errors.Join(ErrServiceCreateService, err)
+	}
+
+	return service, nil
+}
+
+// link moves the subscriptions of the service under its name and records an
+// update event for each one it changed.
+func (s *SubscriptionService) link(ctx context.Context, c Connection, service Service) error {
+	changes, err := s.servicesRepo.Link(ctx, c, service)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if err := s.record(ctx, c, SubscriptionEventUpdated, &change.Before, &change.After); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateService replaces a catalog entry, renaming the subscriptions linked to
+// it and linking the ones named after its new aliases. Budgets scoped to its
+// old or new name or aliases follow it the same way.
+func (s *SubscriptionService) UpdateService(ctx context.Context, service Service) (Service, error) {
+	slog.DebugContext(ctx, "Service: updating catalog service.", log.RequestID(ctx))
+	service = service.WithDefaults()
+	if err := validateService(service); err != nil {
+		return Service{}, errors.Join(ErrServiceUpdateService, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		previous, err := s.servicesRepo.Read(ctx, c, service.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.ensureKeysFree(ctx, c, service); err != nil {
+			return err
+		}
+		if err := s.servicesRepo.Update(ctx, c, service); err != nil {
+			return err
+		}
+		if err := s.link(ctx, c, service); err != nil {
+			return err
+		}
+		_, err = s.budgetsRepo.RenameService(
+			ctx,
+			c,
+			append(previous.Keys(), service.Keys()...),
+			service.Name,
+		)
+		return err
+	})
+	if err != nil {
+		return Service{}, errors.Join(ErrServiceUpdateService, err)
+	}
+
+	return service, nil
+}
+
+// DeleteService removes a catalog entry no subscription is linked to.
+func (s *SubscriptionService) DeleteService(ctx context.Context, id ServiceID) error {
+	slog.DebugContext(ctx, "Service: deleting catalog service.", log.RequestID(ctx))
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		return s.servicesRepo.Delete(ctx, c, id)
+	})
+	if err != nil {
+		return errors.Join(ErrServiceDeleteService, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionService) ReadService(ctx context.Context, id ServiceID) (Service, error) {
+	slog.DebugContext(ctx, "Service: reading catalog service.", log.RequestID(ctx))
+	var service Service
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		service, err = s.servicesRepo.Read(ctx, c, id)
+		return err
+	})
+	if err != nil {
+		return Service{}, errors.Join(ErrServiceReadService, err)
+	}
+
+	return service, nil
+}
+
+func (s *SubscriptionService) ListServices(
+	ctx context.Context,
+	filter ServiceFilter,
+) ([]Service, error) {
+	slog.DebugContext(ctx, "Service: listing catalog services.", log.RequestID(ctx))
+	var services []Service
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		services, err = s.servicesRepo.List(ctx, c, filter)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceListServices, err)
+	}
+
+	return services, nil
+}
+
+func (s *SubscriptionService) ResolveService(
+	ctx context.Context,
+	name ServiceName,
+) (Service, error) {
+	var service Service
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		service, err = s.servicesRepo.Resolve(ctx, c, ServiceKey(name))
+		return err
+	})
+	if err != nil {
+		return Service{}, errors.Join(ErrServiceResolveService, err)
+	}
+
+	return service, nil
+}
+
+// resolveService links the subscription to the catalog entry its name refers
+// to and stores it under the name of the entry, in the category of the entry
+// unless it has one. Names missing from the catalog are only normalized.
+func (s *SubscriptionService) resolveService(
+	ctx context.Context,
+	c Connection,
+	subscription Subscription,
+) (Subscription, error) {
+	subscription.Name = NormalizeServiceName(subscription.Name)
+	subscription.Category = strings.TrimSpace(subscription.Category)
+	subscription.ServiceID = nil
+
+	service, err := s.servicesRepo.Resolve(ctx, c, ServiceKey(subscription.Name))
+	if errors.Is(err, ErrServiceNotFound) {
+		return subscription, nil
+	}
+	if err != nil {
+		return Subscription{}, errors.Join(ErrServiceResolveService, err)
+	}
+
+	subscription.Name = service.Name
+	subscription.ServiceID = &service.ID
+	if subscription.Category == "" {
+		subscription.Category = service.Category
+	}
+
+	return subscription, nil
+}
+
+// canonicalName is the name the subscriptions to the named service are stored
+// under, an empty name stays empty.
+func (s *SubscriptionService) canonicalName(
+	ctx context.Context,
+	c Connection,
+	name ServiceName,
+) (ServiceName, error) {
+	if NormalizeServiceName(name) == "" {
+		return name, nil
+	}
+
+	resolved, err := s.resolveService(ctx, c, Subscription{Name: name})
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.Name, nil
+}
+
+// ensureKeysFree rejects a service whose name or aliases already belong to
+// another catalog entry.
+func (s *SubscriptionService) ensureKeysFree(
+	ctx context.Context,
+	c Connection,
+	service Service,
+) error {
+	var fields []FieldError
+	for i, key := range service.Keys() {
+		other, err := s.servicesRepo.Resolve(ctx, c, key)
+		if errors.Is(err, ErrServiceNotFound) || (err == nil && other.ID == service.ID) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		field := "name"
+		if i > 0 {
+			field = fmt.Sprintf("aliases[%d]", i-1)
+		}
+		fields = append(fields, FieldError{
+			Field:   field,
+			Message: "belongs to service " + other.Name,
+		})
+	}
+
+	if len(fields) > 0 {
+		return ErrServiceNameTaken.WithFields(fields...)
+	}
+
+	return nil
+}
+
+func validateService(service Service) error {
+	var fields []FieldError
+	if service.Name == "" {
+		fields = append(fields, FieldError{
+			Field:   "name",
+			Message: "name must not be empty",
+		})
+	}
+	if service.DefaultPrice != nil && *service.DefaultPrice < 0 {
+		fields = append(fields, FieldError{
+			Field:   "default_price",
+			Message: "default price must not be negative",
+		})
+	}
+	if !service.BillingPeriod.IsValid() {
+		fields = append(fields, FieldError{
+			Field:   "billing_period",
+			Message: "billing period must be weekly, monthly, quarterly or yearly",
+		})
+	}
+	if !service.Currency.IsValid() {
+		fields = append(fields, FieldError{
+			Field:   "currency",
+			Message: "currency must be an ISO 4217 code such as RUB",
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_service", "service is invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
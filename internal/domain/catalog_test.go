@@ -0,0 +1,250 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceKey(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "Yandex Plus", domain.NormalizeServiceName("  Yandex \t Plus "))
+	require.Equal(t, "yandex plus", domain.ServiceKey("YANDEX  plus "))
+	require.Equal(t, "яндекс плюс", domain.ServiceKey("Яндекс Плюс"))
+
+	service := domain.Service{
+		Name:    " Yandex  Plus",
+		Aliases: []string{"yandex plus", "Yandex+", " yandex+ ", ""},
+	}.WithDefaults()
+	require.Equal(t, "Yandex Plus", service.Name)
+	require.Equal(t, []string{"Yandex+"}, service.Aliases)
+	require.Equal(t, []string{"yandex plus", "yandex+"}, service.Keys())
+	require.Equal(t, domain.DefaultCurrency, service.Currency)
+	require.Equal(t, domain.BillingMonthly, service.BillingPeriod)
+}
+
+func TestSubscriptionsResolveCatalogNames(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	plus, err := service.CreateService(t.Context(), domain.Service{
+		ID:      uuid.New(),
+		Name:    "Yandex Plus",
+		Aliases: []string{"Yandex+"},
+	})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "yandex+",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	stored, err := service.ReadByID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Yandex Plus", stored.Name)
+	require.Equal(t, &plus.ID, stored.ServiceID)
+
+	// Another spelling is the same service, so it overlaps.
+	err = service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      " YANDEX  PLUS",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.March),
+	})
+	require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+
+	total, err := service.TotalSubscriptionsCost(
+		t.Context(),
+		userID,
+		"yandex+",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.March)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 300, total)
+
+	// Names missing from the catalog are only normalized.
+	other := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "  Some   Service ",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+	}
+	require.NoError(t, service.Create(t.Context(), other))
+	stored, err = service.ReadByID(t.Context(), other.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Some Service", stored.Name)
+	require.Nil(t, stored.ServiceID)
+}
+
+func TestCreateServiceLinksExistingSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	for i, name := range []domain.ServiceName{"Netflix", "netflix", "NETFLIX"} {
+		require.NoError(t, service.Create(t.Context(), domain.Subscription{
+			ID:        uuid.New(),
+			Name:      name,
+			Cost:      100,
+			UserID:    userID,
+			StartDate: monthOf(2025, time.Month(1+2*i)),
+			EndDate:   pointer.Ref(monthOf(2025, time.Month(2+2*i))),
+		}))
+	}
+
+	breakdown, err := service.TotalSubscriptionsCostByService(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.June)),
+	)
+	require.NoError(t, err)
+	require.Len(t, breakdown.Items, 3)
+
+	netflix, err := service.CreateService(t.Context(), domain.Service{
+		ID:           uuid.New(),
+		Name:         "Netflix",
+		Category:     "video",
+		DefaultPrice: pointer.Ref(79900),
+	})
+	require.NoError(t, err)
+
+	breakdown, err = service.TotalSubscriptionsCostByService(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.June)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ServiceTotalCost{{
+		Name:          "Netflix",
+		BillingPeriod: domain.BillingMonthly,
+		Currency:      domain.DefaultCurrency,
+		Charges:       6,
+		Price:         100,
+		Cost:          600,
+	}}, breakdown.Items)
+
+	// Every linked subscription has the link in its history.
+	page, err := service.History(t.Context(), domain.EventQuery{UserID: &userID, Limit: 10})
+	require.NoError(t, err)
+	renamed := make(map[domain.ServiceName]domain.ServiceName)
+	for _, event := range page.Events {
+		if event.Type != domain.SubscriptionEventUpdated {
+			continue
+		}
+		require.Equal(t, &netflix.ID, event.After.ServiceID)
+		renamed[event.Before.Name] = event.After.Name
+	}
+	require.Equal(t, map[domain.ServiceName]domain.ServiceName{
+		"Netflix": "Netflix",
+		"netflix": "Netflix",
+		"NETFLIX": "Netflix",
+	}, renamed)
+
+	err = service.DeleteService(t.Context(), netflix.ID)
+	require.ErrorIs(t, err, domain.ErrServiceInUse)
+	require.Equal(t, domain.ErrorKindConflict, domain.KindOf(err))
+}
+
+func TestCatalogRejectsTakenNames(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	plus, err := service.CreateService(t.Context(), domain.Service{
+		ID:      uuid.New(),
+		Name:    "Yandex Plus",
+		Aliases: []string{"Yandex+"},
+	})
+	require.NoError(t, err)
+
+	_, err = service.CreateService(t.Context(), domain.Service{
+		ID:      uuid.New(),
+		Name:    "Plus",
+		Aliases: []string{"yandex+", "Yandex Plus Family"},
+	})
+	require.ErrorIs(t, err, domain.ErrServiceNameTaken)
+	domainErr, ok := domain.AsError(err)
+	require.True(t, ok)
+	require.Equal(t, []domain.FieldError{{
+		Field:   "aliases[1]",
+		Message: "belongs to service Yandex Plus",
+	}}, domainErr.Fields)
+
+	// A service keeps its own keys when it is saved again.
+	plus.Aliases = append(plus.Aliases, "Яндекс Плюс")
+	_, err = service.UpdateService(t.Context(), plus)
+	require.NoError(t, err)
+
+	_, err = service.UpdateService(t.Context(), domain.Service{ID: uuid.New(), Name: "Missing"})
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+
+	_, err = service.CreateService(t.Context(), domain.Service{ID: uuid.New(), Name: "  "})
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
+
+func TestCatalogMovesScopedBudgets(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Muzyka",
+		Cost:      300,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+	}))
+	budget, err := service.CreateBudget(t.Context(), domain.Budget{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Period:      domain.BudgetMonthly,
+		Limit:       1000,
+		ServiceName: pointer.Ref("muzyka"),
+	})
+	require.NoError(t, err)
+
+	requireScopedTo := func(name string) {
+		t.Helper()
+
+		stored, err := service.ReadBudget(t.Context(), budget.ID)
+		require.NoError(t, err)
+		require.Equal(t, &name, stored.ServiceName)
+
+		statuses, err := service.BudgetStatuses(t.Context(), userID, monthOf(2025, time.March))
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		require.Equal(t, 300, statuses[0].Spent)
+	}
+
+	// Linking the old name as an alias moves the budget with the subscription.
+	music, err := service.CreateService(t.Context(), domain.Service{
+		ID:      uuid.New(),
+		Name:    "Yandex Music",
+		Aliases: []string{"Muzyka"},
+	})
+	require.NoError(t, err)
+	requireScopedTo("Yandex Music")
+
+	// So does renaming the service.
+	music.Name = "Music Plus"
+	_, err = service.UpdateService(t.Context(), music)
+	require.NoError(t, err)
+	requireScopedTo("Music Plus")
+}
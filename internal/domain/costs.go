@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// maxCostSeriesMonths bounds the length of a monthly cost series.
+const maxCostSeriesMonths = 240
+
+var (
+	ErrServiceMonthlySubscriptionsCost = errors.Join(
+		errServiceSubscription,
+		errors.New("monthly cost failed"),
+	)
+	ErrServiceTotalCostByGroup = errors.Join(
+		errServiceSubscription,
+		errors.New("total cost by group failed"),
+	)
+	ErrServiceForecastSubscriptionsCost = errors.Join(
+		errServiceSubscription,
+		errors.New("forecast failed"),
+	)
+)
+
+func (s *SubscriptionService) TotalSubscriptionsCostByService(
+	ctx context.Context,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	start time.Time,
+	end *time.Time,
+) (TotalCostBreakdown, error) {
+	slog.DebugContext(ctx, "Service: calculating total cost by service.", log.RequestID(ctx))
+	var items []ServiceTotalCost
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		subscriptionName, dbErr := s.canonicalName(ctx, c, subscriptionName)
+		if dbErr != nil {
+			return dbErr
+		}
+		dbErr = s.ensureOneCurrency(ctx, c, subscriptionUserID, subscriptionName, start, end)
+		if dbErr != nil {
+			return dbErr
+		}
+		items, dbErr = s.subscriptionRepo.CalculateTotalCostByService(
+			ctx,
+			c,
+			subscriptionUserID,
+			subscriptionName,
+			start,
+			end,
+		)
+		return dbErr
+	})
+	if err != nil {
+		return TotalCostBreakdown{}, errors.Join(ErrServiceTotalSubscriptionsCost, err)
+	}
+
+	breakdown := TotalCostBreakdown{Items: items}
+	for _, item := range items {
+		breakdown.Total += item.Cost
+	}
+
+	return breakdown, nil
+}
+
+// TotalSubscriptionsCostByGroup is the total cost rolled up by category or by
+// tag, months are counted as in TotalSubscriptionsCost.
+func (s *SubscriptionService) TotalSubscriptionsCostByGroup(
+	ctx context.Context,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	start time.Time,
+	end *time.Time,
+	group CostGroup,
+) (GroupedCost, error) {
+	slog.DebugContext(ctx, "Service: calculating total cost by group.", log.RequestID(ctx))
+	if !group.IsValid() {
+		return GroupedCost{}, errors.Join(
+			ErrServiceTotalCostByGroup,
+			NewValidationError("invalid_group_by", "unsupported grouping").WithFields(FieldError{
+				Field:   "group_by",
+				Message: "must be category or tag",
+			}),
+		)
+	}
+
+	var grouped GroupedCost
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		subscriptionName, dbErr := s.canonicalName(ctx, c, subscriptionName)
+		if dbErr != nil {
+			return dbErr
+		}
+		dbErr = s.ensureOneCurrency(ctx, c, subscriptionUserID, subscriptionName, start, end)
+		if dbErr != nil {
+			return dbErr
+		}
+		grouped.Total, dbErr = s.subscriptionRepo.CalculateTotalCost(
+			ctx,
+			c,
+			subscriptionUserID,
+			subscriptionName,
+			start,
+			end,
+		)
+		if dbErr != nil {
+			return dbErr
+		}
+		grouped.Groups, dbErr = s.subscriptionRepo.CalculateTotalCostByGroup(
+			ctx,
+			c,
+			subscriptionUserID,
+			subscriptionName,
+			start,
+			end,
+			group,
+		)
+		return dbErr
+	})
+	if err != nil {
+		return GroupedCost{}, errors.Join(ErrServiceTotalCostByGroup, err)
+	}
+
+	return grouped, nil
+}
+
+func (s *SubscriptionService) MonthlySubscriptionsCost(
+	ctx context.Context,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]MonthlyCost, error) {
+	slog.DebugContext(ctx, "Service: calculating monthly costs.", log.RequestID(ctx))
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	period := NewPeriod(start, end)
+	if err := validateCostPeriod(period); err != nil {
+		return nil, errors.Join(ErrServiceMonthlySubscriptionsCost, err)
+	}
+
+	var costs []MonthlyServiceCost
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		subscriptionName, dbErr := s.canonicalName(ctx, c, subscriptionName)
+		if dbErr != nil {
+			return dbErr
+		}
+		dbErr = s.ensureOneCurrency(ctx, c, subscriptionUserID, subscriptionName, start, end)
+		if dbErr != nil {
+			return dbErr
+		}
+		costs, dbErr = s.subscriptionRepo.CalculateMonthlyCosts(
+			ctx,
+			c,
+			subscriptionUserID,
+			subscriptionName,
+			start,
+			end,
+		)
+		return dbErr
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceMonthlySubscriptionsCost, err)
+	}
+
+	return fillMonthlyCosts(period.Months(), costs), nil
+}
+
+// ForecastSubscriptionsCost is MonthlySubscriptionsCost over a number of
+// months starting with the month of from. Every subscription stored so far
+// is projected, future ones included, with its end date, scheduled prices and
+// billing period.
+func (s *SubscriptionService) ForecastSubscriptionsCost(
+	ctx context.Context,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	from time.Time,
+	months int,
+) ([]MonthlyCost, error) {
+	if months < 1 || months > maxCostSeriesMonths {
+		return nil, errors.Join(
+			ErrServiceForecastSubscriptionsCost,
+			NewValidationError(
+				"invalid_period",
+				"forecast length is out of range",
+			).WithFields(FieldError{
+				Field:   "months",
+				Message: fmt.Sprintf("must be between 1 and %d", maxCostSeriesMonths),
+			}),
+		)
+	}
+
+	start := MonthStart(from)
+	end := start.AddDate(0, months-1, 0)
+	forecast, err := s.MonthlySubscriptionsCost(
+		ctx,
+		subscriptionUserID,
+		subscriptionName,
+		start,
+		&end,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrServiceForecastSubscriptionsCost, err)
+	}
+
+	return forecast, nil
+}
+
+// ensureOneCurrency rejects a sum over subscriptions billed in different
+// currencies, only ConvertedTotalCost adds those up.
+func (s *SubscriptionService) ensureOneCurrency(
+	ctx context.Context,
+	c Connection,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	start time.Time,
+	end *time.Time,
+) error {
+	currencies, err := s.subscriptionRepo.BilledCurrencies(
+		ctx,
+		c,
+		subscriptionUserID,
+		subscriptionName,
+		start,
+		end,
+	)
+	if err != nil {
+		return err
+	}
+	if len(currencies) < 2 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		codes = append(codes, string(currency))
+	}
+	return ErrMixedCurrencies.WithFields(FieldError{
+		Field: "currency",
+		Message: fmt.Sprintf(
+			"subscriptions are billed in %s, request the total in one currency",
+			strings.Join(codes, ", "),
+		),
+	})
+}
+
+// fillMonthlyCosts spreads per-service costs over the full month series so
+// months without charges are reported with zero totals.
+func fillMonthlyCosts(months []time.Time, costs []MonthlyServiceCost) []MonthlyCost {
+	series := make([]MonthlyCost, len(months))
+	index := make(map[time.Time]int, len(months))
+	for i, month := range months {
+		series[i] = MonthlyCost{Month: month, Services: []ServiceCost{}}
+		index[month] = i
+	}
+
+	for _, cost := range costs {
+		i, ok := index[MonthStart(cost.Month)]
+		if !ok {
+			continue
+		}
+		series[i].Total += cost.Cost
+		series[i].Services = append(series[i].Services, ServiceCost{
+			Name: cost.Name,
+			Cost: cost.Cost,
+		})
+	}
+
+	return series
+}
+
+func validateCostPeriod(period Period) error {
+	if period.IsEmpty() {
+		return NewValidationError("invalid_period", "end date must not be before start date").
+			WithFields(FieldError{Field: "end_date", Message: "must not be before start_date"})
+	}
+	if period.MonthCount() > maxCostSeriesMonths {
+		return NewValidationError("invalid_period", "period is too long").
+			WithFields(FieldError{Field: "end_date", Message: "period must not exceed 240 months"})
+	}
+
+	return nil
+}
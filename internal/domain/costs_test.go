@@ -0,0 +1,242 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type monthlyCostsRepository struct {
+	domain.SubscriptionsRepository
+	costs      []domain.MonthlyServiceCost
+	currencies []domain.Currency
+}
+
+func (r monthlyCostsRepository) BilledCurrencies(
+	context.Context,
+	domain.Connection,
+	domain.UserID,
+	domain.ServiceName,
+	time.Time,
+	*time.Time,
+) ([]domain.Currency, error) {
+	return r.currencies, nil
+}
+
+func (r monthlyCostsRepository) CalculateMonthlyCosts(
+	context.Context,
+	domain.Connection,
+	domain.UserID,
+	domain.ServiceName,
+	time.Time,
+	*time.Time,
+) ([]domain.MonthlyServiceCost, error) {
+	return r.costs, nil
+}
+
+func TestMonthlySubscriptionsCostFillsEmptyMonths(t *testing.T) {
+	t.Parallel()
+
+	month := func(m time.Month) time.Time { return time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	service := domain.NewSubscriptionService(
+		database.NewDummyProvider(nil),
+		domain.Repositories{
+			Subscriptions: monthlyCostsRepository{costs: []domain.MonthlyServiceCost{
+				{Month: month(time.January), Name: "Music", Cost: 100},
+				{Month: month(time.January), Name: "Video", Cost: 300},
+				{Month: month(time.March), Name: "Music", Cost: 100},
+			}},
+		},
+	)
+
+	months, err := service.MonthlySubscriptionsCost(
+		t.Context(),
+		uuid.New(),
+		"",
+		month(time.January),
+		pointer.Ref(month(time.April)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []domain.MonthlyCost{
+		{
+			Month: month(time.January),
+			Total: 400,
+			Services: []domain.ServiceCost{
+				{Name: "Music", Cost: 100},
+				{Name: "Video", Cost: 300},
+			},
+		},
+		{Month: month(time.February), Total: 0, Services: []domain.ServiceCost{}},
+		{
+			Month:    month(time.March),
+			Total:    100,
+			Services: []domain.ServiceCost{{Name: "Music", Cost: 100}},
+		},
+		{Month: month(time.April), Total: 0, Services: []domain.ServiceCost{}},
+	}, months)
+}
+
+func TestMonthlySubscriptionsCostRejectsInvertedPeriod(t *testing.T) {
+	t.Parallel()
+
+	service := domain.NewSubscriptionService(
+		database.NewDummyProvider(nil),
+		domain.Repositories{
+			Subscriptions: monthlyCostsRepository{},
+		},
+	)
+
+	start := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	_, err := service.MonthlySubscriptionsCost(
+		t.Context(),
+		uuid.New(),
+		"",
+		start,
+		pointer.Ref(start.AddDate(0, -1, 0)),
+	)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
+
+func TestCostsRejectMixedCurrencies(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	for _, subscription := range []domain.Subscription{
+		{
+			ID:        uuid.New(),
+			Name:      "Music",
+			Cost:      10000,
+			UserID:    userID,
+			StartDate: monthOf(2025, time.January),
+		},
+		{
+			// Ends before the video subscription in dollars starts.
+			ID:        uuid.New(),
+			Name:      "Video",
+			Cost:      30000,
+			UserID:    userID,
+			StartDate: monthOf(2024, time.January),
+			EndDate:   pointer.Ref(monthOf(2024, time.December)),
+		},
+		{
+			ID:        uuid.New(),
+			Name:      "Video",
+			Cost:      500,
+			Currency:  "USD",
+			UserID:    userID,
+			StartDate: monthOf(2025, time.March),
+		},
+	} {
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+
+	start, end := monthOf(2025, time.January), pointer.Ref(monthOf(2025, time.June))
+	_, err := service.TotalSubscriptionsCost(t.Context(), userID, "", start, end)
+	require.ErrorIs(t, err, domain.ErrMixedCurrencies)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+	_, err = service.TotalSubscriptionsCostByService(t.Context(), userID, "", start, end)
+	require.ErrorIs(t, err, domain.ErrMixedCurrencies)
+	_, err = service.TotalSubscriptionsCostByGroup(
+		t.Context(),
+		userID,
+		"",
+		start,
+		end,
+		domain.CostGroupCategory,
+	)
+	require.ErrorIs(t, err, domain.ErrMixedCurrencies)
+	_, err = service.MonthlySubscriptionsCost(t.Context(), userID, "", start, end)
+	require.ErrorIs(t, err, domain.ErrMixedCurrencies)
+
+	// One currency within the period, or within the service, adds up.
+	total, err := service.TotalSubscriptionsCost(
+		t.Context(),
+		userID,
+		"",
+		start,
+		pointer.Ref(monthOf(2025, time.February)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2*10000, total)
+	total, err = service.TotalSubscriptionsCost(t.Context(), userID, "Video", start, end)
+	require.NoError(t, err)
+	require.Equal(t, 4*500, total)
+}
+
+func TestForecastSubscriptionsCost(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	music := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+		EndDate:   pointer.Ref(monthOf(2025, time.May)),
+	}
+	for _, subscription := range []domain.Subscription{
+		music,
+		{
+			// Starts after the forecast begins.
+			ID:        uuid.New(),
+			Name:      "Video",
+			Cost:      300,
+			UserID:    userID,
+			StartDate: monthOf(2025, time.April),
+		},
+		{
+			ID:            uuid.New(),
+			Name:          "Cloud",
+			Cost:          1200,
+			UserID:        userID,
+			StartDate:     monthOf(2024, time.May),
+			BillingPeriod: domain.BillingYearly,
+			BillingAnchor: monthOf(2024, time.May),
+		},
+	} {
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+	_, err := service.SchedulePriceChange(t.Context(), domain.PriceChange{
+		SubscriptionID: music.ID,
+		EffectiveFrom:  monthOf(2025, time.April),
+		Price:          150,
+	})
+	require.NoError(t, err)
+
+	forecast, err := service.ForecastSubscriptionsCost(
+		t.Context(),
+		userID,
+		"",
+		time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+		4,
+	)
+	require.NoError(t, err)
+
+	totals := make([]int, 0, len(forecast))
+	for _, month := range forecast {
+		totals = append(totals, month.Total)
+	}
+	require.Equal(t, monthOf(2025, time.March), forecast[0].Month)
+	require.Equal(t, []int{100, 150 + 300, 150 + 300 + 1200, 300}, totals)
+	require.Equal(t, []domain.ServiceCost{
+		{Name: "Cloud", Cost: 1200},
+		{Name: "Music", Cost: 150},
+		{Name: "Video", Cost: 300},
+	}, forecast[2].Services)
+
+	for _, months := range []int{0, 241} {
+		_, err := service.ForecastSubscriptionsCost(t.Context(), userID, "", time.Now(), months)
+		require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err), months)
+	}
+}
@@ -0,0 +1,388 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// DefaultCurrency is what subscriptions without a currency are billed in.
+const DefaultCurrency Currency = "RUB"
+
+// minorDigits lists the currencies whose minor unit is not a hundredth of
+// the major one.
+var minorDigits = map[Currency]int{
+	"BHD": 3,
+	"CLP": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+var (
+	ErrServiceImportExchangeRates = errors.Join(
+		errServiceSubscription,
+		errors.New("import exchange rates failed"),
+	)
+	ErrServiceConvertedTotalCost = errors.Join(
+		errServiceSubscription,
+		errors.New("converted total cost failed"),
+	)
+)
+
+// IsValid reports whether c is shaped like an ISO 4217 code, three upper
+// case letters.
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OrDefault resolves the zero value to DefaultCurrency.
+func (c Currency) OrDefault() Currency {
+	if c == "" {
+		return DefaultCurrency
+	}
+
+	return c
+}
+
+// MinorDigits is the number of decimal places of the minor unit.
+func (c Currency) MinorDigits() int {
+	if digits, ok := minorDigits[c]; ok {
+		return digits
+	}
+
+	return 2
+}
+
+// Convert turns an amount in minor units of from into minor units of to at
+// rate, rounded to the nearest unit.
+func Convert(amount int, from, to Currency, rate float64) int {
+	scale := math.Pow10(to.MinorDigits() - from.MinorDigits())
+
+	return int(math.Round(float64(amount) * rate * scale))
+}
+
+// ImportExchangeRates stores the rates, replacing the ones already stored
+// for the same pair and month. Either all rates are stored or none.
+func (s *SubscriptionService) ImportExchangeRates(ctx context.Context, rates []ExchangeRate) error {
+	slog.DebugContext(
+		ctx,
+		"Service: importing exchange rates.",
+		log.RequestID(ctx),
+		"rates",
+		len(rates),
+	)
+	for i := range rates {
+		rates[i].EffectiveFrom = MonthStart(rates[i].EffectiveFrom)
+	}
+	if err := validateExchangeRates(rates); err != nil {
+		return errors.Join(ErrServiceImportExchangeRates, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		return s.ratesRepo.Save(ctx, c, rates)
+	})
+	if err != nil {
+		return errors.Join(ErrServiceImportExchangeRates, err)
+	}
+	return nil
+}
+
+// ConvertedTotalCost is the total cost in currency. The charges of every
+// month are converted at the latest rate of their currency that took effect
+// in that month or before, a rate stored for either direction of the pair
+// will do.
+func (s *SubscriptionService) ConvertedTotalCost(
+	ctx context.Context,
+	subscriptionUserID UserID,
+	subscriptionName ServiceName,
+	start time.Time,
+	end *time.Time,
+	currency Currency,
+) (ConvertedCost, error) {
+	slog.DebugContext(ctx, "Service: calculating converted total cost.", log.RequestID(ctx))
+	if err := validateTargetCurrency(currency); err != nil {
+		return ConvertedCost{}, errors.Join(ErrServiceConvertedTotalCost, err)
+	}
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	var charges []MonthlyCharges
+	var rates []ExchangeRate
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		subscriptionName, dbErr := s.canonicalName(ctx, c, subscriptionName)
+		if dbErr != nil {
+			return dbErr
+		}
+		charges, dbErr = s.subscriptionRepo.CalculateMonthlyCharges(
+			ctx,
+			c,
+			subscriptionUserID,
+			subscriptionName,
+			start,
+			end,
+		)
+		if dbErr != nil {
+			return dbErr
+		}
+
+		rates, dbErr = s.ratesRepo.List(ctx, c, currency, MonthStart(*end))
+		return dbErr
+	})
+	if err != nil {
+		return ConvertedCost{}, errors.Join(ErrServiceConvertedTotalCost, err)
+	}
+
+	converted, err := convertCharges(charges, rates, currency)
+	if err != nil {
+		return ConvertedCost{}, errors.Join(ErrServiceConvertedTotalCost, err)
+	}
+	return converted, nil
+}
+
+// convertCharges converts every month of charges to currency, rates must be
+// in month order. All missing rates are reported at once.
+func convertCharges(
+	charges []MonthlyCharges,
+	rates []ExchangeRate,
+	currency Currency,
+) (ConvertedCost, error) {
+	type itemKey struct {
+		name          ServiceName
+		billingPeriod BillingPeriod
+		currency      Currency
+		price         int
+	}
+	converted := ConvertedCost{Currency: currency}
+	items := make(map[itemKey]*ServiceTotalCost)
+	applied := make(map[rateKey]AppliedRate)
+	missing := make(map[rateKey]bool)
+	for _, row := range charges {
+		month := MonthStart(row.Month)
+		cost := row.Price * row.Charges
+		if row.Currency != currency {
+			key := rateKey{month: month, from: row.Currency}
+			rate, ok := applied[key]
+			if !ok {
+				rate, ok = rateAt(rates, row.Currency, currency, month)
+				if !ok {
+					missing[key] = true
+					continue
+				}
+				applied[key] = rate
+			}
+			cost = Convert(cost, row.Currency, currency, rate.Rate)
+		}
+
+		key := itemKey{
+			name:          row.Name,
+			billingPeriod: row.BillingPeriod,
+			currency:      row.Currency,
+			price:         row.Price,
+		}
+		item, ok := items[key]
+		if !ok {
+			item = &ServiceTotalCost{
+				Name:          row.Name,
+				BillingPeriod: row.BillingPeriod,
+				Currency:      row.Currency,
+				Price:         row.Price,
+			}
+			items[key] = item
+		}
+		item.Charges += row.Charges
+		item.Cost += cost
+		converted.Total += cost
+	}
+
+	if len(missing) > 0 {
+		return ConvertedCost{}, missingRatesError(missing, currency)
+	}
+
+	converted.Items = make([]ServiceTotalCost, 0, len(items))
+	for _, item := range items {
+		converted.Items = append(converted.Items, *item)
+	}
+	slices.SortFunc(converted.Items, compareServiceTotalCosts)
+
+	converted.Rates = make([]AppliedRate, 0, len(applied))
+	for _, rate := range applied {
+		converted.Rates = append(converted.Rates, rate)
+	}
+	slices.SortFunc(converted.Rates, func(a, b AppliedRate) int {
+		return cmp.Or(a.Month.Compare(b.Month), cmp.Compare(a.From, b.From))
+	})
+
+	return converted, nil
+}
+
+// validateTargetCurrency checks the currency costs are converted to.
+func validateTargetCurrency(currency Currency) error {
+	if !currency.IsValid() {
+		return NewValidationError("invalid_currency", "currency is invalid").WithFields(FieldError{
+			Field:   "currency",
+			Message: "currency must be an ISO 4217 code such as RUB",
+		})
+	}
+
+	return nil
+}
+
+// rateKey is the conversion of one currency in one month.
+type rateKey struct {
+	month time.Time
+	from  Currency
+}
+
+// convertMonthlyCosts converts the costs of every month to currency the way
+// convertCharges does and adds up the spend on a service in a month. The
+// result is in month order and by name within a month.
+func convertMonthlyCosts(
+	costs []MonthlyServiceCost,
+	rates []ExchangeRate,
+	currency Currency,
+) ([]MonthlyServiceCost, error) {
+	type costKey struct {
+		month time.Time
+		name  ServiceName
+	}
+
+	spend := make(map[costKey]int)
+	missing := make(map[rateKey]bool)
+	for _, cost := range costs {
+		month := MonthStart(cost.Month)
+		amount := cost.Cost
+		if cost.Currency != currency {
+			rate, ok := rateAt(rates, cost.Currency, currency, month)
+			if !ok {
+				missing[rateKey{month: month, from: cost.Currency}] = true
+				continue
+			}
+			amount = Convert(amount, cost.Currency, currency, rate.Rate)
+		}
+		spend[costKey{month: month, name: cost.Name}] += amount
+	}
+	if len(missing) > 0 {
+		return nil, missingRatesError(missing, currency)
+	}
+
+	converted := make([]MonthlyServiceCost, 0, len(spend))
+	for key, amount := range spend {
+		converted = append(converted, MonthlyServiceCost{
+			Month:    key.month,
+			Name:     key.name,
+			Currency: currency,
+			Cost:     amount,
+		})
+	}
+	slices.SortFunc(converted, func(a, b MonthlyServiceCost) int {
+		return cmp.Or(a.Month.Compare(b.Month), cmp.Compare(a.Name, b.Name))
+	})
+
+	return converted, nil
+}
+
+// missingRatesError reports every rate a conversion to currency lacked.
+func missingRatesError(missing map[rateKey]bool, currency Currency) error {
+	fields := make([]FieldError, 0, len(missing))
+	for key := range missing {
+		fields = append(fields, FieldError{
+			Field: "currency",
+			Message: fmt.Sprintf(
+				"no %s/%s rate for %s or earlier",
+				key.from,
+				currency,
+				key.month.Format("01-2006"),
+			),
+		})
+	}
+	slices.SortFunc(fields, func(a, b FieldError) int {
+		return cmp.Compare(a.Message, b.Message)
+	})
+
+	return ErrExchangeRateMissing.WithFields(fields...)
+}
+
+// rateAt finds the rate from one currency to another in effect in month, the
+// latest one that took effect in that month or before. A rate stored the
+// other way round is inverted, a direct rate wins a tie.
+func rateAt(rates []ExchangeRate, from, to Currency, month time.Time) (AppliedRate, bool) {
+	applied := AppliedRate{Month: month, From: from, To: to}
+	var found bool
+	for _, rate := range rates {
+		if rate.EffectiveFrom.After(month) {
+			break
+		}
+
+		switch {
+		case rate.Base == from && rate.Quote == to:
+			applied.Rate = rate.Rate
+		case rate.Base == to && rate.Quote == from &&
+			(!found || rate.EffectiveFrom.After(applied.EffectiveFrom)):
+			applied.Rate = 1 / rate.Rate
+		default:
+			continue
+		}
+		applied.EffectiveFrom = rate.EffectiveFrom
+		found = true
+	}
+
+	return applied, found
+}
+
+func validateExchangeRates(rates []ExchangeRate) error {
+	var fields []FieldError
+	for i, rate := range rates {
+		field := fmt.Sprintf("rates[%d]", i)
+		switch {
+		case !rate.Base.IsValid() || !rate.Quote.IsValid():
+			fields = append(fields, FieldError{
+				Field:   field,
+				Message: "currencies must be ISO 4217 codes such as RUB",
+			})
+		case rate.Base == rate.Quote:
+			fields = append(fields, FieldError{
+				Field:   field,
+				Message: "currencies must differ",
+			})
+		case !(rate.Rate > 0) || math.IsInf(rate.Rate, 0):
+			fields = append(fields, FieldError{
+				Field:   field,
+				Message: "rate must be a positive number",
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_exchange_rates", "exchange rates are invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
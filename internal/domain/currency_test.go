@@ -0,0 +1,152 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 89910, domain.Convert(999, "USD", "RUB", 90))
+	require.Equal(t, 556, domain.Convert(50000, "RUB", "USD", 1.0/90))
+	// Minor units follow the currency: a cent is not a yen, a fils is a
+	// thousandth of a dinar.
+	require.Equal(t, 1500, domain.Convert(1000, "USD", "JPY", 150))
+	require.Equal(t, 1000, domain.Convert(1500, "JPY", "USD", 1.0/150))
+	require.Equal(t, 300, domain.Convert(100, "USD", "KWD", 0.3))
+}
+
+func TestConvertedTotalCost(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      999,
+		Currency:  "USD",
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+	}))
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Video",
+		Cost:      50000,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.February),
+	}))
+	require.NoError(t, service.ImportExchangeRates(t.Context(), []domain.ExchangeRate{
+		{Base: "USD", Quote: "RUB", EffectiveFrom: monthOf(2025, time.January), Rate: 90},
+		{Base: "USD", Quote: "RUB", EffectiveFrom: monthOf(2025, time.March), Rate: 80},
+	}))
+
+	converted, err := service.ConvertedTotalCost(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.March)),
+		"RUB",
+	)
+	require.NoError(t, err)
+	require.Equal(t, 89910+89910+79920+50000+50000, converted.Total)
+	require.Equal(t, []domain.ServiceTotalCost{
+		{
+			Name:          "Music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      "USD",
+			Charges:       3,
+			Price:         999,
+			Cost:          89910 + 89910 + 79920,
+		},
+		{
+			Name:          "Video",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      "RUB",
+			Charges:       2,
+			Price:         50000,
+			Cost:          100000,
+		},
+	}, converted.Items)
+	require.Equal(t, []domain.AppliedRate{
+		{
+			Month:         monthOf(2025, time.January),
+			From:          "USD",
+			To:            "RUB",
+			Rate:          90,
+			EffectiveFrom: monthOf(2025, time.January),
+		},
+		{
+			Month:         monthOf(2025, time.February),
+			From:          "USD",
+			To:            "RUB",
+			Rate:          90,
+			EffectiveFrom: monthOf(2025, time.January),
+		},
+		{
+			Month:         monthOf(2025, time.March),
+			From:          "USD",
+			To:            "RUB",
+			Rate:          80,
+			EffectiveFrom: monthOf(2025, time.March),
+		},
+	}, converted.Rates)
+
+	// A rate stored the other way round is inverted.
+	converted, err = service.ConvertedTotalCost(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.February),
+		pointer.Ref(monthOf(2025, time.February)),
+		"USD",
+	)
+	require.NoError(t, err)
+	require.Equal(t, 999+556, converted.Total)
+	require.Len(t, converted.Rates, 1)
+	require.Equal(t, domain.Currency("RUB"), converted.Rates[0].From)
+	require.InDelta(t, 1.0/90, converted.Rates[0].Rate, 1e-12)
+
+	_, err = service.ConvertedTotalCost(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.March)),
+		"EUR",
+	)
+	require.ErrorIs(t, err, domain.ErrExchangeRateMissing)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	_, err = service.ConvertedTotalCost(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		nil,
+		"usd",
+	)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
+
+func TestImportExchangeRatesValidates(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	for _, rate := range []domain.ExchangeRate{
+		{Base: "USD", Quote: "USD", Rate: 1},
+		{Base: "usd", Quote: "RUB", Rate: 90},
+		{Base: "USD", Quote: "RUB", Rate: 0},
+	} {
+		err := service.ImportExchangeRates(t.Context(), []domain.ExchangeRate{rate})
+		require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err), rate)
+	}
+}
@@ -5,11 +5,80 @@ import (
 	"time"
 )
 
+// SubscriptionEventsRepository stores the append-only change history.
+type SubscriptionEventsRepository interface {
+	Append(context.Context, Connection, SubscriptionEvent) error
+	List(context.Context, Connection, EventQuery) ([]SubscriptionEvent, error)
+}
+
+// ExchangeRatesRepository stores the exchange rates costs are converted at.
+type ExchangeRatesRepository interface {
+	// Save stores the rates, replacing ones of the same pair and month.
+	Save(context.Context, Connection, []ExchangeRate) error
+	// List returns the rates quoting the currency either way that take
+	// effect in the given month or earlier, ordered by month.
+	List(context.Context, Connection, Currency, time.Time) ([]ExchangeRate, error)
+}
+
+// ServicesRepository stores the service catalog. Names and aliases are
+// matched by ServiceKey.
+type ServicesRepository interface {
+	Create(context.Context, Connection, Service) error
+	Update(context.Context, Connection, Service) error
+	Delete(context.Context, Connection, ServiceID) error
+	Read(context.Context, Connection, ServiceID) (Service, error)
+	List(context.Context, Connection, ServiceFilter) ([]Service, error)
+	// Resolve returns the service whose name or alias has the given key.
+	Resolve(context.Context, Connection, string) (Service, error)
+	// Link stores the subscriptions already linked to the service or named
+	// after one of its keys under its name and returns the ones it changed.
+	// Live subscriptions that would then overlap another live subscription
+	// of the same user are left as they are.
+	Link(context.Context, Connection, Service) ([]SubscriptionChange, error)
+}
+
+// TagsRepository stores the tags of the users. Names are unique per user by
+// TagKey, subscriptions are tagged when they are saved.
+type TagsRepository interface {
+	Create(context.Context, Connection, Tag) error
+	Read(context.Context, Connection, TagID) (Tag, error)
+	// List returns the tags of the user sorted by name.
+	List(context.Context, Connection, UserID) ([]Tag, error)
+	// Rename changes the name of the tag on every subscription too.
+	Rename(context.Context, Connection, TagID, string) error
+	// Delete removes the tag from its subscriptions as well.
+	Delete(context.Context, Connection, TagID) error
+	// Merge tags the subscriptions of the first tag with the second one and
+	// deletes the first.
+	Merge(context.Context, Connection, TagID, TagID) error
+}
+
+// BudgetsRepository stores the budgets of the users.
+type BudgetsRepository interface {
+	Create(context.Context, Connection, Budget) error
+	Update(context.Context, Connection, Budget) error
+	Delete(context.Context, Connection, BudgetID) error
+	Read(context.Context, Connection, BudgetID) (Budget, error)
+	// List returns the budgets of the user sorted by category, service name,
+	// period and ID, so the ones over all subscriptions come first.
+	List(context.Context, Connection, UserID) ([]Budget, error)
+	// RenameService moves the budgets of every user scoped to a service
+	// whose ServiceKey is one of the keys under the name and reports how
+	// many it moved.
+	RenameService(context.Context, Connection, []string, ServiceName) (int, error)
+}
+
 type SubscriptionsRepository interface {
 	Create(context.Context, Connection, Subscription) error
 	Update(context.Context, Connection, Subscription) error
+	// Delete soft deletes a live subscription.
 	Delete(context.Context, Connection, SubscriptionID) error
-	ReadAll(context.Context, Connection, UserID, int, int) ([]Subscription, error)
+	// Restore undeletes a deleted subscription and returns it.
+	Restore(context.Context, Connection, SubscriptionID) (Subscription, error)
+	// Purge removes subscriptions deleted before the given time for good.
+	Purge(context.Context, Connection, time.Time) (int, error)
+	ReadAll(context.Context, Connection, SubscriptionFilter, Pagination) ([]Subscription, error)
+	Count(context.Context, Connection, SubscriptionFilter) (int, error)
 	Read(context.Context, Connection, SubscriptionID) (Subscription, error)
 	CalculateTotalCost(
 		context.Context,
@@ -19,10 +88,115 @@ type SubscriptionsRepository interface {
 		time.Time,
 		*time.Time,
 	) (int, error)
-	GetLatestSubscriptionEndDate(
+	CalculateTotalCostByService(
+		context.Context,
+		Connection,
+		UserID,
+		ServiceName,
+		time.Time,
+		*time.Time,
+	) ([]ServiceTotalCost, error)
+	// CalculateTotalCostByGroup rolls the total up by category or by tag,
+	// groups without charges are left out.
+	CalculateTotalCostByGroup(
+		context.Context,
+		Connection,
+		UserID,
+		ServiceName,
+		time.Time,
+		*time.Time,
+		CostGroup,
+	) ([]GroupCost, error)
+	CalculateMonthlyCosts(
 		context.Context,
 		Connection,
 		UserID,
 		ServiceName,
-	) (*time.Time, error)
+		time.Time,
+		*time.Time,
+	) ([]MonthlyServiceCost, error)
+	// CalculateMonthlyCharges counts the charges month by month, grouped by
+	// service, billing period, currency and price.
+	CalculateMonthlyCharges(
+		context.Context,
+		Connection,
+		UserID,
+		ServiceName,
+		time.Time,
+		*time.Time,
+	) ([]MonthlyCharges, error)
+	// BilledCurrencies returns the currencies of the live subscriptions
+	// running in any month of the period, sorted.
+	BilledCurrencies(
+		context.Context,
+		Connection,
+		UserID,
+		ServiceName,
+		time.Time,
+		*time.Time,
+	) ([]Currency, error)
+	// CalculateCategoryCharges is CalculateMonthlyCharges narrowed to the
+	// subscriptions of one category instead of one service.
+	CalculateCategoryCharges(
+		context.Context,
+		Connection,
+		UserID,
+		string,
+		time.Time,
+		*time.Time,
+	) ([]MonthlyCharges, error)
+	// SchedulePrice stores a price change, replacing one already scheduled
+	// for the same month.
+	SchedulePrice(context.Context, Connection, PriceChange) error
+	// ReadPrices returns the price changes of a subscription in effective
+	// order.
+	ReadPrices(context.Context, Connection, SubscriptionID) ([]PriceChange, error)
+	// ReadOverlapping returns the other subscriptions of the same user to the
+	// same service whose days overlap the given one.
+	ReadOverlapping(context.Context, Connection, Subscription) ([]Subscription, error)
+}
+
+// AnalyticsRepository computes fleet-wide figures over the live subscriptions
+// of every user. Months are billed the same way as in SubscriptionsRepository,
+// so the figures add up to the per-user totals.
+type AnalyticsRepository interface {
+	// MonthlyRevenue is CalculateMonthlyCosts for every user at once, with
+	// the costs of every currency reported apart.
+	MonthlyRevenue(context.Context, Connection, time.Time, time.Time) ([]MonthlyServiceCost, error)
+	// MonthlyActivity reports every month of the period, including the ones
+	// without subscriptions.
+	MonthlyActivity(context.Context, Connection, time.Time, time.Time) ([]MonthlyActivity, error)
+}
+
+// MonthlyCostsRepository keeps the spend of every user on every service per
+// calendar month up to a horizon. The subscription and catalog write paths
+// recalculate the months of the subscriptions they touch in the same
+// transaction and cost reads that end within the horizon are served from the
+// stored months.
+type MonthlyCostsRepository interface {
+	// Claim takes the maintenance for the transaction, it reports false
+	// when another transaction holds it or the last maintenance finished
+	// after the time.
+	Claim(context.Context, Connection, time.Time) (bool, error)
+	// Mismatches compares the stored months against the live calculation up
+	// to the horizon, nothing is reported before the first maintenance.
+	Mismatches(context.Context, Connection) ([]MonthlyCostMismatch, error)
+	// Maintain recalculates the months of the mismatches, moves the horizon
+	// forward to the month of the first time, storing only the months it
+	// adds, and records the second time as when maintenance finished.
+	Maintain(context.Context, Connection, []MonthlyCostMismatch, time.Time, time.Time) error
+}
+
+// Repositories is the storage a SubscriptionService works on, every
+// repository runs on the connections of the same ConnectionProvider.
+// Repositories a caller never reaches can be left nil.
+type Repositories struct {
+	Subscriptions SubscriptionsRepository
+	Events        SubscriptionEventsRepository
+	ExchangeRates ExchangeRatesRepository
+	Services      ServicesRepository
+	Tags          TagsRepository
+	Budgets       BudgetsRepository
+	Analytics     AnalyticsRepository
+	MonthlyCosts  MonthlyCostsRepository
 }
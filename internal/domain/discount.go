@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (k DiscountKind) IsValid() bool {
+	switch k {
+	case DiscountTrial, DiscountPercent, DiscountFixed:
+		return true
+	}
+
+	return false
+}
+
+// Apply returns what is left of price after the discount. Percentages are
+// rounded to the nearest minor unit, halves in the customer's favor.
+func (d Discount) Apply(price int) int {
+	switch d.Kind {
+	case DiscountTrial:
+		return 0
+	case DiscountPercent:
+		return price - (price*d.Value+50)/100
+	case DiscountFixed:
+		return max(price-d.Value, 0)
+	}
+
+	return price
+}
+
+// Covers reports whether the discount applies in the month of t.
+func (d Discount) Covers(t time.Time) bool {
+	month := MonthStart(t)
+
+	return !month.Before(MonthStart(d.StartMonth)) && !month.After(MonthStart(d.EndMonth))
+}
+
+// DiscountAt returns the discount in effect in the month of t.
+func (s Subscription) DiscountAt(t time.Time) (Discount, bool) {
+	for _, discount := range s.Discounts {
+		if discount.Covers(t) {
+			return discount, true
+		}
+	}
+
+	return Discount{}, false
+}
+
+// AmountAt is what one charge in the month of t costs, the price in effect
+// less the discount in effect.
+func (s Subscription) AmountAt(t time.Time) int {
+	price := s.PriceAt(t)
+	if discount, ok := s.DiscountAt(t); ok {
+		return discount.Apply(price)
+	}
+
+	return price
+}
+
+// discountsUntil drops the discounts starting after the month of end and
+// cuts the ones running past it.
+func (s Subscription) discountsUntil(end time.Time) []Discount {
+	end = MonthStart(end)
+
+	var discounts []Discount
+	for _, discount := range s.Discounts {
+		if MonthStart(discount.StartMonth).After(end) {
+			continue
+		}
+		if MonthStart(discount.EndMonth).After(end) {
+			discount.EndMonth = end
+		}
+		discounts = append(discounts, discount)
+	}
+
+	return discounts
+}
+
+// normalizeDiscounts returns a copy of the discounts of the subscription
+// starting and ending on month boundaries in month order, nil when there are
+// none.
+func normalizeDiscounts(id SubscriptionID, discounts []Discount) []Discount {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	normalized := make([]Discount, len(discounts))
+	for i, discount := range discounts {
+		discount.SubscriptionID = id
+		discount.StartMonth = MonthStart(discount.StartMonth)
+		discount.EndMonth = MonthStart(discount.EndMonth)
+		normalized[i] = discount
+	}
+	slices.SortStableFunc(normalized, func(a, b Discount) int {
+		return a.StartMonth.Compare(b.StartMonth)
+	})
+
+	return normalized
+}
+
+// validateDiscounts checks normalized discounts against each other and the
+// months of the subscription.
+func validateDiscounts(subscription Subscription) []FieldError {
+	var fields []FieldError
+	period := subscription.Period()
+	for i, discount := range subscription.Discounts {
+		field := fmt.Sprintf("discounts[%d]", i)
+		reject := func(message string) {
+			fields = append(fields, FieldError{Field: field, Message: message})
+		}
+
+		switch discount.Kind {
+		case DiscountTrial:
+			if discount.Value != 0 {
+				reject("a trial has no value")
+			}
+		case DiscountPercent:
+			if discount.Value < 1 || discount.Value > 100 {
+				reject("percent must be between 1 and 100")
+			}
+		case DiscountFixed:
+			if discount.Value < 1 {
+				reject("amount must be positive")
+			}
+		default:
+			reject("kind must be trial, percent or fixed")
+		}
+
+		switch {
+		case discount.EndMonth.Before(discount.StartMonth):
+			reject("end month must not be before start month")
+		case !period.Contains(discount.StartMonth) || !period.Contains(discount.EndMonth):
+			reject("must fall within the subscription")
+		case i > 0 && !subscription.Discounts[i-1].EndMonth.Before(discount.StartMonth):
+			reject("overlaps the previous discount")
+		}
+	}
+
+	return fields
+}
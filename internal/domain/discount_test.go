@@ -0,0 +1,185 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscountApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		discount domain.Discount
+		price    int
+		want     int
+	}{
+		{domain.Discount{Kind: domain.DiscountTrial}, 999, 0},
+		{domain.Discount{Kind: domain.DiscountPercent, Value: 50}, 1000, 500},
+		{domain.Discount{Kind: domain.DiscountPercent, Value: 50}, 999, 499},
+		{domain.Discount{Kind: domain.DiscountPercent, Value: 100}, 999, 0},
+		{domain.Discount{Kind: domain.DiscountFixed, Value: 300}, 999, 699},
+		{domain.Discount{Kind: domain.DiscountFixed, Value: 1500}, 999, 0},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, tt.discount.Apply(tt.price), tt.discount)
+	}
+}
+
+func TestChargesWithDiscounts(t *testing.T) {
+	t.Parallel()
+
+	subscription := domain.Subscription{
+		Name:      "Music",
+		Cost:      1000,
+		StartDate: monthOf(2025, time.January),
+		Discounts: []domain.Discount{
+			{
+				Kind:       domain.DiscountTrial,
+				StartMonth: monthOf(2025, time.January),
+				EndMonth:   monthOf(2025, time.January),
+			},
+			{
+				Kind:       domain.DiscountPercent,
+				Value:      25,
+				StartMonth: monthOf(2025, time.February),
+				EndMonth:   monthOf(2025, time.March),
+			},
+			{
+				Kind:       domain.DiscountFixed,
+				Value:      100,
+				StartMonth: monthOf(2025, time.May),
+				EndMonth:   monthOf(2025, time.May),
+			},
+		},
+	}.WithBillingDefaults()
+
+	var amounts []int
+	for _, charge := range subscription.Charges(
+		domain.NewPeriod(monthOf(2025, time.January), pointer.Ref(monthOf(2025, time.June))),
+	) {
+		amounts = append(amounts, charge.Amount)
+	}
+	require.Equal(t, []int{0, 750, 750, 1000, 900, 1000}, amounts)
+}
+
+func TestValidateDiscounts(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	discount := func(kind domain.DiscountKind, value int, start, end time.Month) domain.Discount {
+		return domain.Discount{
+			Kind:       kind,
+			Value:      value,
+			StartMonth: monthOf(2025, start),
+			EndMonth:   monthOf(2025, end),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		discounts []domain.Discount
+	}{
+		{"unknown kind", []domain.Discount{discount("free", 0, time.March, time.March)}},
+		{
+			"trial with value",
+			[]domain.Discount{discount(domain.DiscountTrial, 10, time.March, time.March)},
+		},
+		{
+			"zero percent",
+			[]domain.Discount{discount(domain.DiscountPercent, 0, time.March, time.March)},
+		},
+		{
+			"over 100 percent",
+			[]domain.Discount{discount(domain.DiscountPercent, 101, time.March, time.March)},
+		},
+		{
+			"zero amount",
+			[]domain.Discount{discount(domain.DiscountFixed, 0, time.March, time.March)},
+		},
+		{
+			"ends before start",
+			[]domain.Discount{discount(domain.DiscountTrial, 0, time.May, time.April)},
+		},
+		{
+			"before subscription",
+			[]domain.Discount{discount(domain.DiscountTrial, 0, time.February, time.March)},
+		},
+		{
+			"after subscription",
+			[]domain.Discount{discount(domain.DiscountTrial, 0, time.June, time.July)},
+		},
+		{"overlapping", []domain.Discount{
+			discount(domain.DiscountTrial, 0, time.March, time.April),
+			discount(domain.DiscountPercent, 10, time.April, time.May),
+		}},
+	}
+
+	for _, tt := range tests {
+		err := service.Create(t.Context(), domain.Subscription{
+			ID:        uuid.New(),
+			Name:      "Music",
+			Cost:      100,
+			UserID:    uuid.New(),
+			StartDate: monthOf(2025, time.March),
+			EndDate:   pointer.Ref(monthOf(2025, time.June)),
+			Discounts: tt.discounts,
+		})
+		require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err), tt.name)
+	}
+
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: monthOf(2025, time.March),
+		EndDate:   pointer.Ref(monthOf(2025, time.June)),
+		Discounts: []domain.Discount{
+			discount(domain.DiscountPercent, 10, time.May, time.June),
+			discount(domain.DiscountTrial, 0, time.March, time.April),
+		},
+	}))
+}
+
+func TestCancelClipsDiscounts(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: monthOf(2025, time.January),
+		Discounts: []domain.Discount{
+			{
+				Kind:       domain.DiscountTrial,
+				StartMonth: monthOf(2025, time.January),
+				EndMonth:   monthOf(2025, time.March),
+			},
+			{
+				Kind:       domain.DiscountPercent,
+				Value:      10,
+				StartMonth: monthOf(2025, time.June),
+				EndMonth:   monthOf(2025, time.December),
+			},
+		},
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	canceled, err := service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.February))
+	require.NoError(t, err)
+	require.Equal(t, []domain.Discount{{
+		SubscriptionID: subscription.ID,
+		Kind:           domain.DiscountTrial,
+		StartMonth:     monthOf(2025, time.January),
+		EndMonth:       monthOf(2025, time.February),
+	}}, canceled.Discounts)
+}
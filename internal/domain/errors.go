@@ -0,0 +1,164 @@
+package domain
+
+import "errors"
+
+type ErrorKind string
+
+const (
+	ErrorKindInternal    ErrorKind = "internal"
+	ErrorKindNotFound    ErrorKind = "not_found"
+	ErrorKindConflict    ErrorKind = "conflict"
+	ErrorKindValidation  ErrorKind = "validation"
+	ErrorKindUnavailable ErrorKind = "unavailable"
+)
+
+// Error is a classified failure. It is meant to be joined into the usual
+// errors.Join chains so adapters can find it with errors.As.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+var (
+	ErrSubscriptionNotFound = NewError(
+		ErrorKindNotFound,
+		"subscription_not_found",
+		"subscription not found",
+	)
+	// ErrSubscriptionOverlap keeps the code of the rule it replaced, a
+	// subscription starting before the previous one ended, so clients
+	// matching on it still recognize the conflict.
+	ErrSubscriptionOverlap = NewError(
+		ErrorKindConflict,
+		"previous_subscription_not_ended",
+		"subscription overlaps another subscription to the same service",
+	)
+	// Deprecated: use ErrSubscriptionOverlap.
+	ErrPreviousSubscriptionNotEnded = ErrSubscriptionOverlap
+
+	ErrSubscriptionEnded = NewError(
+		ErrorKindConflict,
+		"subscription_ended",
+		"subscription has already ended, renew it instead",
+	)
+	ErrSubscriptionOpenEnded = NewError(
+		ErrorKindConflict,
+		"subscription_open_ended",
+		"subscription has no end date",
+	)
+	ErrServiceNotFound = NewError(
+		ErrorKindNotFound,
+		"service_not_found",
+		"service not found in the catalog",
+	)
+	ErrServiceNameTaken = NewError(
+		ErrorKindConflict,
+		"service_name_taken",
+		"name or alias already belongs to another service",
+	)
+	ErrServiceInUse = NewError(
+		ErrorKindConflict,
+		"service_in_use",
+		"service is referenced by subscriptions",
+	)
+	ErrTagNotFound = NewError(
+		ErrorKindNotFound,
+		"tag_not_found",
+		"tag not found",
+	)
+	ErrTagNameTaken = NewError(
+		ErrorKindConflict,
+		"tag_name_taken",
+		"user already has a tag with this name, merge the tags instead",
+	)
+	ErrBudgetNotFound = NewError(
+		ErrorKindNotFound,
+		"budget_not_found",
+		"budget not found",
+	)
+	ErrExchangeRateMissing = NewError(
+		ErrorKindValidation,
+		"exchange_rate_missing",
+		"no exchange rate to convert the costs at",
+	)
+	ErrMixedCurrencies = NewError(
+		ErrorKindValidation,
+		"mixed_currencies",
+		"costs are billed in more than one currency and cannot be added up unconverted",
+	)
+	ErrAlreadyExists = NewError(
+		ErrorKindConflict,
+		"already_exists",
+		"resource already exists",
+	)
+	ErrConcurrentUpdate = NewError(
+		ErrorKindConflict,
+		"concurrent_update",
+		"resource was modified concurrently, retry the request",
+	)
+	ErrUnavailable = NewError(
+		ErrorKindUnavailable,
+		"storage_unavailable",
+		"storage is temporarily unavailable",
+	)
+)
+
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func NewValidationError(code, message string) *Error {
+	return NewError(ErrorKindValidation, code, message)
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors with the same code, so copies made by WithFields still
+// match their sentinel.
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+
+	return ok && other.Code == e.Code
+}
+
+// WithFields returns a copy of the error carrying per-field details.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	withFields := *e
+	withFields.Fields = fields
+
+	return &withFields
+}
+
+// AsError returns the first classified error in the chain.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	return nil, false
+}
+
+// KindOf reports the kind of the first classified error in the chain,
+// unclassified errors are internal.
+func KindOf(err error) ErrorKind {
+	if domainErr, ok := AsError(err); ok {
+		return domainErr.Kind
+	}
+
+	return ErrorKindInternal
+}
@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, domain.ErrorKindInternal, domain.KindOf(errors.New("plain")))
+	require.Equal(t, domain.ErrorKindInternal, domain.KindOf(nil))
+
+	wrapped := errors.Join(
+		domain.ErrServiceCreateSubscription,
+		errors.Join(errors.New("repository error"), domain.ErrSubscriptionOverlap),
+	)
+	require.Equal(t, domain.ErrorKindConflict, domain.KindOf(wrapped))
+	require.ErrorIs(t, wrapped, domain.ErrSubscriptionOverlap)
+	require.ErrorIs(t, wrapped, domain.ErrPreviousSubscriptionNotEnded)
+
+	domainErr, ok := domain.AsError(wrapped)
+	require.True(t, ok)
+	require.Equal(t, "previous_subscription_not_ended", domainErr.Code)
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// anonymousActor is recorded when a request does not name its actor.
+const anonymousActor = "anonymous"
+
+var (
+	ErrServiceHistory = errors.Join(
+		errServiceSubscription,
+		errors.New("history failed"),
+	)
+	ErrServiceRecordEvent = errors.Join(
+		errServiceSubscription,
+		errors.New("record event failed"),
+	)
+)
+
+// History pages through the changes of one subscription or one user, newest
+// first.
+func (s *SubscriptionService) History(ctx context.Context, query EventQuery) (EventPage, error) {
+	slog.DebugContext(ctx, "Service: reading history.", log.RequestID(ctx))
+	if err := validateEventQuery(query); err != nil {
+		return EventPage{}, errors.Join(ErrServiceHistory, err)
+	}
+
+	var page EventPage
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		// One extra event tells whether there is a next page.
+		lookahead := query
+		lookahead.Limit++
+
+		events, err := s.eventsRepo.List(ctx, c, lookahead)
+		if err != nil {
+			return err
+		}
+
+		page.HasMore = len(events) > query.Limit
+		if page.HasMore {
+			events = events[:query.Limit]
+			page.Next = &events[len(events)-1].ID
+		}
+		page.Events = events
+
+		return nil
+	})
+	if err != nil {
+		return EventPage{}, errors.Join(ErrServiceHistory, err)
+	}
+	return page, nil
+}
+
+// record appends an event on the connection of the change it describes, so
+// both commit or roll back together.
+func (s *SubscriptionService) record(
+	ctx context.Context,
+	c Connection,
+	eventType SubscriptionEventType,
+	before, after *Subscription,
+) error {
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+
+	actor := log.ActorValue(ctx)
+	if actor == "" {
+		actor = anonymousActor
+	}
+
+	err := s.eventsRepo.Append(ctx, c, SubscriptionEvent{
+		SubscriptionID: subject.ID,
+		UserID:         subject.UserID,
+		Type:           eventType,
+		Before:         before,
+		After:          after,
+		Actor:          actor,
+		RequestID:      log.RequestIDValue(ctx),
+	})
+	if err != nil {
+		return errors.Join(ErrServiceRecordEvent, err)
+	}
+
+	return nil
+}
+
+func validateEventQuery(query EventQuery) error {
+	var fields []FieldError
+	if (query.SubscriptionID == nil) == (query.UserID == nil) {
+		fields = append(fields, FieldError{
+			Field:   "id",
+			Message: "history is read for either a subscription or a user",
+		})
+	}
+	if query.Limit <= 0 {
+		fields = append(fields, FieldError{
+			Field:   "limit",
+			Message: "limit must be positive",
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_history_query", "history parameters are invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+var (
+	ErrServiceCancelSubscription = errors.Join(
+		errServiceSubscription,
+		errors.New("cancel failed"),
+	)
+	ErrServiceRenewSubscription = errors.Join(
+		errServiceSubscription,
+		errors.New("renew failed"),
+	)
+)
+
+// Cancel ends the subscription with the month of effective, which is still
+// billed. A subscription can be canceled earlier than it was going to end,
+// but not later, that is a renewal. A subscription whose end month has passed
+// is reported as ended instead. Canceling again with the same month changes
+// nothing. Discounts past the new end are cut off.
+func (s *SubscriptionService) Cancel(
+	ctx context.Context,
+	subscriptionID SubscriptionID,
+	effective time.Time,
+) (Subscription, error) {
+	slog.DebugContext(ctx, "Service: canceling subscription.", log.RequestID(ctx))
+	end := MonthStart(effective)
+
+	subscription, err := s.change(ctx, subscriptionID, SubscriptionEventCanceled,
+		func(subscription *Subscription) (bool, error) {
+			if end.Before(MonthStart(subscription.StartDate)) {
+				return false, NewValidationError(
+					"invalid_cancellation",
+					"subscription cannot end before it starts",
+				).WithFields(FieldError{
+					Field: "effective",
+					Message: "must not be before start date " + subscription.StartDate.Format(
+						"01-2006",
+					),
+				})
+			}
+			if subscription.EndDate != nil {
+				current := MonthStart(*subscription.EndDate)
+				if current.Equal(end) {
+					return false, nil
+				}
+				if current.Before(end) && current.Before(MonthStart(time.Now())) {
+					return false, ErrSubscriptionEnded.WithFields(FieldError{
+						Field:   "effective",
+						Message: "subscription ended in " + current.Format("01-2006"),
+					})
+				}
+				if current.Before(end) {
+					return false, NewValidationError(
+						"invalid_cancellation",
+						"canceling cannot extend the subscription, renew it instead",
+					).WithFields(FieldError{
+						Field:   "effective",
+						Message: "must not be after end date " + current.Format("01-2006"),
+					})
+				}
+			}
+
+			// A subscription starting within the month ends on its start day.
+			if end.Before(subscription.StartDate) {
+				end = subscription.StartDate
+			}
+			subscription.EndDate = &end
+			subscription.Discounts = subscription.discountsUntil(end)
+			return true, nil
+		})
+	if err != nil {
+		return Subscription{}, errors.Join(ErrServiceCancelSubscription, err)
+	}
+	return subscription, nil
+}
+
+// Renew extends a subscription with an end date by the given number of
+// months past that date, or makes it open-ended when months is nil.
+func (s *SubscriptionService) Renew(
+	ctx context.Context,
+	subscriptionID SubscriptionID,
+	months *int,
+) (Subscription, error) {
+	slog.DebugContext(ctx, "Service: renewing subscription.", log.RequestID(ctx))
+	if months != nil && *months <= 0 {
+		return Subscription{}, errors.Join(
+			ErrServiceRenewSubscription,
+			NewValidationError("invalid_renewal", "renewal term is invalid").
+				WithFields(FieldError{Field: "months", Message: "must be positive"}),
+		)
+	}
+
+	subscription, err := s.change(ctx, subscriptionID, SubscriptionEventRenewed,
+		func(subscription *Subscription) (bool, error) {
+			if subscription.EndDate == nil {
+				return false, ErrSubscriptionOpenEnded
+			}
+
+			if months == nil {
+				subscription.EndDate = nil
+			} else {
+				end := MonthStart(*subscription.EndDate).AddDate(0, *months, 0)
+				subscription.EndDate = &end
+			}
+			return true, nil
+		})
+	if err != nil {
+		return Subscription{}, errors.Join(ErrServiceRenewSubscription, err)
+	}
+	return subscription, nil
+}
+
+// change applies apply to a live subscription under the same validation and
+// overlap rules as Create and Update and records the change. When apply
+// reports nothing changed the subscription is returned as is.
+func (s *SubscriptionService) change(
+	ctx context.Context,
+	subscriptionID SubscriptionID,
+	eventType SubscriptionEventType,
+	apply func(*Subscription) (bool, error),
+) (Subscription, error) {
+	var after Subscription
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		before, err := s.subscriptionRepo.Read(ctx, c, subscriptionID)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt != nil {
+			return ErrSubscriptionNotFound
+		}
+
+		changed := before
+		ok, err := apply(&changed)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			after = before
+			return nil
+		}
+
+		if err := validateSubscription(changed); err != nil {
+			return err
+		}
+		if err := s.ensureNoOverlap(ctx, c, changed); err != nil {
+			return err
+		}
+		if err := s.subscriptionRepo.Update(ctx, c, changed); err != nil {
+			return err
+		}
+
+		after, err = s.subscriptionRepo.Read(ctx, c, subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, c, eventType, &before, &after)
+	})
+
+	return after, err
+}
@@ -0,0 +1,189 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/memory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryService() *domain.SubscriptionService {
+	return domain.NewSubscriptionService(memory.NewProvider(), memory.NewRepositories())
+}
+
+func monthOf(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCancel(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: monthOf(2025, time.March),
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	_, err := service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.February))
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	canceled, err := service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.September))
+	require.NoError(t, err)
+	require.Equal(t, monthOf(2025, time.September), *canceled.EndDate)
+
+	// Canceling earlier is allowed, canceling again with the same month is a
+	// no-op and extending is a renewal.
+	canceled, err = service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.June))
+	require.NoError(t, err)
+	require.Equal(t, monthOf(2025, time.June), *canceled.EndDate)
+
+	_, err = service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.June))
+	require.NoError(t, err)
+
+	_, err = service.Cancel(t.Context(), subscription.ID, monthOf(2025, time.July))
+	require.ErrorIs(t, err, domain.ErrSubscriptionEnded)
+
+	_, err = service.Cancel(t.Context(), uuid.New(), monthOf(2025, time.July))
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+	page, err := service.History(t.Context(), domain.EventQuery{
+		SubscriptionID: &subscription.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 3)
+	require.Equal(t, domain.SubscriptionEventCanceled, page.Events[0].Type)
+	require.Equal(t, monthOf(2025, time.September), *page.Events[0].Before.EndDate)
+	require.Equal(t, monthOf(2025, time.June), *page.Events[0].After.EndDate)
+}
+
+func TestCancelCannotExtend(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	thisMonth := domain.MonthStart(time.Now())
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: thisMonth.AddDate(-1, 0, 0),
+		EndDate:   pointer.Ref(thisMonth.AddDate(0, 2, 0)),
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	// The subscription has not ended yet, canceling after its end would
+	// extend it.
+	_, err := service.Cancel(t.Context(), subscription.ID, thisMonth.AddDate(0, 5, 0))
+	require.NotErrorIs(t, err, domain.ErrSubscriptionEnded)
+	invalid, ok := domain.AsError(err)
+	require.True(t, ok)
+	require.Equal(t, "invalid_cancellation", invalid.Code)
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	stored, err := service.ReadByID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, thisMonth.AddDate(0, 2, 0), *stored.EndDate)
+}
+
+func TestRenew(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+		EndDate:   pointer.Ref(monthOf(2025, time.March)),
+	}
+	next := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      150,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.August),
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+	require.NoError(t, service.Create(t.Context(), next))
+
+	_, err := service.Renew(t.Context(), subscription.ID, pointer.Ref(0))
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	renewed, err := service.Renew(t.Context(), subscription.ID, pointer.Ref(3))
+	require.NoError(t, err)
+	require.Equal(t, monthOf(2025, time.June), *renewed.EndDate)
+
+	// Renewing into the next subscription breaks the overlap rule.
+	_, err = service.Renew(t.Context(), subscription.ID, pointer.Ref(2))
+	require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+	_, err = service.Renew(t.Context(), subscription.ID, nil)
+	require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+
+	stored, err := service.ReadByID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, monthOf(2025, time.June), *stored.EndDate)
+
+	_, err = service.Renew(t.Context(), next.ID, pointer.Ref(1))
+	require.ErrorIs(t, err, domain.ErrSubscriptionOpenEnded)
+
+	_, err = service.Cancel(t.Context(), next.ID, monthOf(2025, time.December))
+	require.NoError(t, err)
+	renewed, err = service.Renew(t.Context(), next.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, renewed.EndDate)
+}
+
+func TestLifecycleKeepsSubscriptionsValid(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	midMonth := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, service.Create(t.Context(), midMonth))
+
+	// Canceling in the start month does not end the subscription before it
+	// starts.
+	canceled, err := service.Cancel(t.Context(), midMonth.ID, monthOf(2025, time.March))
+	require.NoError(t, err)
+	require.Equal(t, midMonth.StartDate, *canceled.EndDate)
+
+	require.NoError(t, service.Delete(t.Context(), midMonth.ID))
+	_, err = service.Renew(t.Context(), midMonth.ID, pointer.Ref(1))
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	_, err = service.Cancel(t.Context(), midMonth.ID, monthOf(2025, time.March))
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+}
+
+func TestLifecycleValidatesBeforeWriting(t *testing.T) {
+	t.Parallel()
+
+	// The stored subscription has no service name, so it cannot be written
+	// back.
+	var written int
+	service := domain.NewSubscriptionService(
+		database.NewDummyProvider(nil),
+		domain.Repositories{Subscriptions: overlapRepository{written: &written}},
+	)
+
+	_, err := service.Cancel(t.Context(), uuid.New(), monthOf(2025, time.March))
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+	require.Zero(t, written)
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// materializedMonths is how many months past the current one
+// MaintainMonthlyCosts stores, costs further ahead are calculated live.
+const materializedMonths = 60
+
+var (
+	ErrServiceMaintainMonthlyCosts = errors.Join(
+		errServiceSubscription,
+		errors.New("maintain monthly costs failed"),
+	)
+	ErrServiceCheckMonthlyCosts = errors.Join(
+		errServiceSubscription,
+		errors.New("check monthly costs failed"),
+	)
+)
+
+// MaintainMonthlyCosts repairs the stored monthly costs that differ from the
+// live calculation and moves the horizon along with now. One instance at a
+// time maintains them, ran is false when another one is at it or finished
+// after since.
+func (s *SubscriptionService) MaintainMonthlyCosts(
+	ctx context.Context,
+	now time.Time,
+	since time.Time,
+) ([]MonthlyCostMismatch, bool, error) {
+	slog.DebugContext(ctx, "Service: maintaining monthly costs.", log.RequestID(ctx))
+	horizon := MonthStart(now).AddDate(0, materializedMonths, 0)
+	var (
+		mismatches []MonthlyCostMismatch
+		ran        bool
+	)
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		var dbErr error
+		ran, dbErr = s.monthlyCostsRepo.Claim(ctx, c, since)
+		if dbErr != nil || !ran {
+			return dbErr
+		}
+		mismatches, dbErr = s.monthlyCostsRepo.Mismatches(ctx, c)
+		if dbErr != nil {
+			return dbErr
+		}
+		return s.monthlyCostsRepo.Maintain(ctx, c, mismatches, horizon, now)
+	})
+	if err != nil {
+		return nil, false, errors.Join(ErrServiceMaintainMonthlyCosts, err)
+	}
+
+	return mismatches, ran, nil
+}
+
+func (s *SubscriptionService) CheckMonthlyCosts(
+	ctx context.Context,
+) ([]MonthlyCostMismatch, error) {
+	slog.DebugContext(ctx, "Service: checking monthly costs.", log.RequestID(ctx))
+	var mismatches []MonthlyCostMismatch
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var dbErr error
+		mismatches, dbErr = s.monthlyCostsRepo.Mismatches(ctx, c)
+		return dbErr
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceCheckMonthlyCosts, err)
+	}
+
+	return mismatches, nil
+}
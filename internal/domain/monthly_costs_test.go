@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyCostsStayConsistent(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	music := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.February),
+	}
+	require.NoError(t, service.Create(t.Context(), music))
+
+	// Nothing is stored before the first maintenance.
+	mismatches, err := service.CheckMonthlyCosts(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+
+	now := monthOf(2025, time.March)
+	mismatches, ran, err := service.MaintainMonthlyCosts(t.Context(), now, now)
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.Empty(t, mismatches)
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Video",
+		Cost:      300,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.April),
+	}))
+	require.NoError(t, service.Delete(t.Context(), music.ID))
+
+	mismatches, err = service.CheckMonthlyCosts(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+
+	start, end := monthOf(2025, time.January), monthOf(2025, time.June)
+	total, err := service.TotalSubscriptionsCost(t.Context(), userID, "", start, &end)
+	require.NoError(t, err)
+	require.Equal(t, 3*300, total)
+}
+
+func TestMaintainMonthlyCostsSkipsRecentMaintenance(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	now := monthOf(2025, time.March)
+	_, ran, err := service.MaintainMonthlyCosts(t.Context(), now, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.True(t, ran)
+
+	_, ran, err = service.MaintainMonthlyCosts(t.Context(), now, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.False(t, ran)
+
+	later := now.Add(24 * time.Hour)
+	_, ran, err = service.MaintainMonthlyCosts(t.Context(), later, later.Add(-12*time.Hour))
+	require.NoError(t, err)
+	require.True(t, ran)
+}
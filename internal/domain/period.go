@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// Period is an inclusive range of calendar months. Start and End hold the
+// first day of their month in UTC, a nil End means the period is open-ended.
+// A period whose End is before its Start is empty.
+type Period struct {
+	Start time.Time
+	End   *time.Time
+}
+
+func NewPeriod(start time.Time, end *time.Time) Period {
+	period := Period{Start: MonthStart(start)}
+	if end != nil {
+		last := MonthStart(*end)
+		period.End = &last
+	}
+
+	return period
+}
+
+func (p Period) IsBounded() bool {
+	return p.End != nil
+}
+
+func (p Period) IsEmpty() bool {
+	return p.End != nil && p.End.Before(p.Start)
+}
+
+// Contains reports whether the month of t lies within the period.
+func (p Period) Contains(t time.Time) bool {
+	month := MonthStart(t)
+
+	return !month.Before(p.Start) && (p.End == nil || !month.After(*p.End))
+}
+
+func (p Period) Overlaps(other Period) bool {
+	_, ok := p.Intersect(other)
+
+	return ok
+}
+
+// Intersect returns the months both periods share, ok is false when there
+// are none.
+func (p Period) Intersect(other Period) (Period, bool) {
+	intersection := Period{Start: p.Start, End: p.End}
+	if other.Start.After(intersection.Start) {
+		intersection.Start = other.Start
+	}
+	if other.End != nil && (intersection.End == nil || other.End.Before(*intersection.End)) {
+		intersection.End = other.End
+	}
+
+	if p.IsEmpty() || other.IsEmpty() || intersection.IsEmpty() {
+		return Period{}, false
+	}
+
+	return intersection, true
+}
+
+// MonthCount is the number of months in a bounded period, open-ended
+// periods report -1.
+func (p Period) MonthCount() int {
+	if p.End == nil {
+		return -1
+	}
+	if p.IsEmpty() {
+		return 0
+	}
+
+	return (p.End.Year()-p.Start.Year())*12 + int(p.End.Month()) - int(p.Start.Month()) + 1
+}
+
+// Months lists the first day of every month in a bounded period, open-ended
+// periods yield nil.
+func (p Period) Months() []time.Time {
+	count := p.MonthCount()
+	if count <= 0 {
+		return nil
+	}
+
+	months := make([]time.Time, 0, count)
+	for month := p.Start; !month.After(*p.End); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+
+	return months
+}
+
+// MonthStart truncates t to the first day of its month in UTC.
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthsBetween counts the calendar months from the month of from to the
+// month of to, negative when to comes first.
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
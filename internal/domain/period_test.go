@@ -0,0 +1,82 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriod(t *testing.T) {
+	t.Parallel()
+
+	spring := domain.NewPeriod(month(2025, time.March), pointer.Ref(month(2025, time.May)))
+	fromApril := domain.NewPeriod(month(2025, time.April), nil)
+	winter := domain.NewPeriod(month(2024, time.December), pointer.Ref(month(2025, time.February)))
+
+	t.Run("normalizes to whole months", func(t *testing.T) {
+		t.Parallel()
+
+		period := domain.NewPeriod(
+			time.Date(2025, time.March, 17, 13, 0, 0, 0, time.UTC),
+			pointer.Ref(time.Date(2025, time.May, 2, 0, 0, 0, 0, time.UTC)),
+		)
+		require.Equal(t, spring, period)
+		require.Equal(t, 3, period.MonthCount())
+		require.Equal(
+			t,
+			[]time.Time{month(2025, time.March), month(2025, time.April), month(2025, time.May)},
+			period.Months(),
+		)
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, spring.Contains(time.Date(2025, time.May, 31, 23, 0, 0, 0, time.UTC)))
+		require.False(t, spring.Contains(month(2025, time.June)))
+		require.False(t, spring.Contains(month(2025, time.February)))
+		require.True(t, fromApril.Contains(month(2099, time.January)))
+	})
+
+	t.Run("intersect", func(t *testing.T) {
+		t.Parallel()
+
+		intersection, ok := spring.Intersect(fromApril)
+		require.True(t, ok)
+		require.Equal(
+			t,
+			domain.NewPeriod(month(2025, time.April), pointer.Ref(month(2025, time.May))),
+			intersection,
+		)
+		require.True(t, spring.Overlaps(fromApril))
+
+		_, ok = winter.Intersect(fromApril)
+		require.False(t, ok)
+		require.False(t, fromApril.Overlaps(winter))
+	})
+
+	t.Run("open-ended", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, fromApril.IsBounded())
+		require.Equal(t, -1, fromApril.MonthCount())
+		require.Nil(t, fromApril.Months())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		empty := domain.NewPeriod(month(2025, time.May), pointer.Ref(month(2025, time.March)))
+		require.True(t, empty.IsEmpty())
+		require.Zero(t, empty.MonthCount())
+		require.False(t, empty.Overlaps(spring))
+	})
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+var (
+	ErrServiceSchedulePrice = errors.Join(
+		errServiceSubscription,
+		errors.New("schedule price change failed"),
+	)
+	ErrServicePriceHistory = errors.Join(
+		errServiceSubscription,
+		errors.New("price history failed"),
+	)
+)
+
+// SchedulePriceChange bills the subscription at a new price from the month
+// of change.EffectiveFrom on, until the next change. It returns the price
+// history after the change.
+func (s *SubscriptionService) SchedulePriceChange(
+	ctx context.Context,
+	change PriceChange,
+) ([]PriceChange, error) {
+	slog.DebugContext(ctx, "Service: scheduling price change.", log.RequestID(ctx))
+	change.EffectiveFrom = MonthStart(change.EffectiveFrom)
+
+	var history []PriceChange
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		subscription, err := s.subscriptionRepo.Read(ctx, c, change.SubscriptionID)
+		if err != nil {
+			return err
+		}
+		if err := validatePriceChange(subscription, change); err != nil {
+			return err
+		}
+		if err := s.subscriptionRepo.SchedulePrice(ctx, c, change); err != nil {
+			return err
+		}
+
+		history, err = s.priceHistory(ctx, c, subscription)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceSchedulePrice, err)
+	}
+	return history, nil
+}
+
+// PriceHistory lists the prices of a subscription in effective order, the
+// first one is the price it started with.
+func (s *SubscriptionService) PriceHistory(
+	ctx context.Context,
+	subscriptionID SubscriptionID,
+) ([]PriceChange, error) {
+	slog.DebugContext(ctx, "Service: reading price history.", log.RequestID(ctx))
+	var history []PriceChange
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		subscription, err := s.subscriptionRepo.Read(ctx, c, subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		history, err = s.priceHistory(ctx, c, subscription)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServicePriceHistory, err)
+	}
+	return history, nil
+}
+
+func (s *SubscriptionService) priceHistory(
+	ctx context.Context,
+	c Connection,
+	subscription Subscription,
+) ([]PriceChange, error) {
+	changes, err := s.subscriptionRepo.ReadPrices(ctx, c, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := MonthStart(subscription.StartDate)
+	history := []PriceChange{{
+		SubscriptionID: subscription.ID,
+		EffectiveFrom:  start,
+		Price:          subscription.Cost,
+	}}
+	for _, change := range changes {
+		// A change in the start month replaces the initial price, earlier
+		// ones left over from a moved start date are not billed.
+		switch {
+		case change.EffectiveFrom.Equal(start):
+			history[0] = change
+		case change.EffectiveFrom.After(start):
+			history = append(history, change)
+		}
+	}
+
+	return history, nil
+}
+
+func validatePriceChange(subscription Subscription, change PriceChange) error {
+	var fields []FieldError
+	if change.Price < 0 {
+		fields = append(fields, FieldError{
+			Field:   "price",
+			Message: "price must not be negative",
+		})
+	}
+	if !subscription.Period().Contains(change.EffectiveFrom) {
+		fields = append(fields, FieldError{
+			Field:   "effective_from",
+			Message: "must fall within the subscription",
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_price_change", "price change is invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
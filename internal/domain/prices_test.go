@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceAt(t *testing.T) {
+	t.Parallel()
+
+	subscription := domain.Subscription{
+		Cost:      100,
+		StartDate: monthOf(2025, time.March),
+		Prices: []domain.PriceChange{
+			{EffectiveFrom: monthOf(2025, time.January), Price: 1000},
+			{EffectiveFrom: monthOf(2025, time.June), Price: 150},
+			{EffectiveFrom: monthOf(2025, time.September), Price: 200},
+		},
+	}
+
+	require.Equal(t, 100, subscription.PriceAt(monthOf(2025, time.March)))
+	require.Equal(t, 100, subscription.PriceAt(time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)))
+	require.Equal(
+		t,
+		150,
+		subscription.PriceAt(time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)),
+	)
+	require.Equal(t, 150, subscription.PriceAt(monthOf(2025, time.August)))
+	require.Equal(t, 200, subscription.PriceAt(monthOf(2026, time.January)))
+
+	charges := subscription.Charges(domain.NewPeriod(
+		monthOf(2025, time.May),
+		pointer.Ref(monthOf(2025, time.September)),
+	))
+	amounts := make([]int, 0, len(charges))
+	for _, charge := range charges {
+		amounts = append(amounts, charge.Amount)
+	}
+	require.Equal(t, []int{100, 150, 150, 150, 200}, amounts)
+}
+
+func TestSchedulePriceChange(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: monthOf(2025, time.January),
+		EndDate:   pointer.Ref(monthOf(2025, time.December)),
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	for _, change := range []domain.PriceChange{
+		{SubscriptionID: subscription.ID, EffectiveFrom: monthOf(2024, time.December), Price: 150},
+		{SubscriptionID: subscription.ID, EffectiveFrom: monthOf(2026, time.January), Price: 150},
+		{SubscriptionID: subscription.ID, EffectiveFrom: monthOf(2025, time.July), Price: -1},
+	} {
+		_, err := service.SchedulePriceChange(t.Context(), change)
+		require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err), change)
+	}
+
+	_, err := service.SchedulePriceChange(t.Context(), domain.PriceChange{
+		SubscriptionID: uuid.New(),
+		EffectiveFrom:  monthOf(2025, time.July),
+		Price:          150,
+	})
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+	history, err := service.SchedulePriceChange(t.Context(), domain.PriceChange{
+		SubscriptionID: subscription.ID,
+		EffectiveFrom:  time.Date(2025, time.July, 20, 0, 0, 0, 0, time.UTC),
+		Price:          150,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.PriceChange{
+		{SubscriptionID: subscription.ID, EffectiveFrom: monthOf(2025, time.January), Price: 100},
+		{SubscriptionID: subscription.ID, EffectiveFrom: monthOf(2025, time.July), Price: 150},
+	}, history)
+
+	total, err := service.TotalSubscriptionsCost(
+		t.Context(),
+		subscription.UserID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.December)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 6*100+6*150, total)
+}
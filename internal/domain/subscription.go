@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
@@ -17,9 +18,9 @@ var (
 		errServiceSubscription,
 		errors.New("read by id failed"),
 	)
-	ErrGetLatestSubscriptionEndDate = errors.Join(
+	ErrServiceReadOverlapping = errors.Join(
 		errServiceSubscription,
-		errors.New("get end date failed"),
+		errors.New("read overlapping failed"),
 	)
 	ErrServiceCreateSubscription = errors.Join(
 		errServiceSubscription,
@@ -33,6 +34,14 @@ var (
 		errServiceSubscription,
 		errors.New("update failed"),
 	)
+	ErrServiceRestoreSubscription = errors.Join(
+		errServiceSubscription,
+		errors.New("restore failed"),
+	)
+	ErrServicePurgeSubscriptions = errors.Join(
+		errServiceSubscription,
+		errors.New("purge failed"),
+	)
 	ErrServiceReadAllByUserID = errors.Join(
 		errServiceSubscription,
 		errors.New("read all by user id failed"),
@@ -46,37 +55,58 @@ var (
 type SubscriptionService struct {
 	provider         ConnectionProvider
 	subscriptionRepo SubscriptionsRepository
+	eventsRepo       SubscriptionEventsRepository
+	ratesRepo        ExchangeRatesRepository
+	servicesRepo     ServicesRepository
+	tagsRepo         TagsRepository
+	budgetsRepo      BudgetsRepository
+	analyticsRepo    AnalyticsRepository
+	monthlyCostsRepo MonthlyCostsRepository
 }
 
 func NewSubscriptionService(
 	provider ConnectionProvider,
-	subscriptionRepo SubscriptionsRepository,
+	repositories Repositories,
 ) *SubscriptionService {
 	return &SubscriptionService{
 		provider:         provider,
-		subscriptionRepo: subscriptionRepo,
+		subscriptionRepo: repositories.Subscriptions,
+		eventsRepo:       repositories.Events,
+		ratesRepo:        repositories.ExchangeRates,
+		servicesRepo:     repositories.Services,
+		tagsRepo:         repositories.Tags,
+		budgetsRepo:      repositories.Budgets,
+		analyticsRepo:    repositories.Analytics,
+		monthlyCostsRepo: repositories.MonthlyCosts,
 	}
 }
 
 func (s *SubscriptionService) Create(ctx context.Context, subscription Subscription) error {
 	slog.DebugContext(ctx, "Service: creating subscription.", log.RequestID(ctx))
+	subscription = subscription.WithBillingDefaults()
+	subscription.Tags = normalizeTags(subscription.Tags)
+	if err := validateSubscription(subscription); err != nil {
+		return errors.Join(ErrServiceCreateSubscription, err)
+	}
 	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
-		latestEndDate, err := s.subscriptionRepo.GetLatestSubscriptionEndDate(
-			ctx,
-			c,
-			subscription.UserID,
-			subscription.Name,
-		)
+		subscription, err := s.resolveService(ctx, c, subscription)
 		if err != nil {
-			return errors.Join(ErrGetLatestSubscriptionEndDate, err)
+			return err
+		}
+		if err := s.ensureNoOverlap(ctx, c, subscription); err != nil {
+			return err
 		}
-		if latestEndDate != nil && (latestEndDate.After(subscription.StartDate)) {
-			return errors.Join(
-				ErrServiceCreateSubscription,
-				errors.New("previous subscription has not ended"))
+		if err := s.subscriptionRepo.Create(ctx, c, subscription); err != nil {
+			return err
+		}
+
+		// Read back what the storage kept, dates are truncated to days.
+		created, err := s.subscriptionRepo.Read(ctx, c, subscription.ID)
+		if err != nil {
+			return err
 		}
 
-		return s.subscriptionRepo.Create(ctx, c, subscription)
+		return s.record(ctx, c, SubscriptionEventCreated, nil, &created)
 	})
 	if err != nil {
 		return errors.Join(ErrServiceCreateSubscription, err)
@@ -89,8 +119,16 @@ func (s *SubscriptionService) Delete(
 	subscriptionID SubscriptionID,
 ) error {
 	slog.DebugContext(ctx, "Service: deleting subscription.", log.RequestID(ctx))
-	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
-		return s.subscriptionRepo.Delete(ctx, c, subscriptionID)
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		before, err := s.subscriptionRepo.Read(ctx, c, subscriptionID)
+		if err != nil {
+			return err
+		}
+		if err := s.subscriptionRepo.Delete(ctx, c, subscriptionID); err != nil {
+			return err
+		}
+
+		return s.record(ctx, c, SubscriptionEventDeleted, &before, nil)
 	})
 	if err != nil {
 		return errors.Join(ErrServiceDeleteSubscription, err)
@@ -98,10 +136,77 @@ func (s *SubscriptionService) Delete(
 	return nil
 }
 
+// Restore brings back a deleted subscription unless a subscription created
+// since then overlaps it.
+func (s *SubscriptionService) Restore(
+	ctx context.Context,
+	subscriptionID SubscriptionID,
+) (Subscription, error) {
+	slog.DebugContext(ctx, "Service: restoring subscription.", log.RequestID(ctx))
+	var subscription Subscription
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		subscription, err = s.subscriptionRepo.Restore(ctx, c, subscriptionID)
+		if err != nil {
+			return err
+		}
+		if err := s.ensureNoOverlap(ctx, c, subscription); err != nil {
+			return err
+		}
+
+		return s.record(ctx, c, SubscriptionEventRestored, nil, &subscription)
+	})
+	if err != nil {
+		return Subscription{}, errors.Join(ErrServiceRestoreSubscription, err)
+	}
+	return subscription, nil
+}
+
+// PurgeDeleted removes the subscriptions deleted before the given time and
+// reports how many were removed.
+func (s *SubscriptionService) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	slog.DebugContext(ctx, "Service: purging deleted subscriptions.", log.RequestID(ctx))
+	var purged int
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		purged, err = s.subscriptionRepo.Purge(ctx, c, before)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Join(ErrServicePurgeSubscriptions, err)
+	}
+	return purged, nil
+}
+
 func (s *SubscriptionService) Update(ctx context.Context, subscription Subscription) error {
 	slog.DebugContext(ctx, "Service: updating subscription.", log.RequestID(ctx))
-	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
-		return s.subscriptionRepo.Update(ctx, c, subscription)
+	subscription = subscription.WithBillingDefaults()
+	subscription.Tags = normalizeTags(subscription.Tags)
+	if err := validateSubscription(subscription); err != nil {
+		return errors.Join(ErrServiceUpdateSubscription, err)
+	}
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		before, err := s.subscriptionRepo.Read(ctx, c, subscription.ID)
+		if err != nil {
+			return err
+		}
+		subscription, err := s.resolveService(ctx, c, subscription)
+		if err != nil {
+			return err
+		}
+		if err := s.ensureNoOverlap(ctx, c, subscription); err != nil {
+			return err
+		}
+		if err := s.subscriptionRepo.Update(ctx, c, subscription); err != nil {
+			return err
+		}
+
+		after, err := s.subscriptionRepo.Read(ctx, c, subscription.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, c, SubscriptionEventUpdated, &before, &after)
 	})
 	if err != nil {
 		return errors.Join(ErrServiceUpdateSubscription, err)
@@ -125,22 +230,60 @@ func (s *SubscriptionService) ReadByID(ctx context.Context,
 	return subscription, nil
 }
 
-func (s *SubscriptionService) ReadAllByUserID(
+func (s *SubscriptionService) ReadAll(
 	ctx context.Context,
-	subscriptionUserID UserID,
-	limit int, offset int,
-) ([]Subscription, error) {
-	slog.DebugContext(ctx, "Service: reading subscription by user ID.", log.RequestID(ctx))
-	var subscriptions []Subscription
+	filter SubscriptionFilter,
+	pagination Pagination,
+) (SubscriptionPage, error) {
+	slog.DebugContext(ctx, "Service: reading subscriptions.", log.RequestID(ctx))
+	if pagination.Sort.Field == "" {
+		pagination.Sort = DefaultSubscriptionSort
+	}
+	if err := validateListing(filter, pagination); err != nil {
+		return SubscriptionPage{}, errors.Join(ErrServiceReadAllByUserID, err)
+	}
+
+	var page SubscriptionPage
 	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
-		var dbErr error
-		subscriptions, dbErr = s.subscriptionRepo.ReadAll(ctx, c, subscriptionUserID, limit, offset)
-		return dbErr
+		// One extra row tells whether there is a next page.
+		lookahead := pagination
+		lookahead.Limit++
+
+		if filter.ServiceName != nil {
+			name, dbErr := s.canonicalName(ctx, c, *filter.ServiceName)
+			if dbErr != nil {
+				return dbErr
+			}
+			filter.ServiceName = &name
+		}
+
+		subscriptions, dbErr := s.subscriptionRepo.ReadAll(ctx, c, filter, lookahead)
+		if dbErr != nil {
+			return dbErr
+		}
+
+		page.HasMore = len(subscriptions) > pagination.Limit
+		if page.HasMore {
+			subscriptions = subscriptions[:pagination.Limit]
+			cursor := NewSubscriptionCursor(pagination.Sort, subscriptions[len(subscriptions)-1])
+			page.Next = &cursor
+		}
+		page.Subscriptions = subscriptions
+
+		if pagination.IncludeTotal {
+			total, dbErr := s.subscriptionRepo.Count(ctx, c, filter)
+			if dbErr != nil {
+				return dbErr
+			}
+			page.Total = &total
+		}
+
+		return nil
 	})
 	if err != nil {
-		return subscriptions, errors.Join(ErrServiceReadAllByUserID, err)
+		return page, errors.Join(ErrServiceReadAllByUserID, err)
 	}
-	return subscriptions, nil
+	return page, nil
 }
 
 func (s *SubscriptionService) TotalSubscriptionsCost(
@@ -153,7 +296,14 @@ func (s *SubscriptionService) TotalSubscriptionsCost(
 	var totalCost int
 	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
 		slog.DebugContext(ctx, "Service: calculating total cost.", log.RequestID(ctx))
-		var dbErr error
+		subscriptionName, dbErr := s.canonicalName(ctx, c, subscriptionName)
+		if dbErr != nil {
+			return dbErr
+		}
+		dbErr = s.ensureOneCurrency(ctx, c, subscriptionUserID, subscriptionName, start, end)
+		if dbErr != nil {
+			return dbErr
+		}
 		totalCost, dbErr = s.subscriptionRepo.CalculateTotalCost(
 			ctx,
 			c,
@@ -169,3 +319,109 @@ func (s *SubscriptionService) TotalSubscriptionsCost(
 	}
 	return totalCost, nil
 }
+
+// ensureNoOverlap rejects a subscription whose days overlap another
+// subscription of the same user to the same service, end dates are inclusive
+// and a missing end date never ends. Concurrent writers that both pass this
+// check are stopped by the storage, see ErrSubscriptionOverlap.
+func (s *SubscriptionService) ensureNoOverlap(
+	ctx context.Context,
+	c Connection,
+	subscription Subscription,
+) error {
+	overlapping, err := s.subscriptionRepo.ReadOverlapping(ctx, c, subscription)
+	if err != nil {
+		return errors.Join(ErrServiceReadOverlapping, err)
+	}
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(overlapping))
+	for _, other := range overlapping {
+		fields = append(fields, FieldError{
+			Field:   "start_date",
+			Message: "overlaps subscription " + other.ID.String(),
+		})
+	}
+
+	return ErrSubscriptionOverlap.WithFields(fields...)
+}
+
+func validateSubscription(subscription Subscription) error {
+	var fields []FieldError
+	if strings.TrimSpace(subscription.Name) == "" {
+		fields = append(fields, FieldError{
+			Field:   "service_name",
+			Message: "service name must not be empty",
+		})
+	}
+	if subscription.Cost < 0 {
+		fields = append(fields, FieldError{
+			Field:   "price",
+			Message: "price must not be negative",
+		})
+	}
+	if !subscription.BillingPeriod.IsValid() {
+		fields = append(fields, FieldError{
+			Field:   "billing_period",
+			Message: "billing period must be weekly, monthly, quarterly or yearly",
+		})
+	}
+	if !subscription.Currency.IsValid() {
+		fields = append(fields, FieldError{
+			Field:   "currency",
+			Message: "currency must be an ISO 4217 code such as RUB",
+		})
+	}
+	if subscription.EndDate != nil && subscription.EndDate.Before(subscription.StartDate) {
+		fields = append(fields, FieldError{
+			Field:   "end_date",
+			Message: "end date must not be before start date",
+		})
+	}
+	fields = append(fields, validateDiscounts(subscription)...)
+	fields = append(fields, validateTags(subscription)...)
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_subscription", "subscription is invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
+
+func validateListing(filter SubscriptionFilter, pagination Pagination) error {
+	var fields []FieldError
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		fields = append(fields, FieldError{
+			Field:   "min_price",
+			Message: "min price must not exceed max price",
+		})
+	}
+	if filter.StartFrom != nil && filter.StartTo != nil && filter.StartFrom.After(*filter.StartTo) {
+		fields = append(fields, FieldError{
+			Field:   "start_from",
+			Message: "start_from must not be after start_to",
+		})
+	}
+	if pagination.After != nil && pagination.Offset > 0 {
+		fields = append(fields, FieldError{
+			Field:   "cursor",
+			Message: "cursor and offset are mutually exclusive",
+		})
+	}
+	if pagination.After != nil && pagination.After.Sort != pagination.Sort {
+		fields = append(fields, FieldError{
+			Field:   "cursor",
+			Message: "cursor was issued for a different sort order",
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError("invalid_listing", "listing parameters are invalid").
+			WithFields(fields...)
+	}
+
+	return nil
+}
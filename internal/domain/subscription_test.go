@@ -0,0 +1,198 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type overlapRepository struct {
+	domain.SubscriptionsRepository
+	overlapping []domain.Subscription
+	written     *int
+}
+
+func (r overlapRepository) ReadOverlapping(
+	context.Context,
+	domain.Connection,
+	domain.Subscription,
+) ([]domain.Subscription, error) {
+	return r.overlapping, nil
+}
+
+func (r overlapRepository) Read(
+	_ context.Context,
+	_ domain.Connection,
+	id domain.SubscriptionID,
+) (domain.Subscription, error) {
+	return domain.Subscription{ID: id}, nil
+}
+
+func (r overlapRepository) Create(context.Context, domain.Connection, domain.Subscription) error {
+	*r.written++
+
+	return nil
+}
+
+func (r overlapRepository) Update(context.Context, domain.Connection, domain.Subscription) error {
+	*r.written++
+
+	return nil
+}
+
+func (r overlapRepository) Delete(context.Context, domain.Connection, domain.SubscriptionID) error {
+	*r.written++
+
+	return nil
+}
+
+// emptyCatalog resolves no service name.
+type emptyCatalog struct {
+	domain.ServicesRepository
+}
+
+func (emptyCatalog) Resolve(context.Context, domain.Connection, string) (domain.Service, error) {
+	return domain.Service{}, domain.ErrServiceNotFound
+}
+
+type eventsRecorder struct {
+	domain.SubscriptionEventsRepository
+	events *[]domain.SubscriptionEvent
+}
+
+func (r eventsRecorder) Append(
+	_ context.Context,
+	_ domain.Connection,
+	event domain.SubscriptionEvent,
+) error {
+	*r.events = append(*r.events, event)
+
+	return nil
+}
+
+func TestWritesRejectOverlappingSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+	other := subscription
+	other.ID = uuid.New()
+
+	writes := map[string]func(*domain.SubscriptionService) error{
+		"create": func(s *domain.SubscriptionService) error {
+			return s.Create(t.Context(), subscription)
+		},
+		"update": func(s *domain.SubscriptionService) error {
+			return s.Update(t.Context(), subscription)
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				written int
+				events  []domain.SubscriptionEvent
+			)
+			service := domain.NewSubscriptionService(
+				database.NewDummyProvider(nil),
+				domain.Repositories{
+					Subscriptions: overlapRepository{
+						overlapping: []domain.Subscription{other},
+						written:     &written,
+					},
+					Events:   eventsRecorder{events: &events},
+					Services: emptyCatalog{},
+				},
+			)
+
+			err := write(service)
+			require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+			require.Equal(t, domain.ErrorKindConflict, domain.KindOf(err))
+			require.Zero(t, written)
+			require.Empty(t, events)
+
+			service = domain.NewSubscriptionService(
+				database.NewDummyProvider(nil),
+				domain.Repositories{
+					Subscriptions: overlapRepository{written: &written},
+					Events:        eventsRecorder{events: &events},
+					Services:      emptyCatalog{},
+				},
+			)
+			require.NoError(t, write(service))
+			require.Equal(t, 1, written)
+			require.Len(t, events, 1)
+		})
+	}
+}
+
+func TestWritesRecordTheActor(t *testing.T) {
+	t.Parallel()
+
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var (
+		written int
+		events  []domain.SubscriptionEvent
+	)
+	service := domain.NewSubscriptionService(
+		database.NewDummyProvider(nil),
+		domain.Repositories{
+			Subscriptions: overlapRepository{written: &written},
+			Events:        eventsRecorder{events: &events},
+			Services:      emptyCatalog{},
+		},
+	)
+
+	require.NoError(t, service.Create(t.Context(), subscription))
+	ctx := log.WithActor(log.WithRequestID(t.Context(), "request-1"), "alice")
+	require.NoError(t, service.Delete(ctx, subscription.ID))
+
+	require.Len(t, events, 2)
+	require.Equal(t, domain.SubscriptionEventCreated, events[0].Type)
+	require.Equal(t, "anonymous", events[0].Actor)
+	require.Nil(t, events[0].Before)
+	require.Equal(t, subscription.ID, events[0].After.ID)
+
+	require.Equal(t, domain.SubscriptionEventDeleted, events[1].Type)
+	require.Equal(t, "alice", events[1].Actor)
+	require.Equal(t, "request-1", events[1].RequestID)
+	require.Nil(t, events[1].After)
+}
+
+func TestHistoryRequiresOneSubject(t *testing.T) {
+	t.Parallel()
+
+	service := domain.NewSubscriptionService(database.NewDummyProvider(nil), domain.Repositories{})
+
+	_, err := service.History(t.Context(), domain.EventQuery{Limit: 10})
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+
+	id := uuid.New()
+	_, err = service.History(t.Context(), domain.EventQuery{
+		SubscriptionID: &id,
+		UserID:         &id,
+		Limit:          10,
+	})
+	require.Equal(t, domain.ErrorKindValidation, domain.KindOf(err))
+}
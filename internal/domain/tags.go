@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/log"
+)
+
+// maxTagLength bounds tag names, in characters.
+const maxTagLength = 64
+
+var (
+	ErrServiceCreateTag = errors.Join(
+		errServiceSubscription,
+		errors.New("create tag failed"),
+	)
+	ErrServiceListTags = errors.Join(
+		errServiceSubscription,
+		errors.New("list tags failed"),
+	)
+	ErrServiceRenameTag = errors.Join(
+		errServiceSubscription,
+		errors.New("rename tag failed"),
+	)
+	ErrServiceDeleteTag = errors.Join(
+		errServiceSubscription,
+		errors.New("delete tag failed"),
+	)
+	ErrServiceMergeTags = errors.Join(
+		errServiceSubscription,
+		errors.New("merge tags failed"),
+	)
+)
+
+// TagKey is what the tags of a user are told apart by, the name normalized
+// as a service name and lower cased.
+func TagKey(name string) string {
+	return ServiceKey(name)
+}
+
+// normalizeTags normalizes the tag names, drops blank ones and repeats of an
+// earlier name and sorts the rest.
+func normalizeTags(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeServiceName(name)
+		if key := TagKey(name); key != "" && !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, name)
+		}
+	}
+	slices.Sort(normalized)
+
+	return normalized
+}
+
+func (s *SubscriptionService) CreateTag(ctx context.Context, tag Tag) (Tag, error) {
+	slog.DebugContext(ctx, "Service: creating tag.", log.RequestID(ctx))
+	tag.Name = NormalizeServiceName(tag.Name)
+	tag.Subscriptions = 0
+	if err := validateTagName("name", tag.Name); err != nil {
+		return Tag{}, errors.Join(ErrServiceCreateTag, err)
+	}
+
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		if err := s.ensureTagNameFree(ctx, c, tag); err != nil {
+			return err
+		}
+
+		return s.tagsRepo.Create(ctx, c, tag)
+	})
+	if err != nil {
+		return Tag{}, errors.Join(ErrServiceCreateTag, err)
+	}
+
+	return tag, nil
+}
+
+func (s *SubscriptionService) ListTags(ctx context.Context, userID UserID) ([]Tag, error) {
+	slog.DebugContext(ctx, "Service: listing tags.", log.RequestID(ctx))
+	var tags []Tag
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		var err error
+		tags, err = s.tagsRepo.List(ctx, c, userID)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrServiceListTags, err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames the tag on all of its subscriptions. A name the user
+// already has for another tag is rejected, those tags are merged instead.
+func (s *SubscriptionService) RenameTag(ctx context.Context, id TagID, name string) (Tag, error) {
+	slog.DebugContext(ctx, "Service: renaming tag.", log.RequestID(ctx))
+	name = NormalizeServiceName(name)
+	if err := validateTagName("name", name); err != nil {
+		return Tag{}, errors.Join(ErrServiceRenameTag, err)
+	}
+
+	var tag Tag
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		current, err := s.tagsRepo.Read(ctx, c, id)
+		if err != nil {
+			return err
+		}
+		current.Name = name
+		if err := s.ensureTagNameFree(ctx, c, current); err != nil {
+			return err
+		}
+		if err := s.tagsRepo.Rename(ctx, c, id, name); err != nil {
+			return err
+		}
+
+		tag, err = s.tagsRepo.Read(ctx, c, id)
+		return err
+	})
+	if err != nil {
+		return Tag{}, errors.Join(ErrServiceRenameTag, err)
+	}
+
+	return tag, nil
+}
+
+// DeleteTag removes the tag from its subscriptions and deletes it.
+func (s *SubscriptionService) DeleteTag(ctx context.Context, id TagID) error {
+	slog.DebugContext(ctx, "Service: deleting tag.", log.RequestID(ctx))
+	err := s.provider.Execute(ctx, func(ctx context.Context, c Connection) error {
+		return s.tagsRepo.Delete(ctx, c, id)
+	})
+	if err != nil {
+		return errors.Join(ErrServiceDeleteTag, err)
+	}
+
+	return nil
+}
+
+// MergeTags tags the subscriptions of from with into and deletes from, both
+// tags must belong to the same user.
+func (s *SubscriptionService) MergeTags(ctx context.Context, from, into TagID) (Tag, error) {
+	slog.DebugContext(ctx, "Service: merging tags.", log.RequestID(ctx))
+	invalidMerge := func(message string) error {
+		return NewValidationError("invalid_merge", "tags cannot be merged").
+			WithFields(FieldError{Field: "into", Message: message})
+	}
+	if from == into {
+		return Tag{}, errors.Join(ErrServiceMergeTags, invalidMerge("must be another tag"))
+	}
+
+	var tag Tag
+	err := s.provider.ExecuteTx(ctx, func(ctx context.Context, c Connection) error {
+		source, err := s.tagsRepo.Read(ctx, c, from)
+		if err != nil {
+			return err
+		}
+		target, err := s.tagsRepo.Read(ctx, c, into)
+		if err != nil {
+			return err
+		}
+		if source.UserID != target.UserID {
+			return invalidMerge("must belong to the same user")
+		}
+		if err := s.tagsRepo.Merge(ctx, c, from, into); err != nil {
+			return err
+		}
+
+		tag, err = s.tagsRepo.Read(ctx, c, into)
+		return err
+	})
+	if err != nil {
+		return Tag{}, errors.Join(ErrServiceMergeTags, err)
+	}
+
+	return tag, nil
+}
+
+// ensureTagNameFree rejects a name another tag of the same user has.
+func (s *SubscriptionService) ensureTagNameFree(ctx context.Context, c Connection, tag Tag) error {
+	tags, err := s.tagsRepo.List(ctx, c, tag.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range tags {
+		if other.ID != tag.ID && TagKey(other.Name) == TagKey(tag.Name) {
+			return ErrTagNameTaken.WithFields(FieldError{
+				Field:   "name",
+				Message: "belongs to tag " + other.ID.String(),
+			})
+		}
+	}
+
+	return nil
+}
+
+func validateTagName(field, name string) error {
+	if fields := tagNameErrors(field, name); len(fields) > 0 {
+		return NewValidationError("invalid_tag", "tag is invalid").WithFields(fields...)
+	}
+
+	return nil
+}
+
+func tagNameErrors(field, name string) []FieldError {
+	switch {
+	case name == "":
+		return []FieldError{{Field: field, Message: "tag name must not be empty"}}
+	case utf8.RuneCountInString(name) > maxTagLength:
+		return []FieldError{{
+			Field:   field,
+			Message: fmt.Sprintf("tag name must not exceed %d characters", maxTagLength),
+		}}
+	}
+
+	return nil
+}
+
+// validateTags reports the tags of a subscription that are too long, blank
+// ones are dropped by normalizeTags.
+func validateTags(subscription Subscription) []FieldError {
+	var fields []FieldError
+	for i, name := range subscription.Tags {
+		fields = append(fields, tagNameErrors(fmt.Sprintf("tags[%d]", i), name)...)
+	}
+
+	return fields
+}
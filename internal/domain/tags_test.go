@@ -0,0 +1,164 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionCategoryAndTags(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	_, err := service.CreateService(t.Context(), domain.Service{
+		ID:       uuid.New(),
+		Name:     "Music",
+		Category: "entertainment",
+	})
+	require.NoError(t, err)
+
+	subscription := domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "music",
+		Cost:      100,
+		UserID:    uuid.New(),
+		StartDate: monthOf(2025, time.January),
+		Tags:      []string{" work ", "Family", "", "WORK"},
+	}
+	require.NoError(t, service.Create(t.Context(), subscription))
+
+	stored, err := service.ReadByID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, "entertainment", stored.Category)
+	require.Equal(t, []string{"Family", "work"}, stored.Tags)
+
+	subscription.ID = uuid.New()
+	subscription.Name = "video"
+	subscription.Tags = []string{strings.Repeat("a", 65)}
+	err = service.Create(t.Context(), subscription)
+
+	var invalid *domain.Error
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, "tags[0]", invalid.Fields[0].Field)
+}
+
+func TestRenameAndMergeTags(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	require.NoError(t, service.Create(t.Context(), domain.Subscription{
+		ID:        uuid.New(),
+		Name:      "Music",
+		Cost:      100,
+		UserID:    userID,
+		StartDate: monthOf(2025, time.January),
+		Tags:      []string{"famly", "home"},
+	}))
+
+	tags, err := service.ListTags(t.Context(), userID)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	famly, home := tags[0], tags[1]
+
+	_, err = service.RenameTag(t.Context(), famly.ID, " HOME")
+	require.ErrorIs(t, err, domain.ErrTagNameTaken)
+
+	family, err := service.RenameTag(t.Context(), famly.ID, "family")
+	require.NoError(t, err)
+	require.Equal(t, "family", family.Name)
+	require.Equal(t, 1, family.Subscriptions)
+
+	_, err = service.MergeTags(t.Context(), family.ID, family.ID)
+	var invalid *domain.Error
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, "into", invalid.Fields[0].Field)
+
+	other, err := service.CreateTag(t.Context(), domain.Tag{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Name:   "family",
+	})
+	require.NoError(t, err)
+	_, err = service.MergeTags(t.Context(), other.ID, family.ID)
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, "invalid_merge", invalid.Code)
+
+	merged, err := service.MergeTags(t.Context(), home.ID, family.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, merged.Subscriptions)
+
+	tags, err = service.ListTags(t.Context(), userID)
+	require.NoError(t, err)
+	require.Equal(t, []domain.Tag{merged}, tags)
+}
+
+func TestTotalCostByGroup(t *testing.T) {
+	t.Parallel()
+
+	service := newMemoryService()
+	userID := uuid.New()
+	for _, subscription := range []domain.Subscription{
+		{Name: "Music", Cost: 100, Category: "entertainment", Tags: []string{"family"}},
+		{Name: "Video", Cost: 300, Category: "entertainment"},
+		{Name: "Cloud", Cost: 50, Tags: []string{"family", "work"}},
+	} {
+		subscription.ID = uuid.New()
+		subscription.UserID = userID
+		subscription.StartDate = monthOf(2025, time.January)
+		require.NoError(t, service.Create(t.Context(), subscription))
+	}
+
+	byCategory, err := service.TotalSubscriptionsCostByGroup(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.February)),
+		domain.CostGroupCategory,
+	)
+	require.NoError(t, err)
+	require.Equal(t, domain.GroupedCost{
+		Total: 900,
+		Groups: []domain.GroupCost{
+			{Key: "", Cost: 100},
+			{Key: "entertainment", Cost: 800},
+		},
+	}, byCategory)
+
+	byTag, err := service.TotalSubscriptionsCostByGroup(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		pointer.Ref(monthOf(2025, time.February)),
+		domain.CostGroupTag,
+	)
+	require.NoError(t, err)
+	require.Equal(t, domain.GroupedCost{
+		Total: 900,
+		Groups: []domain.GroupCost{
+			{Key: "", Cost: 600},
+			{Key: "family", Cost: 300},
+			{Key: "work", Cost: 100},
+		},
+	}, byTag)
+
+	_, err = service.TotalSubscriptionsCostByGroup(
+		t.Context(),
+		userID,
+		"",
+		monthOf(2025, time.January),
+		nil,
+		"service",
+	)
+	var invalid *domain.Error
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, "group_by", invalid.Fields[0].Field)
+}
@@ -8,18 +8,386 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	SubscriptionStatusActive SubscriptionStatus = "active"
+	SubscriptionStatusEnded  SubscriptionStatus = "ended"
+	SubscriptionStatusFuture SubscriptionStatus = "future"
+)
+
+const (
+	BillingWeekly    BillingPeriod = "weekly"
+	BillingMonthly   BillingPeriod = "monthly"
+	BillingQuarterly BillingPeriod = "quarterly"
+	BillingYearly    BillingPeriod = "yearly"
+)
+
+const (
+	DiscountTrial   DiscountKind = "trial"
+	DiscountPercent DiscountKind = "percent"
+	DiscountFixed   DiscountKind = "fixed"
+)
+
+const (
+	SubscriptionEventCreated  SubscriptionEventType = "created"
+	SubscriptionEventUpdated  SubscriptionEventType = "updated"
+	SubscriptionEventDeleted  SubscriptionEventType = "deleted"
+	SubscriptionEventRestored SubscriptionEventType = "restored"
+	SubscriptionEventCanceled SubscriptionEventType = "canceled"
+	SubscriptionEventRenewed  SubscriptionEventType = "renewed"
+)
+
+const (
+	CostGroupCategory CostGroup = "category"
+	CostGroupTag      CostGroup = "tag"
+)
+
+const (
+	BudgetMonthly BudgetPeriod = "monthly"
+	BudgetYearly  BudgetPeriod = "yearly"
+)
+
+const (
+	SortByStartDate   SubscriptionSortField = "start_date"
+	SortByEndDate     SubscriptionSortField = "end_date"
+	SortByPrice       SubscriptionSortField = "price"
+	SortByServiceName SubscriptionSortField = "service_name"
+)
+
+// DefaultSubscriptionSort lists the newest subscriptions first.
+var DefaultSubscriptionSort = SubscriptionSort{Field: SortByStartDate, Descending: true}
+
 type (
 	SubscriptionID = uuid.UUID
 	UserID         = uuid.UUID
+	ServiceID      = uuid.UUID
 	ServiceName    = string
+	TagID          = uuid.UUID
+	BudgetID       = uuid.UUID
 
 	Subscription struct {
-		ID        SubscriptionID `db:"id"`
-		Name      ServiceName    `db:"service_name"`
-		Cost      int            `db:"month_cost"`
-		UserID    UserID         `db:"user_id"`
-		StartDate time.Time      `db:"subs_start_date"`
-		EndDate   *time.Time     `db:"subs_end_date"`
+		ID        SubscriptionID `db:"id"              json:"id"`
+		Name      ServiceName    `db:"service_name"    json:"service_name"`
+		Cost      int            `db:"month_cost"      json:"price"`
+		UserID    UserID         `db:"user_id"         json:"user_id"`
+		StartDate time.Time      `db:"subs_start_date" json:"start_date"`
+		EndDate   *time.Time     `db:"subs_end_date"   json:"end_date,omitempty"`
+		// Cost, in minor units of Currency, is charged once per BillingPeriod.
+		// Quarterly and yearly charges fall in the months a whole number of
+		// periods away from the month of BillingAnchor, weekly ones every seven
+		// days from the start date.
+		BillingPeriod BillingPeriod `db:"billing_period"  json:"billing_period"`
+		BillingAnchor time.Time     `db:"billing_anchor"  json:"billing_anchor"`
+		Currency      Currency      `db:"currency"        json:"currency"`
+		// ServiceID links the subscription to the catalog entry its name
+		// resolved to, it is nil for names missing from the catalog.
+		ServiceID *ServiceID `db:"service_id"      json:"service_id,omitempty"`
+		// Category is free text, it defaults to the category of the catalog
+		// entry the name resolved to.
+		Category string `db:"category"        json:"category,omitempty"`
+		// DeletedAt is set while the subscription is soft deleted, deleted
+		// subscriptions are hidden from reads and costs until restored.
+		DeletedAt *time.Time `db:"deleted_at"      json:"deleted_at,omitempty"`
+		// Prices are the scheduled price changes in effective order, Cost is
+		// billed until the first of them. Only the billing reads fill them in.
+		Prices []PriceChange `db:"-"               json:"-"`
+		// Discounts lower the price in some months, they are in month order and
+		// never overlap.
+		Discounts []Discount `db:"-"               json:"discounts,omitempty"`
+		// Tags are the names of the user's tags on the subscription, sorted.
+		// Saving a subscription creates the tags the user does not have yet.
+		Tags []string `db:"-"               json:"tags,omitempty"`
+	}
+
+	// Tag labels subscriptions of one user, names are unique per user by
+	// TagKey. Subscriptions counts the live subscriptions tagged with it.
+	Tag struct {
+		ID            TagID  `db:"id"`
+		UserID        UserID `db:"user_id"`
+		Name          string `db:"name"`
+		Subscriptions int    `db:"subscriptions"`
+	}
+
+	// Budget limits what a user spends in every calendar month or year, in
+	// minor units of Currency. A budget with a Category or a ServiceName only
+	// counts those subscriptions, at most one of them is set.
+	Budget struct {
+		ID          BudgetID     `db:"id"`
+		UserID      UserID       `db:"user_id"`
+		Period      BudgetPeriod `db:"period"`
+		Limit       int          `db:"amount_limit"`
+		Currency    Currency     `db:"currency"`
+		Category    *string      `db:"category"`
+		ServiceName *ServiceName `db:"service_name"`
+	}
+
+	// BudgetStatus is how a budget stands in the period from the month of
+	// Start to the month of End. Spent is charged up to the month the status
+	// was taken at, Projected is what the subscriptions known so far charge
+	// in the rest of the period.
+	BudgetStatus struct {
+		Budget    Budget
+		Start     time.Time
+		End       time.Time
+		Spent     int
+		Projected int
+	}
+
+	// Discount lowers the price of every charge from the month of StartMonth
+	// to the month of EndMonth. Trials are free, percent discounts take Value
+	// percent off and fixed ones Value minor units, down to zero.
+	Discount struct {
+		SubscriptionID SubscriptionID `db:"subscription_id" json:"-"`
+		Kind           DiscountKind   `db:"kind"            json:"kind"`
+		Value          int            `db:"value"           json:"value,omitempty"`
+		StartMonth     time.Time      `db:"start_month"     json:"start_month"`
+		EndMonth       time.Time      `db:"end_month"       json:"end_month"`
+	}
+
+	// Service is a catalog entry. Subscriptions whose name matches Name or
+	// one of Aliases, ignoring case and extra spaces, are stored under Name.
+	// DefaultPrice, billed every BillingPeriod in Currency, is used for new
+	// subscriptions that come without a price.
+	Service struct {
+		ID            ServiceID     `db:"id"`
+		Name          ServiceName   `db:"name"`
+		Aliases       []string      `db:"-"`
+		Category      string        `db:"category"`
+		DefaultPrice  *int          `db:"default_price"`
+		Currency      Currency      `db:"currency"`
+		BillingPeriod BillingPeriod `db:"billing_period"`
+	}
+
+	// ServiceFilter narrows the catalog down, nil fields are not applied.
+	ServiceFilter struct {
+		Category *string
+	}
+
+	// PriceChange sets the price per billing period of a subscription from the month of
+	// EffectiveFrom on.
+	PriceChange struct {
+		SubscriptionID SubscriptionID `db:"subscription_id"`
+		EffectiveFrom  time.Time      `db:"effective_from"`
+		Price          int            `db:"month_cost"`
+	}
+
+	SubscriptionStatus string
+	// BillingPeriod is how often a subscription is charged, the zero value
+	// bills monthly.
+	BillingPeriod string
+	// Currency is an ISO 4217 code, the zero value is DefaultCurrency.
+	Currency              string
+	DiscountKind          string
+	SubscriptionSortField string
+	// CostGroup is what a rollup of costs is grouped by.
+	CostGroup string
+	// BudgetPeriod is how often the limit of a budget starts over.
+	BudgetPeriod string
+
+	SubscriptionSort struct {
+		Field      SubscriptionSortField
+		Descending bool
+	}
+
+	// SubscriptionFilter narrows a listing down to one user's subscriptions,
+	// nil fields are not applied. Deleted subscriptions are only listed with
+	// IncludeDeleted. Tag names are matched by TagKey.
+	SubscriptionFilter struct {
+		UserID            UserID
+		ServiceName       *ServiceName
+		ServiceNamePrefix *string
+		Category          *string
+		Tag               *string
+		ActiveAt          *time.Time
+		Status            *SubscriptionStatus
+		MinPrice          *int
+		MaxPrice          *int
+		StartFrom         *time.Time
+		StartTo           *time.Time
+		IncludeDeleted    bool
+	}
+
+	// SubscriptionCursor keeps the sort key of the last subscription of a
+	// page, ID breaks ties between equal keys.
+	SubscriptionCursor struct {
+		Sort      SubscriptionSort
+		StartDate time.Time
+		EndDate   *time.Time
+		Cost      int
+		Name      ServiceName
+		ID        SubscriptionID
+	}
+
+	// Pagination selects a page either by offset or, when After is set, by
+	// seeking past the cursor.
+	Pagination struct {
+		Limit        int
+		Offset       int
+		Sort         SubscriptionSort
+		After        *SubscriptionCursor
+		IncludeTotal bool
+	}
+
+	SubscriptionPage struct {
+		Subscriptions []Subscription
+		Next          *SubscriptionCursor
+		HasMore       bool
+		Total         *int
+	}
+
+	// ServiceCost is what one service contributes to a total.
+	ServiceCost struct {
+		Name ServiceName `db:"service_name"`
+		Cost int         `db:"cost"`
+	}
+
+	// ServiceTotalCost is the spend on one service at one price per billing
+	// period, a service billed at several prices, periods or currencies is
+	// reported once per combination. Price is in Currency, Cost is too unless
+	// it was converted.
+	ServiceTotalCost struct {
+		Name          ServiceName   `db:"service_name"`
+		BillingPeriod BillingPeriod `db:"billing_period"`
+		Currency      Currency      `db:"currency"`
+		Charges       int           `db:"charges"`
+		Price         int           `db:"price"`
+		Cost          int           `db:"cost"`
+	}
+
+	TotalCostBreakdown struct {
+		Total int
+		Items []ServiceTotalCost
+	}
+
+	// GroupCost is the spend on the subscriptions of one category or with
+	// one tag, the empty key stands for the uncategorized or untagged ones.
+	GroupCost struct {
+		Key  string `db:"group_key"`
+		Cost int    `db:"cost"`
+	}
+
+	// GroupedCost is a total with its rollup. A subscription with several
+	// tags counts in every one of them, so tag groups may add up to more
+	// than Total.
+	GroupedCost struct {
+		Total  int
+		Groups []GroupCost
+	}
+
+	// MonthlyServiceCost is the spend on one service in one calendar month.
+	// Currency is set where the costs of several currencies are reported
+	// side by side, each in its own.
+	MonthlyServiceCost struct {
+		Month    time.Time   `db:"month"`
+		Name     ServiceName `db:"service_name"`
+		Currency Currency    `db:"currency"`
+		Cost     int         `db:"cost"`
+	}
+
+	// MonthlyCharges counts the charges of one service at one price in one
+	// calendar month.
+	MonthlyCharges struct {
+		Month         time.Time     `db:"month"`
+		Name          ServiceName   `db:"service_name"`
+		BillingPeriod BillingPeriod `db:"billing_period"`
+		Currency      Currency      `db:"currency"`
+		Price         int           `db:"price"`
+		Charges       int           `db:"charges"`
+	}
+
+	// ExchangeRate is how many units of Quote one unit of Base buys from the
+	// month of EffectiveFrom on, until the next rate of the pair.
+	ExchangeRate struct {
+		Base          Currency  `db:"base_currency"`
+		Quote         Currency  `db:"quote_currency"`
+		EffectiveFrom time.Time `db:"effective_from"`
+		Rate          float64   `db:"rate"`
+	}
+
+	// AppliedRate is the rate the charges in From of one month were converted
+	// to To at, EffectiveFrom tells which stored rate it was.
+	AppliedRate struct {
+		Month         time.Time
+		From          Currency
+		To            Currency
+		Rate          float64
+		EffectiveFrom time.Time
+	}
+
+	// ConvertedCost is a total in Currency with every month converted at the
+	// rate in effect in that month. Items keep their original prices and
+	// currencies, only their costs are converted.
+	ConvertedCost struct {
+		Currency Currency
+		Total    int
+		Items    []ServiceTotalCost
+		Rates    []AppliedRate
+	}
+
+	// MonthlyCost is the spend in one calendar month, Services is sorted by
+	// name and empty for months without charges.
+	MonthlyCost struct {
+		Month    time.Time
+		Total    int
+		Services []ServiceCost
+	}
+
+	// MonthlyCostMismatch is a month of one service of a user in one
+	// currency whose stored monthly cost differs from the live calculation, a
+	// missing row counts as zero.
+	MonthlyCostMismatch struct {
+		UserID   UserID      `db:"user_id"`
+		Name     ServiceName `db:"service_name"`
+		Month    time.Time   `db:"month"`
+		Currency Currency    `db:"currency"`
+		Stored   int         `db:"stored"`
+		Live     int         `db:"live"`
+	}
+
+	// MonthlyActivity counts the live subscriptions of every user touching
+	// one calendar month, New of them start in it and Cancelled end in it.
+	MonthlyActivity struct {
+		Month     time.Time `db:"month"`
+		Active    int       `db:"active"`
+		New       int       `db:"new"`
+		Cancelled int       `db:"cancelled"`
+	}
+
+	SubscriptionEventType string
+
+	// SubscriptionEvent records one change to a subscription. Before is nil
+	// for creations and restores, After is nil for deletions.
+	SubscriptionEvent struct {
+		ID             int64                 `db:"id"`
+		SubscriptionID SubscriptionID        `db:"subscription_id"`
+		UserID         UserID                `db:"user_id"`
+		Type           SubscriptionEventType `db:"event_type"`
+		Before         *Subscription         `db:"before_state"`
+		After          *Subscription         `db:"after_state"`
+		Actor          string                `db:"actor"`
+		RequestID      string                `db:"request_id"`
+		OccurredAt     time.Time             `db:"occurred_at"`
+	}
+
+	// SubscriptionChange is a subscription before and after a write that
+	// changed it.
+	SubscriptionChange struct {
+		Before Subscription
+		After  Subscription
+	}
+
+	// EventQuery selects the history of one subscription or of all
+	// subscriptions of one user, newest first. Before seeks past an event ID.
+	EventQuery struct {
+		SubscriptionID *SubscriptionID
+		UserID         *UserID
+		Limit          int
+		Before         *int64
+	}
+
+	EventPage struct {
+		Events  []SubscriptionEvent
+		Next    *int64
+		HasMore bool
 	}
 
 	Connection interface {
@@ -38,7 +406,39 @@ type (
 		ReadByID(context.Context, SubscriptionID) (Subscription, error)
 		Update(context.Context, Subscription) error
 		Delete(context.Context, SubscriptionID) error
-		ReadAllByUserID(context.Context, UserID, int, int) ([]Subscription, error)
+		Restore(context.Context, SubscriptionID) (Subscription, error)
+		Cancel(context.Context, SubscriptionID, time.Time) (Subscription, error)
+		Renew(context.Context, SubscriptionID, *int) (Subscription, error)
+		SchedulePriceChange(context.Context, PriceChange) ([]PriceChange, error)
+		ImportExchangeRates(context.Context, []ExchangeRate) error
+		CreateService(context.Context, Service) (Service, error)
+		ReadService(context.Context, ServiceID) (Service, error)
+		UpdateService(context.Context, Service) (Service, error)
+		DeleteService(context.Context, ServiceID) error
+		ListServices(context.Context, ServiceFilter) ([]Service, error)
+		// ResolveService finds the catalog entry a service name refers to.
+		ResolveService(context.Context, ServiceName) (Service, error)
+		CreateTag(context.Context, Tag) (Tag, error)
+		ListTags(context.Context, UserID) ([]Tag, error)
+		RenameTag(context.Context, TagID, string) (Tag, error)
+		DeleteTag(context.Context, TagID) error
+		// MergeTags moves the subscriptions of the first tag to the second
+		// one, deletes the first and returns the second.
+		MergeTags(context.Context, TagID, TagID) (Tag, error)
+		CreateBudget(context.Context, Budget) (Budget, error)
+		ReadBudget(context.Context, BudgetID) (Budget, error)
+		UpdateBudget(context.Context, Budget) (Budget, error)
+		DeleteBudget(context.Context, BudgetID) error
+		ListBudgets(context.Context, UserID) ([]Budget, error)
+		// BudgetStatuses reports every budget of the user in its period that
+		// contains the month.
+		BudgetStatuses(context.Context, UserID, time.Time) ([]BudgetStatus, error)
+		// BudgetWarnings reports the budgets the subscription counts towards
+		// that are over their limit with it.
+		BudgetWarnings(context.Context, Subscription) ([]BudgetStatus, error)
+		PriceHistory(context.Context, SubscriptionID) ([]PriceChange, error)
+		History(context.Context, EventQuery) (EventPage, error)
+		ReadAll(context.Context, SubscriptionFilter, Pagination) (SubscriptionPage, error)
 		TotalSubscriptionsCost(
 			context.Context,
 			UserID,
@@ -46,5 +446,75 @@ type (
 			time.Time,
 			*time.Time,
 		) (int, error)
+		TotalSubscriptionsCostByService(
+			context.Context,
+			UserID,
+			ServiceName,
+			time.Time,
+			*time.Time,
+		) (TotalCostBreakdown, error)
+		TotalSubscriptionsCostByGroup(
+			context.Context,
+			UserID,
+			ServiceName,
+			time.Time,
+			*time.Time,
+			CostGroup,
+		) (GroupedCost, error)
+		ConvertedTotalCost(
+			context.Context,
+			UserID,
+			ServiceName,
+			time.Time,
+			*time.Time,
+			Currency,
+		) (ConvertedCost, error)
+		MonthlySubscriptionsCost(
+			context.Context,
+			UserID,
+			ServiceName,
+			time.Time,
+			*time.Time,
+		) ([]MonthlyCost, error)
+		// ForecastSubscriptionsCost projects the monthly costs of the given
+		// number of months from the month of the time on.
+		ForecastSubscriptionsCost(
+			context.Context,
+			UserID,
+			ServiceName,
+			time.Time,
+			int,
+		) ([]MonthlyCost, error)
+		// MonthlyRevenue is MonthlySubscriptionsCost over the subscriptions
+		// of every user, converted to the currency.
+		MonthlyRevenue(context.Context, time.Time, *time.Time, Currency) ([]MonthlyCost, error)
+		// SubscriptionActivity counts active, new and cancelled subscriptions
+		// of every user month by month.
+		SubscriptionActivity(context.Context, time.Time, *time.Time) ([]MonthlyActivity, error)
+		// TopServices returns at most the given number of services with the
+		// highest spend of every user in the currency, highest first.
+		TopServices(context.Context, time.Time, *time.Time, int, Currency) ([]ServiceCost, error)
+		// MaintainMonthlyCosts repairs the stored monthly costs and moves
+		// their horizon along with the first time unless another instance
+		// is at it or finished after the second time.
+		MaintainMonthlyCosts(
+			context.Context,
+			time.Time,
+			time.Time,
+		) ([]MonthlyCostMismatch, bool, error)
+		// CheckMonthlyCosts compares the stored monthly costs against the
+		// live calculation.
+		CheckMonthlyCosts(context.Context) ([]MonthlyCostMismatch, error)
 	}
 )
+
+func NewSubscriptionCursor(sort SubscriptionSort, last Subscription) SubscriptionCursor {
+	return SubscriptionCursor{
+		Sort:      sort,
+		StartDate: last.StartDate,
+		EndDate:   last.EndDate,
+		Cost:      last.Cost,
+		Name:      last.Name,
+		ID:        last.ID,
+	}
+}
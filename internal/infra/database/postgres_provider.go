@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
 
@@ -76,7 +77,7 @@ func (p *PostgresProvider) ExecuteTx(
 	return p.acquire(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
 		tx, err := c.Begin(ctx)
 		if err != nil {
-			return err
+			return errors.Join(domain.ErrUnavailable, err)
 		}
 
 		defer func(tx pgx.Tx) {
@@ -103,7 +104,7 @@ func (p *PostgresProvider) acquire(
 	ctx = context.WithoutCancel(ctx)
 	conn, err := p.pool.Acquire(ctx)
 	if err != nil {
-		return err
+		return errors.Join(domain.ErrUnavailable, err)
 	}
 	defer conn.Release()
 
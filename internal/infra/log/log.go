@@ -7,6 +7,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type (
+	requestIDKey struct{}
+	actorKey     struct{}
+)
+
 func ErrorAttr(err error) slog.Attr {
 	return slog.String("error", err.Error())
 }
@@ -15,20 +20,50 @@ func SetRequestID(ctx *gin.Context, id string) {
 	ctx.Set("request_id", id)
 }
 
-func RequestID(ctx context.Context) slog.Attr {
-	id := "unknown"
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDValue returns the request ID stored in the context or an empty
+// string when there is none.
+func RequestIDValue(ctx context.Context) string {
+	if reqID, ok := ctx.Value(requestIDKey{}).(string); ok && reqID != "" {
+		return reqID
+	}
 
 	if ginCtx, ok := ctx.(*gin.Context); ok {
 		if reqID := ginCtx.GetString("request_id"); reqID != "" {
-			id = reqID
+			return reqID
 		}
 	}
 
 	if val := ctx.Value("request_id"); val != nil {
 		if reqID, ok := val.(string); ok && reqID != "" {
-			id = reqID
+			return reqID
 		}
 	}
 
+	return ""
+}
+
+// WithActor stores who made the request, it is recorded in the change history.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorValue returns the actor stored in the context or an empty string when
+// there is none.
+func ActorValue(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+
+	return actor
+}
+
+func RequestID(ctx context.Context) slog.Attr {
+	id := RequestIDValue(ctx)
+	if id == "" {
+		id = "unknown"
+	}
+
 	return slog.String("request_id", id)
 }
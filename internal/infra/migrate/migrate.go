@@ -0,0 +1,373 @@
+// Package migrate applies versioned schema migrations and records them in the
+// schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID is the advisory lock key that serializes migrators, so replicas
+// starting together apply every migration once.
+const lockID int64 = 0x5375627363726962 // "Subscrib"
+
+var (
+	errMigrate          = errors.New("migrate error")
+	ErrInvalidMigration = errors.Join(errMigrate, errors.New("invalid migration file"))
+	ErrUnknownVersion   = errors.Join(errMigrate, errors.New("unknown migration version"))
+	ErrChecksumMismatch = errors.Join(
+		errMigrate,
+		errors.New("applied migration was changed, checksum mismatch"),
+	)
+	ErrNoDownScript = errors.Join(errMigrate, errors.New("migration has no down script"))
+	ErrLock         = errors.Join(errMigrate, errors.New("acquire migration lock failed"))
+	ErrApply        = errors.Join(errMigrate, errors.New("apply migration failed"))
+	ErrRevert       = errors.Join(errMigrate, errors.New("revert migration failed"))
+	ErrReadApplied  = errors.Join(errMigrate, errors.New("read applied migrations failed"))
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type (
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+		// Checksum is the SHA-256 of the up script, an applied migration
+		// must not change afterwards.
+		Checksum string
+	}
+
+	// Status is a known migration and whether it is applied.
+	Status struct {
+		Migration
+		AppliedAt        *time.Time
+		ChecksumMismatch bool
+	}
+
+	Migrator struct {
+		pool       *pgxpool.Pool
+		migrations []Migration
+	}
+
+	applied struct {
+		checksum  string
+		appliedAt time.Time
+	}
+)
+
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Load reads NNN_name.up.sql and NNN_name.down.sql pairs, sorted by version.
+// Other files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Join(ErrInvalidMigration, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, errors.Join(
+				ErrInvalidMigration,
+				fmt.Errorf("%s: bad version", entry.Name()),
+			)
+		}
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Join(ErrInvalidMigration, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, errors.Join(
+				ErrInvalidMigration,
+				fmt.Errorf("version %d is used by %s and %s", version, migration.Name, match[2]),
+			)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, errors.Join(
+				ErrInvalidMigration,
+				fmt.Errorf("version %d has no up script", migration.Version),
+			)
+		}
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return int(a.Version - b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.latest())
+}
+
+// Down reverts the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(
+		ctx,
+		func(ctx context.Context, conn *pgxpool.Conn, done map[int64]applied) error {
+			versions := appliedVersions(done)
+			if len(versions) == 0 {
+				return nil
+			}
+
+			var target int64
+			if len(versions) > 1 {
+				target = versions[len(versions)-2]
+			}
+
+			return m.migrate(ctx, conn, done, target)
+		},
+	)
+}
+
+// To applies or reverts migrations until exactly the versions up to target
+// are applied, version 0 reverts everything.
+func (m *Migrator) To(ctx context.Context, target int64) error {
+	return m.locked(
+		ctx,
+		func(ctx context.Context, conn *pgxpool.Conn, done map[int64]applied) error {
+			return m.migrate(ctx, conn, done, target)
+		},
+	)
+}
+
+// Status lists the known migrations, checksums are compared but a mismatch
+// is reported rather than failing.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(ctx context.Context, _ *pgxpool.Conn, done map[int64]applied) error {
+		for _, migration := range m.migrations {
+			status := Status{Migration: migration}
+			if row, ok := done[migration.Version]; ok {
+				status.AppliedAt = &row.appliedAt
+				status.ChecksumMismatch = row.checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) migrate(
+	ctx context.Context,
+	conn *pgxpool.Conn,
+	done map[int64]applied,
+	target int64,
+) error {
+	up, down, err := plan(m.migrations, done, target)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range down {
+		if err := revert(ctx, conn, migration); err != nil {
+			return errors.Join(ErrRevert, fmt.Errorf("version %d", migration.Version), err)
+		}
+	}
+	for _, migration := range up {
+		if err := apply(ctx, conn, migration); err != nil {
+			return errors.Join(ErrApply, fmt.Errorf("version %d", migration.Version), err)
+		}
+	}
+
+	return nil
+}
+
+// plan verifies the applied migrations and returns the ones to apply in
+// version order and the ones to revert in reverse version order.
+func plan(
+	migrations []Migration,
+	done map[int64]applied,
+	target int64,
+) (up, down []Migration, err error) {
+	known := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	if _, ok := known[target]; !ok && target != 0 {
+		return nil, nil, errors.Join(ErrUnknownVersion, fmt.Errorf("version %d", target))
+	}
+	for version, row := range done {
+		migration, ok := known[version]
+		if !ok {
+			return nil, nil, errors.Join(
+				ErrUnknownVersion,
+				fmt.Errorf("version %d is applied but not known to this build", version),
+			)
+		}
+		if row.checksum != migration.Checksum {
+			return nil, nil, errors.Join(
+				ErrChecksumMismatch,
+				fmt.Errorf("version %d %s", version, migration.Name),
+			)
+		}
+	}
+
+	for _, migration := range migrations {
+		_, isApplied := done[migration.Version]
+		switch {
+		case migration.Version <= target && !isApplied:
+			up = append(up, migration)
+		case migration.Version > target && isApplied:
+			if migration.Down == "" {
+				return nil, nil, errors.Join(
+					ErrNoDownScript,
+					fmt.Errorf("version %d %s", migration.Version, migration.Name),
+				)
+			}
+			down = append(down, migration)
+		}
+	}
+	slices.Reverse(down)
+
+	return up, down, nil
+}
+
+// locked runs fn on one connection holding the advisory lock, with the
+// migrations applied so far.
+func (m *Migrator) locked(
+	ctx context.Context,
+	fn func(context.Context, *pgxpool.Conn, map[int64]applied) error,
+) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return errors.Join(ErrLock, err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `select pg_advisory_lock($1)`, lockID); err != nil {
+		return errors.Join(ErrLock, err)
+	}
+	defer func() {
+		// The lock goes away with the session if the unlock fails.
+		_, _ = conn.Exec(context.WithoutCancel(ctx), `select pg_advisory_unlock($1)`, lockID)
+	}()
+
+	const createTable = `create table if not exists schema_migrations (
+	version bigint primary key,
+	name text not null,
+	checksum text not null,
+	applied_at timestamptz not null default now()
+)`
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return errors.Join(ErrReadApplied, err)
+	}
+
+	rows, err := conn.Query(ctx, `select version, checksum, applied_at from schema_migrations`)
+	if err != nil {
+		return errors.Join(ErrReadApplied, err)
+	}
+	done := make(map[int64]applied)
+	var (
+		version int64
+		row     applied
+	)
+	_, err = pgx.ForEachRow(rows, []any{&version, &row.checksum, &row.appliedAt}, func() error {
+		done[version] = row
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrReadApplied, err)
+	}
+
+	return fn(ctx, conn, done)
+}
+
+func apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(
+			ctx,
+			`insert into schema_migrations (version, name, checksum) values ($1, $2, $3)`,
+			migration.Version,
+			migration.Name,
+			migration.Checksum,
+		)
+		return err
+	})
+}
+
+func revert(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec(
+			ctx,
+			`delete from schema_migrations where version = $1`,
+			migration.Version,
+		)
+		return err
+	})
+}
+
+func appliedVersions(done map[int64]applied) []int64 {
+	versions := make([]int64, 0, len(done))
+	for version := range done {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+
+	return versions
+}
@@ -0,0 +1,185 @@
+package migrate_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/db/migrations"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/migrate"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	loaded, err := migrate.Load(fstest.MapFS{
+		"002_second.up.sql":   {Data: []byte("create table b ();")},
+		"002_second.down.sql": {Data: []byte("drop table b;")},
+		"001_first.up.sql":    {Data: []byte("create table a ();")},
+		"README.md":           {Data: []byte("ignored")},
+	})
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+
+	require.Equal(t, int64(1), loaded[0].Version)
+	require.Equal(t, "first", loaded[0].Name)
+	require.Empty(t, loaded[0].Down)
+	require.Equal(t, int64(2), loaded[1].Version)
+	require.Equal(t, "drop table b;", loaded[1].Down)
+	require.NotEqual(t, loaded[0].Checksum, loaded[1].Checksum)
+}
+
+func TestLoadRejectsInvalidMigrations(t *testing.T) {
+	t.Parallel()
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"down without up": {"001_first.down.sql": {Data: []byte("drop table a;")}},
+		"shared version": {
+			"001_first.up.sql":  {Data: []byte("create table a ();")},
+			"001_second.up.sql": {Data: []byte("create table b ();")},
+		},
+		"zero version": {"000_first.up.sql": {Data: []byte("create table a ();")}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := migrate.Load(fsys)
+			require.ErrorIs(t, err, migrate.ErrInvalidMigration)
+		})
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	t.Parallel()
+
+	loaded, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+	for i, migration := range loaded {
+		require.Equal(t, int64(i+1), migration.Version, "versions have no gaps")
+		require.NotEmpty(t, migration.Down, migration.Name)
+	}
+}
+
+func TestMigratorIntegration(t *testing.T) {
+	pool := newPool(t)
+	ctx := t.Context()
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, migrator.Up(context.Background())) })
+
+	require.NoError(t, migrator.To(ctx, 0))
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Nil(t, status.AppliedAt)
+	}
+
+	require.NoError(t, migrator.Up(ctx))
+	require.NoError(t, migrator.Up(ctx), "up is idempotent")
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt)
+		require.False(t, status.ChecksumMismatch)
+	}
+
+	require.NoError(t, migrator.Down(ctx))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Nil(t, statuses[len(statuses)-1].AppliedAt)
+	require.NotNil(t, statuses[len(statuses)-2].AppliedAt)
+
+	require.ErrorIs(t, migrator.To(ctx, 999), migrate.ErrUnknownVersion)
+}
+
+func TestMigratorRejectsChangedMigrationIntegration(t *testing.T) {
+	pool := newPool(t)
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(t.Context()))
+
+	changed := fstest.MapFS{}
+	require.NoError(
+		t,
+		fs.WalkDir(migrations.FS, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(migrations.FS, path)
+			changed[path] = &fstest.MapFile{Data: data}
+			return err
+		}),
+	)
+	changed["001_create_subscriptions.up.sql"].Data = append(
+		changed["001_create_subscriptions.up.sql"].Data,
+		[]byte("\n-- edited after it was applied\n")...,
+	)
+
+	migrator, err = migrate.New(pool, changed)
+	require.NoError(t, err)
+	require.ErrorIs(t, migrator.Up(t.Context()), migrate.ErrChecksumMismatch)
+
+	statuses, err := migrator.Status(t.Context())
+	require.NoError(t, err)
+	require.True(t, statuses[0].ChecksumMismatch)
+}
+
+func TestMigratorReportsOverlapsIntegration(t *testing.T) {
+	pool := newPool(t)
+	ctx := t.Context()
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, migrator.Up(context.Background())) })
+	require.NoError(t, migrator.To(ctx, 2))
+
+	const insert = `insert into subscriptions
+	(id, service_name, month_cost, user_id, subs_start_date, subs_end_date)
+	values ($1, 'music', 100, $2, $3, $4)`
+	userID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	_, err = pool.Exec(ctx, insert, first, userID, "2025-01-01", "2025-06-30")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, insert, second, userID, "2025-03-01", nil)
+	require.NoError(t, err)
+
+	err = migrator.Up(ctx)
+	require.ErrorIs(t, err, migrate.ErrApply)
+	require.ErrorContains(t, err, "subscriptions overlap")
+	require.ErrorContains(t, err, second.String())
+
+	_, err = pool.Exec(
+		ctx,
+		`update subscriptions set subs_end_date = '2025-02-28' where id = $1`,
+		first,
+	)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(ctx))
+	_, err = pool.Exec(ctx, `delete from subscriptions where user_id = $1`, userID)
+	require.NoError(t, err)
+}
+
+func newPool(t *testing.T) *pgxpool.Pool {
+	const pathToEnv = "../../../.env"
+	{
+		_, err := os.Stat(pathToEnv)
+		require.NoError(t, err)
+	}
+	require.NoError(t, godotenv.Load(pathToEnv))
+
+	pool, err := pgxpool.New(context.Background(), os.Getenv("DB_CONNECTION"))
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return pool
+}
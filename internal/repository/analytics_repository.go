@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errAnalytics       = errors.New("analytics repository error")
+	ErrMonthlyRevenue  = errors.Join(errAnalytics, errors.New("monthly revenue failed"))
+	ErrMonthlyActivity = errors.Join(errAnalytics, errors.New("monthly activity failed"))
+)
+
+var _ domain.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// AnalyticsRepository runs billedCharges with a null user, so every figure is
+// billed by the same query as the per-user totals. Costs within the horizon
+// of monthly_costs are summed from the stored months instead. Sums are bigint,
+// the spend of every user may not fit an integer.
+type AnalyticsRepository struct{}
+
+func NewAnalytics() *AnalyticsRepository {
+	return &AnalyticsRepository{}
+}
+
+func (r *AnalyticsRepository) MonthlyRevenue(
+	ctx context.Context,
+	connection domain.Connection,
+	start time.Time,
+	end time.Time,
+) ([]domain.MonthlyServiceCost, error) {
+	stored, err := storedThrough(ctx, connection, end)
+	if err != nil {
+		return nil, errors.Join(ErrMonthlyRevenue, err)
+	}
+
+	query := `with ` + billedCharges + `
+select month, service_name, currency, sum(price * charges)::bigint as cost
+from charges
+where charges > 0
+group by month, service_name, currency
+order by month, service_name, currency`
+	if stored {
+		query = `select month, service_name, currency, sum(amount)::bigint as cost
+from monthly_costs
+where ($1::uuid IS NULL OR user_id = $1)
+  and ($2 = '' OR service_name = $2)
+  and month between date_trunc('month', $3::date) and date_trunc('month', $4::date)
+group by month, service_name, currency
+order by month, service_name, currency`
+	}
+	var costs []domain.MonthlyServiceCost
+	if err := connection.SelectContext(ctx, &costs, query, nil, "", start, end); err != nil {
+		return nil, errors.Join(ErrMonthlyRevenue, classify(err, domain.ErrSubscriptionNotFound))
+	}
+
+	return costs, nil
+}
+
+// MonthlyActivity joins every month of the period to the subscriptions
+// touching it, the way domain.Period.Contains counts months. The partial
+// indexes on the start and end dates of live subscriptions narrow the join.
+func (r *AnalyticsRepository) MonthlyActivity(
+	ctx context.Context,
+	connection domain.Connection,
+	start time.Time,
+	end time.Time,
+) ([]domain.MonthlyActivity, error) {
+	const query = `select
+    m.month::date as month,
+    count(s.id)::int as active,
+    count(s.id) filter (where s.subs_start_date >= m.month)::int as new,
+    count(s.id) filter (where s.subs_end_date < m.month + interval '1 month')::int as cancelled
+from generate_series(
+    date_trunc('month', $1::date),
+    date_trunc('month', $2::date),
+    interval '1 month'
+) as m(month)
+left join subscriptions s
+    on s.deleted_at IS NULL
+   and s.subs_start_date < m.month + interval '1 month'
+   and (s.subs_end_date IS NULL OR s.subs_end_date >= m.month)
+group by m.month
+order by m.month`
+	var activity []domain.MonthlyActivity
+	if err := connection.SelectContext(ctx, &activity, query, start, end); err != nil {
+		return nil, errors.Join(ErrMonthlyActivity, classify(err, domain.ErrSubscriptionNotFound))
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errBudget       = errors.New("budget repository error")
+	ErrCreateBudget = errors.Join(errBudget, errors.New("create failed"))
+	ErrUpdateBudget = errors.Join(errBudget, errors.New("update failed"))
+	ErrDeleteBudget = errors.Join(errBudget, errors.New("delete failed"))
+	ErrReadBudget   = errors.Join(errBudget, errors.New("read failed"))
+	ErrListBudgets  = errors.Join(errBudget, errors.New("list failed"))
+	ErrRenameBudget = errors.Join(errBudget, errors.New("rename service failed"))
+)
+
+var _ domain.BudgetsRepository = (*BudgetRepository)(nil)
+
+const budgetColumns = `id, user_id, period, amount_limit, currency, category, service_name`
+
+type BudgetRepository struct{}
+
+func NewBudgets() *BudgetRepository {
+	return &BudgetRepository{}
+}
+
+func (r *BudgetRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	budget domain.Budget,
+) error {
+	const query = `insert into budgets (` + budgetColumns + `)
+	values ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := connection.ExecContext(ctx, query, budget.ID, budget.UserID, budget.Period, budget.Limit, budget.Currency, budget.Category, budget.ServiceName); err != nil {
+		return errors.Join(ErrCreateBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Update(
+	ctx context.Context,
+	connection domain.Connection,
+	budget domain.Budget,
+) error {
+	const query = `update budgets
+	set period = $2, amount_limit = $3, currency = $4, category = $5, service_name = $6
+	where id = $1`
+
+	rowsAffected, err := connection.ExecContext(
+		ctx,
+		query,
+		budget.ID,
+		budget.Period,
+		budget.Limit,
+		budget.Currency,
+		budget.Category,
+		budget.ServiceName,
+	)
+	if err != nil {
+		return errors.Join(ErrUpdateBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrUpdateBudget, domain.ErrBudgetNotFound)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.BudgetID,
+) error {
+	const query = `delete from budgets where id = $1`
+
+	rowsAffected, err := connection.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Join(ErrDeleteBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrDeleteBudget, domain.ErrBudgetNotFound)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.BudgetID,
+) (domain.Budget, error) {
+	const query = `select ` + budgetColumns + ` from budgets where id = $1`
+
+	var budget domain.Budget
+	if err := connection.GetContext(ctx, &budget, query, id); err != nil {
+		return domain.Budget{}, errors.Join(ErrReadBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+) ([]domain.Budget, error) {
+	const query = `select ` + budgetColumns + ` from budgets
+	where user_id = $1
+	order by coalesce(category, '') collate "C", coalesce(service_name, '') collate "C", period, id`
+
+	var budgets []domain.Budget
+	if err := connection.SelectContext(ctx, &budgets, query, userID); err != nil {
+		return nil, errors.Join(ErrListBudgets, classify(err, domain.ErrBudgetNotFound))
+	}
+
+	return budgets, nil
+}
+
+// RenameService matches names by domain.ServiceKey in Go, as
+// ServiceRepository.Link does.
+func (r *BudgetRepository) RenameService(
+	ctx context.Context,
+	connection domain.Connection,
+	keys []string,
+	name domain.ServiceName,
+) (int, error) {
+	const (
+		namesQuery = `select distinct service_name from budgets
+	where service_name is not null and service_name <> $1`
+		renameQuery = `update budgets set service_name = $1 where service_name = any($2)`
+	)
+
+	var names []domain.ServiceName
+	if err := connection.SelectContext(ctx, &names, namesQuery, name); err != nil {
+		return 0, errors.Join(ErrRenameBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+
+	matching := make([]domain.ServiceName, 0, len(names))
+	for _, scoped := range names {
+		if slices.Contains(keys, domain.ServiceKey(scoped)) {
+			matching = append(matching, scoped)
+		}
+	}
+	if len(matching) == 0 {
+		return 0, nil
+	}
+
+	renamed, err := connection.ExecContext(ctx, renameQuery, name, matching)
+	if err != nil {
+		return 0, errors.Join(ErrRenameBudget, classify(err, domain.ErrBudgetNotFound))
+	}
+
+	return int(renamed), nil
+}
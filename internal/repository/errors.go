@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgCheckViolation       = "23514"
+	pgNotNullViolation     = "23502"
+	pgExclusionViolation   = "23P01"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+
+	noOverlapConstraint = "subscriptions_no_overlap"
+
+	pgClassDataException        = "22"
+	pgClassConnectionException  = "08"
+	pgClassInsufficientResource = "53"
+	pgClassOperatorIntervention = "57"
+)
+
+// constraintMessages describe the violations of the named constraints to
+// clients. The message of the database names tables and values, so it is only
+// logged.
+var constraintMessages = map[string]string{
+	"subscriptions_billing_period_check":          "billing period is not supported",
+	"services_billing_period_check":               "billing period is not supported",
+	"subscriptions_currency_check":                "currency must be a three-letter code",
+	"services_currency_check":                     "currency must be a three-letter code",
+	"budgets_currency_check":                      "currency must be a three-letter code",
+	"exchange_rates_base_currency_check":          "currency must be a three-letter code",
+	"exchange_rates_quote_currency_check":         "currency must be a three-letter code",
+	"exchange_rates_check":                        "base and quote currencies must differ",
+	"exchange_rates_rate_check":                   "rate must be positive",
+	"subscription_prices_month_cost_check":        "price must not be negative",
+	"services_default_price_check":                "default price must not be negative",
+	"services_name_check":                         "name must not be empty",
+	"tags_name_check":                             "name must not be empty",
+	"budgets_period_check":                        "budget period is not supported",
+	"budgets_amount_limit_check":                  "limit must be positive",
+	"budgets_check":                               "budget is scoped to a category or a service, not both",
+	"subscriptions_service_id_fkey":               "catalog service does not exist",
+	"service_aliases_service_id_fkey":             "catalog service does not exist",
+	"subscription_prices_subscription_id_fkey":    "subscription does not exist",
+	"subscription_discounts_subscription_id_fkey": "subscription does not exist",
+	"subscription_tags_subscription_id_fkey":      "subscription does not exist",
+	"subscription_tags_tag_id_fkey":               "tag does not exist",
+}
+
+// classify joins a domain error kind to a pgx error, notFound is used for
+// pgx.ErrNoRows and zero rows affected.
+func classify(err error, notFound error) error {
+	var pgErr *pgconn.PgError
+	var connectErr *pgconn.ConnectError
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return errors.Join(notFound, err)
+	case errors.As(err, &pgErr):
+		if kind := classifyPgError(pgErr); kind != nil {
+			return errors.Join(kind, err)
+		}
+	case errors.As(err, &connectErr),
+		pgconn.Timeout(err),
+		errors.Is(err, context.DeadlineExceeded):
+		return errors.Join(domain.ErrUnavailable, err)
+	}
+
+	return err
+}
+
+func classifyPgError(pgErr *pgconn.PgError) error {
+	if pgErr.Code == pgExclusionViolation && pgErr.ConstraintName == noOverlapConstraint {
+		return domain.ErrSubscriptionOverlap
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation, pgExclusionViolation:
+		return domain.ErrAlreadyExists
+	case pgSerializationFailure, pgDeadlockDetected:
+		return domain.ErrConcurrentUpdate
+	case pgForeignKeyViolation, pgCheckViolation, pgNotNullViolation:
+		logRejected(pgErr)
+		message, ok := constraintMessages[pgErr.ConstraintName]
+		if !ok {
+			message = "data violates a constraint of the stored data"
+		}
+		return domain.NewValidationError("constraint_violation", message)
+	}
+
+	switch {
+	case strings.HasPrefix(pgErr.Code, pgClassDataException):
+		logRejected(pgErr)
+		return domain.NewValidationError("invalid_data", "data is malformed or out of range")
+	case strings.HasPrefix(pgErr.Code, pgClassConnectionException),
+		strings.HasPrefix(pgErr.Code, pgClassInsufficientResource),
+		strings.HasPrefix(pgErr.Code, pgClassOperatorIntervention):
+		return domain.ErrUnavailable
+	}
+
+	return nil
+}
+
+// logRejected keeps the message of the database, which clients do not get.
+func logRejected(pgErr *pgconn.PgError) {
+	slog.Warn("Repository: database rejected data.",
+		"code", pgErr.Code,
+		"constraint", pgErr.ConstraintName,
+		"message", pgErr.Message)
+}
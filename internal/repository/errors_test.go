@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+func TestClassifyHidesTheDatabaseMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pgErr   *pgconn.PgError
+		code    string
+		message string
+	}{
+		{
+			name: "known constraint",
+			pgErr: &pgconn.PgError{
+				Code:           pgCheckViolation,
+				ConstraintName: "budgets_amount_limit_check",
+				Message:        `new row for relation "budgets" violates check constraint`,
+			},
+			code:    "constraint_violation",
+			message: "limit must be positive",
+		},
+		{
+			name: "unknown constraint",
+			pgErr: &pgconn.PgError{
+				Code:           pgForeignKeyViolation,
+				ConstraintName: "other_fkey",
+				Message:        `insert or update on table "other" violates foreign key constraint`,
+			},
+			code:    "constraint_violation",
+			message: "data violates a constraint of the stored data",
+		},
+		{
+			name: "data exception",
+			pgErr: &pgconn.PgError{
+				Code:    "22003",
+				Message: `value "99999999999" is out of range for type integer`,
+			},
+			code:    "invalid_data",
+			message: "data is malformed or out of range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := classify(tt.pgErr, domain.ErrSubscriptionNotFound)
+			problem, ok := domain.AsError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.code, problem.Code)
+			require.Equal(t, tt.message, problem.Message)
+			require.Equal(t, domain.ErrorKindValidation, problem.Kind)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errEvent          = errors.New("subscription event repository error")
+	ErrAppendEvent    = errors.Join(errEvent, errors.New("append failed"))
+	ErrListEvents     = errors.Join(errEvent, errors.New("list failed"))
+	errMissingSubject = errors.New("event query has neither a subscription nor a user")
+)
+
+var _ domain.SubscriptionEventsRepository = (*EventRepository)(nil)
+
+type EventRepository struct{}
+
+func NewEvents() *EventRepository {
+	return &EventRepository{}
+}
+
+func (r *EventRepository) Append(
+	ctx context.Context,
+	connection domain.Connection,
+	event domain.SubscriptionEvent,
+) error {
+	const query = `insert into subscription_events
+	(subscription_id, user_id, event_type, before_state, after_state, actor, request_id)
+	values
+	($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := connection.ExecContext(ctx, query, event.SubscriptionID, event.UserID, event.Type, event.Before, event.After, event.Actor, event.RequestID); err != nil {
+		return errors.Join(ErrAppendEvent, classify(err, domain.ErrSubscriptionNotFound))
+	}
+
+	return nil
+}
+
+func (r *EventRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	eventQuery domain.EventQuery,
+) ([]domain.SubscriptionEvent, error) {
+	var builder queryBuilder
+	switch {
+	case eventQuery.SubscriptionID != nil:
+		builder.where("subscription_id = %s", *eventQuery.SubscriptionID)
+	case eventQuery.UserID != nil:
+		builder.where("user_id = %s", *eventQuery.UserID)
+	default:
+		return nil, errors.Join(ErrListEvents, errMissingSubject)
+	}
+	if eventQuery.Before != nil {
+		builder.where("id < %s", *eventQuery.Before)
+	}
+	limit := builder.arg(eventQuery.Limit)
+
+	query := `select id, subscription_id, user_id, event_type, before_state, after_state, actor, request_id, occurred_at
+	from subscription_events` + builder.whereClause() + " order by id desc limit " + limit
+
+	var events []domain.SubscriptionEvent
+	if err := connection.SelectContext(ctx, &events, query, builder.args...); err != nil {
+		return nil, errors.Join(ErrListEvents, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	return events, nil
+}
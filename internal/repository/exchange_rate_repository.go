@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errExchangeRate      = errors.New("exchange rate repository error")
+	ErrSaveExchangeRates = errors.Join(errExchangeRate, errors.New("save failed"))
+	ErrListExchangeRates = errors.Join(errExchangeRate, errors.New("list failed"))
+)
+
+var _ domain.ExchangeRatesRepository = (*ExchangeRateRepository)(nil)
+
+type ExchangeRateRepository struct{}
+
+func NewExchangeRates() *ExchangeRateRepository {
+	return &ExchangeRateRepository{}
+}
+
+func (r *ExchangeRateRepository) Save(
+	ctx context.Context,
+	connection domain.Connection,
+	rates []domain.ExchangeRate,
+) error {
+	const query = `insert into exchange_rates (base_currency, quote_currency, effective_from, rate)
+	values ($1, $2, date_trunc('month', $3::date), $4)
+	on conflict (base_currency, quote_currency, effective_from) do update set rate = excluded.rate`
+
+	for _, rate := range rates {
+		if _, err := connection.ExecContext(ctx, query, rate.Base, rate.Quote, rate.EffectiveFrom, rate.Rate); err != nil {
+			return errors.Join(ErrSaveExchangeRates, classify(err, domain.ErrExchangeRateMissing))
+		}
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	currency domain.Currency,
+	until time.Time,
+) ([]domain.ExchangeRate, error) {
+	const query = `select base_currency, quote_currency, effective_from, rate::float8 as rate
+	from exchange_rates
+	where (base_currency = $1 or quote_currency = $1) and effective_from <= $2
+	order by effective_from, base_currency, quote_currency`
+
+	var rates []domain.ExchangeRate
+	if err := connection.SelectContext(ctx, &rates, query, currency, until); err != nil {
+		return nil, errors.Join(ErrListExchangeRates, classify(err, domain.ErrExchangeRateMissing))
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errAnalytics       = errors.New("memory analytics repository error")
+	ErrMonthlyRevenue  = errors.Join(errAnalytics, errors.New("monthly revenue failed"))
+	ErrMonthlyActivity = errors.Join(errAnalytics, errors.New("monthly activity failed"))
+)
+
+var _ domain.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// AnalyticsRepository mirrors repository.AnalyticsRepository on top of a
+// Provider, costs come from the domain billing engine.
+type AnalyticsRepository struct{}
+
+func NewAnalytics() *AnalyticsRepository {
+	return &AnalyticsRepository{}
+}
+
+func (r *AnalyticsRepository) MonthlyRevenue(
+	ctx context.Context,
+	connection domain.Connection,
+	start time.Time,
+	end time.Time,
+) ([]domain.MonthlyServiceCost, error) {
+	subscriptions, err := allBilled(connection)
+	if err != nil {
+		return nil, errors.Join(ErrMonthlyRevenue, err)
+	}
+
+	spend := make(map[domain.MonthlyServiceCost]int)
+	for _, subscription := range subscriptions {
+		for _, charge := range subscription.Charges(domain.NewPeriod(start, &end)) {
+			key := domain.MonthlyServiceCost{
+				Month:    charge.Month,
+				Name:     charge.Name,
+				Currency: charge.Currency,
+			}
+			spend[key] += charge.Amount
+		}
+	}
+
+	costs := make([]domain.MonthlyServiceCost, 0, len(spend))
+	for cost, amount := range spend {
+		cost.Cost = amount
+		costs = append(costs, cost)
+	}
+	slices.SortFunc(costs, func(a, b domain.MonthlyServiceCost) int {
+		return cmp.Or(
+			a.Month.Compare(b.Month),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Currency, b.Currency),
+		)
+	})
+
+	return costs, nil
+}
+
+func (r *AnalyticsRepository) MonthlyActivity(
+	ctx context.Context,
+	connection domain.Connection,
+	start time.Time,
+	end time.Time,
+) ([]domain.MonthlyActivity, error) {
+	subscriptions, err := allBilled(connection)
+	if err != nil {
+		return nil, errors.Join(ErrMonthlyActivity, err)
+	}
+
+	months := domain.NewPeriod(start, &end).Months()
+	activity := make([]domain.MonthlyActivity, 0, len(months))
+	for _, month := range months {
+		counts := domain.MonthlyActivity{Month: month}
+		for _, subscription := range subscriptions {
+			if !activeIn(subscription, month) {
+				continue
+			}
+			counts.Active++
+			if domain.MonthStart(subscription.StartDate).Equal(month) {
+				counts.New++
+			}
+			if subscription.EndDate != nil &&
+				domain.MonthStart(*subscription.EndDate).Equal(month) {
+				counts.Cancelled++
+			}
+		}
+		activity = append(activity, counts)
+	}
+
+	return activity, nil
+}
+
+// allBilled returns the live subscriptions of every user with their price
+// changes.
+func allBilled(connection domain.Connection) ([]domain.Subscription, error) {
+	var subscriptions []domain.Subscription
+	err := read(connection, func(state *state) error {
+		for _, subscription := range state.subscriptions {
+			if subscription.DeletedAt != nil {
+				continue
+			}
+			subscriptions = append(subscriptions, state.withPrices(subscription))
+		}
+		return nil
+	})
+
+	return subscriptions, err
+}
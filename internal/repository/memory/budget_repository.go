@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+)
+
+var (
+	errBudget       = errors.New("memory budget repository error")
+	ErrCreateBudget = errors.Join(errBudget, errors.New("create failed"))
+	ErrUpdateBudget = errors.Join(errBudget, errors.New("update failed"))
+	ErrDeleteBudget = errors.Join(errBudget, errors.New("delete failed"))
+	ErrReadBudget   = errors.Join(errBudget, errors.New("read failed"))
+	ErrListBudgets  = errors.Join(errBudget, errors.New("list failed"))
+	ErrRenameBudget = errors.Join(errBudget, errors.New("rename service failed"))
+)
+
+var _ domain.BudgetsRepository = (*BudgetRepository)(nil)
+
+// BudgetRepository mirrors repository.BudgetRepository on top of a Provider.
+type BudgetRepository struct{}
+
+func NewBudgets() *BudgetRepository {
+	return &BudgetRepository{}
+}
+
+func (r *BudgetRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	budget domain.Budget,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.budgets[budget.ID]; ok {
+			return domain.ErrAlreadyExists
+		}
+		state.budgets[budget.ID] = detachedBudget(budget)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrCreateBudget, err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Update(
+	ctx context.Context,
+	connection domain.Connection,
+	budget domain.Budget,
+) error {
+	err := write(connection, func(state *state) error {
+		current, ok := state.budgets[budget.ID]
+		if !ok {
+			return domain.ErrBudgetNotFound
+		}
+		budget.UserID = current.UserID
+		state.budgets[budget.ID] = detachedBudget(budget)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrUpdateBudget, err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.BudgetID,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.budgets[id]; !ok {
+			return domain.ErrBudgetNotFound
+		}
+		delete(state.budgets, id)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrDeleteBudget, err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.BudgetID,
+) (domain.Budget, error) {
+	var budget domain.Budget
+	err := read(connection, func(state *state) error {
+		var ok bool
+		budget, ok = state.budgets[id]
+		if !ok {
+			return domain.ErrBudgetNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Budget{}, errors.Join(ErrReadBudget, err)
+	}
+
+	return detachedBudget(budget), nil
+}
+
+func (r *BudgetRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+) ([]domain.Budget, error) {
+	var budgets []domain.Budget
+	err := read(connection, func(state *state) error {
+		for _, budget := range state.budgets {
+			if budget.UserID == userID {
+				budgets = append(budgets, detachedBudget(budget))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListBudgets, err)
+	}
+
+	slices.SortFunc(budgets, func(a, b domain.Budget) int {
+		return cmp.Or(
+			cmp.Compare(orEmpty(a.Category), orEmpty(b.Category)),
+			cmp.Compare(orEmpty(a.ServiceName), orEmpty(b.ServiceName)),
+			cmp.Compare(a.Period, b.Period),
+			slices.Compare(a.ID[:], b.ID[:]),
+		)
+	})
+
+	return budgets, nil
+}
+
+func (r *BudgetRepository) RenameService(
+	ctx context.Context,
+	connection domain.Connection,
+	keys []string,
+	name domain.ServiceName,
+) (int, error) {
+	var renamed int
+	err := write(connection, func(state *state) error {
+		for id, budget := range state.budgets {
+			if budget.ServiceName == nil || *budget.ServiceName == name ||
+				!slices.Contains(keys, domain.ServiceKey(*budget.ServiceName)) {
+				continue
+			}
+			budget.ServiceName = pointer.Ref(name)
+			state.budgets[id] = budget
+			renamed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(ErrRenameBudget, err)
+	}
+
+	return renamed, nil
+}
+
+// detachedBudget copies the scope of the budget, so neither the caller nor
+// the state see writes through the other's pointers.
+func detachedBudget(budget domain.Budget) domain.Budget {
+	if budget.Category != nil {
+		budget.Category = pointer.Ref(*budget.Category)
+	}
+	if budget.ServiceName != nil {
+		budget.ServiceName = pointer.Ref(*budget.ServiceName)
+	}
+
+	return budget
+}
+
+func orEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
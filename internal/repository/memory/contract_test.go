@@ -0,0 +1,62 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/memory"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/repositorytest"
+)
+
+// newBackend is the repositorytest.Factory of the memory backend.
+func newBackend(*testing.T) (domain.ConnectionProvider, domain.Repositories) {
+	return memory.NewProvider(), memory.NewRepositories()
+}
+
+func TestSubscriptionRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.Run(t, newBackend)
+}
+
+func TestEventRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunEvents(t, newBackend)
+}
+
+func TestExchangeRateRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunExchangeRates(t, newBackend)
+}
+
+func TestServiceRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunServices(t, newBackend)
+}
+
+func TestTagRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunTags(t, newBackend)
+}
+
+func TestBudgetRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunBudgets(t, newBackend)
+}
+
+func TestAnalyticsRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunAnalytics(t, newBackend)
+}
+
+func TestMonthlyCostRepositoryContract(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.RunMonthlyCosts(t, newBackend)
+}
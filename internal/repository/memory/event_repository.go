@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errEvent       = errors.New("memory subscription event repository error")
+	ErrAppendEvent = errors.Join(errEvent, errors.New("append failed"))
+	ErrListEvents  = errors.Join(errEvent, errors.New("list failed"))
+)
+
+var _ domain.SubscriptionEventsRepository = (*EventRepository)(nil)
+
+// EventRepository mirrors repository.EventRepository on top of a Provider.
+type EventRepository struct{}
+
+func NewEvents() *EventRepository {
+	return &EventRepository{}
+}
+
+func (r *EventRepository) Append(
+	ctx context.Context,
+	connection domain.Connection,
+	event domain.SubscriptionEvent,
+) error {
+	err := write(connection, func(state *state) error {
+		event.ID = state.nextEventID
+		event.OccurredAt = time.Now()
+		event.Before = detachedEventState(event.Before)
+		event.After = detachedEventState(event.After)
+
+		state.nextEventID++
+		state.events = append(state.events, event)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrAppendEvent, err)
+	}
+
+	return nil
+}
+
+func (r *EventRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	query domain.EventQuery,
+) ([]domain.SubscriptionEvent, error) {
+	var events []domain.SubscriptionEvent
+	err := read(connection, func(state *state) error {
+		for i := len(state.events) - 1; i >= 0 && len(events) < query.Limit; i-- {
+			event := state.events[i]
+			if query.Before != nil && event.ID >= *query.Before {
+				continue
+			}
+			if query.SubscriptionID != nil && event.SubscriptionID != *query.SubscriptionID ||
+				query.UserID != nil && event.UserID != *query.UserID {
+				continue
+			}
+
+			event.Before = detachedEventState(event.Before)
+			event.After = detachedEventState(event.After)
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListEvents, err)
+	}
+
+	return events, nil
+}
+
+func detachedEventState(subscription *domain.Subscription) *domain.Subscription {
+	if subscription == nil {
+		return nil
+	}
+
+	copied := detached(*subscription)
+
+	return &copied
+}
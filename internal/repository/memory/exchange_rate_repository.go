@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errExchangeRate      = errors.New("memory exchange rate repository error")
+	ErrSaveExchangeRates = errors.Join(errExchangeRate, errors.New("save failed"))
+	ErrListExchangeRates = errors.Join(errExchangeRate, errors.New("list failed"))
+)
+
+var _ domain.ExchangeRatesRepository = (*ExchangeRateRepository)(nil)
+
+// ExchangeRateRepository mirrors repository.ExchangeRateRepository on top of
+// a Provider.
+type ExchangeRateRepository struct{}
+
+func NewExchangeRates() *ExchangeRateRepository {
+	return &ExchangeRateRepository{}
+}
+
+func (r *ExchangeRateRepository) Save(
+	ctx context.Context,
+	connection domain.Connection,
+	rates []domain.ExchangeRate,
+) error {
+	err := write(connection, func(state *state) error {
+		saved := slices.Clone(state.rates)
+		for _, rate := range rates {
+			rate.EffectiveFrom = domain.MonthStart(rate.EffectiveFrom)
+			saved = slices.DeleteFunc(saved, func(stored domain.ExchangeRate) bool {
+				return stored.Base == rate.Base &&
+					stored.Quote == rate.Quote &&
+					stored.EffectiveFrom.Equal(rate.EffectiveFrom)
+			})
+			saved = append(saved, rate)
+		}
+		slices.SortFunc(saved, func(a, b domain.ExchangeRate) int {
+			return cmp.Or(
+				a.EffectiveFrom.Compare(b.EffectiveFrom),
+				cmp.Compare(a.Base, b.Base),
+				cmp.Compare(a.Quote, b.Quote),
+			)
+		})
+		state.rates = saved
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrSaveExchangeRates, err)
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	currency domain.Currency,
+	until time.Time,
+) ([]domain.ExchangeRate, error) {
+	var rates []domain.ExchangeRate
+	err := read(connection, func(state *state) error {
+		for _, rate := range state.rates {
+			if rate.EffectiveFrom.After(until) {
+				break
+			}
+			if rate.Base == currency || rate.Quote == currency {
+				rates = append(rates, rate)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListExchangeRates, err)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errMonthlyCosts          = errors.New("memory monthly cost repository error")
+	ErrClaimMonthlyCosts     = errors.Join(errMonthlyCosts, errors.New("claim failed"))
+	ErrMaintainMonthlyCosts  = errors.Join(errMonthlyCosts, errors.New("maintain failed"))
+	ErrMonthlyCostMismatches = errors.Join(errMonthlyCosts, errors.New("mismatches failed"))
+)
+
+var _ domain.MonthlyCostsRepository = (*MonthlyCostRepository)(nil)
+
+// MonthlyCostRepository mirrors repository.MonthlyCostRepository on top of a
+// Provider. The billing engine serves every cost read here, so the stored
+// months only back the consistency check.
+type MonthlyCostRepository struct{}
+
+type monthlyCostKey struct {
+	userID   domain.UserID
+	name     domain.ServiceName
+	month    time.Time
+	currency domain.Currency
+}
+
+func NewMonthlyCosts() *MonthlyCostRepository {
+	return &MonthlyCostRepository{}
+}
+
+// costChange is a range of months of a user whose stored costs a write may
+// have changed, the range of a subscription before or after the write.
+type costChange struct {
+	userID domain.UserID
+	period domain.Period
+}
+
+func subscriptionChange(subscription domain.Subscription) costChange {
+	return costChange{userID: subscription.UserID, period: subscription.Period()}
+}
+
+// Claim only skips a maintenance that finished after since, transactions of
+// a Provider never run side by side.
+func (r *MonthlyCostRepository) Claim(
+	ctx context.Context,
+	connection domain.Connection,
+	since time.Time,
+) (bool, error) {
+	var claimed bool
+	err := read(connection, func(state *state) error {
+		claimed = !state.costsMaintainedAt.After(since)
+
+		return nil
+	})
+	if err != nil {
+		return false, errors.Join(ErrClaimMonthlyCosts, err)
+	}
+
+	return claimed, nil
+}
+
+func (r *MonthlyCostRepository) Maintain(
+	ctx context.Context,
+	connection domain.Connection,
+	mismatches []domain.MonthlyCostMismatch,
+	horizon time.Time,
+	at time.Time,
+) error {
+	err := write(connection, func(state *state) error {
+		for _, mismatch := range mismatches {
+			state.refreshMonthlyCosts(costChange{
+				userID: mismatch.UserID,
+				period: domain.NewPeriod(mismatch.Month, &mismatch.Month),
+			})
+		}
+
+		horizon = domain.MonthStart(horizon)
+		switch {
+		case state.costsHorizon.IsZero():
+			state.monthlyCosts = state.liveMonthlyCosts(
+				nil,
+				domain.NewPeriod(time.Time{}, &horizon),
+			)
+		case horizon.After(state.costsHorizon):
+			maps.Copy(state.monthlyCosts, state.liveMonthlyCosts(
+				nil,
+				domain.NewPeriod(state.costsHorizon.AddDate(0, 1, 0), &horizon),
+			))
+		default:
+			horizon = state.costsHorizon
+		}
+		state.costsHorizon = horizon
+		state.costsMaintainedAt = at
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrMaintainMonthlyCosts, err)
+	}
+
+	return nil
+}
+
+func (r *MonthlyCostRepository) Mismatches(
+	ctx context.Context,
+	connection domain.Connection,
+) ([]domain.MonthlyCostMismatch, error) {
+	var mismatches []domain.MonthlyCostMismatch
+	err := read(connection, func(state *state) error {
+		if state.costsHorizon.IsZero() {
+			return nil
+		}
+
+		live := state.liveMonthlyCosts(nil, domain.NewPeriod(time.Time{}, &state.costsHorizon))
+		keys := maps.Clone(live)
+		maps.Copy(keys, state.monthlyCosts)
+		for key := range keys {
+			liveCost, inLive := live[key]
+			storedCost, inStored := state.monthlyCosts[key]
+			if inLive == inStored && liveCost == storedCost {
+				continue
+			}
+			mismatches = append(mismatches, domain.MonthlyCostMismatch{
+				UserID:   key.userID,
+				Name:     key.name,
+				Month:    key.month,
+				Currency: key.currency,
+				Stored:   storedCost,
+				Live:     liveCost,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrMonthlyCostMismatches, err)
+	}
+
+	slices.SortFunc(mismatches, func(a, b domain.MonthlyCostMismatch) int {
+		return cmp.Or(
+			bytes.Compare(a.UserID[:], b.UserID[:]),
+			cmp.Compare(a.Name, b.Name),
+			a.Month.Compare(b.Month),
+			cmp.Compare(a.Currency, b.Currency),
+		)
+	})
+
+	return mismatches, nil
+}
+
+// refreshMonthlyCosts recalculates the stored months of the changes after a
+// write, nothing is stored before the first maintenance.
+func (s *state) refreshMonthlyCosts(changes ...costChange) {
+	if s.costsHorizon.IsZero() {
+		return
+	}
+
+	stored := domain.NewPeriod(time.Time{}, &s.costsHorizon)
+	for _, change := range changes {
+		period, ok := change.period.Intersect(stored)
+		if !ok {
+			continue
+		}
+		maps.DeleteFunc(s.monthlyCosts, func(key monthlyCostKey, _ int) bool {
+			return key.userID == change.userID && period.Contains(key.month)
+		})
+		maps.Copy(s.monthlyCosts, s.liveMonthlyCosts([]domain.UserID{change.userID}, period))
+	}
+}
+
+// liveMonthlyCosts bills the live subscriptions of the users, of every user
+// when userIDs is nil, within the period the way the cost reads do.
+func (s *state) liveMonthlyCosts(
+	userIDs []domain.UserID,
+	period domain.Period,
+) map[monthlyCostKey]int {
+	costs := make(map[monthlyCostKey]int)
+	for _, subscription := range s.subscriptions {
+		if subscription.DeletedAt != nil ||
+			userIDs != nil && !slices.Contains(userIDs, subscription.UserID) {
+			continue
+		}
+
+		subscription.Prices = s.prices[subscription.ID]
+		for _, charge := range subscription.Charges(period) {
+			key := monthlyCostKey{
+				userID:   subscription.UserID,
+				name:     charge.Name,
+				month:    charge.Month,
+				currency: charge.Currency,
+			}
+			costs[key] += charge.Amount
+		}
+	}
+
+	return costs
+}
@@ -0,0 +1,205 @@
+// Package memory keeps subscriptions in process memory. It backs the service
+// when DB_CONNECTION is memory:// and needs no running database.
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// Scheme selects the in-memory backend in DB_CONNECTION.
+const Scheme = "memory://"
+
+var (
+	_ domain.ConnectionProvider = (*Provider)(nil)
+	_ domain.Connection         = (*Connection)(nil)
+)
+
+var (
+	errMemory = errors.New("memory storage error")
+	// ErrNoSQL is returned by the Connection query methods, the in-memory
+	// backend only works with the repositories of this package.
+	ErrNoSQL = errors.Join(errMemory, errors.New("sql is not supported"))
+	// ErrForeignConnection is returned by the repositories of this package when
+	// they are handed a connection of another provider.
+	ErrForeignConnection = errors.Join(
+		errMemory,
+		errors.New("connection does not belong to a memory provider"),
+	)
+)
+
+type (
+	// Provider hands out connections to a single in-memory store.
+	// Transactions are serialized and work on a snapshot, which replaces the
+	// store when the receiver succeeds and is dropped when it fails.
+	Provider struct {
+		// txMu serializes transactions and standalone writes, so a commit
+		// never overwrites a write made after its snapshot was taken.
+		txMu  sync.Mutex
+		mu    sync.RWMutex
+		state *state
+	}
+
+	// Connection is either bound to a transaction snapshot or, outside of
+	// transactions, applies every repository call to the store on its own.
+	Connection struct {
+		provider *Provider
+		tx       *state
+	}
+
+	state struct {
+		subscriptions map[domain.SubscriptionID]domain.Subscription
+		// prices holds the price changes of each subscription in effective
+		// order. The slices are shared with snapshots, so they are replaced
+		// rather than changed in place.
+		prices map[domain.SubscriptionID][]domain.PriceChange
+		// events is the append-only history in ID order.
+		events      []domain.SubscriptionEvent
+		nextEventID int64
+		// rates holds the exchange rates in effective order, it is replaced
+		// rather than changed in place like prices.
+		rates []domain.ExchangeRate
+		// services is the catalog, alias slices are replaced rather than
+		// changed in place.
+		services map[domain.ServiceID]domain.Service
+		// tags holds the tags of every user, the subscriptions keep the names
+		// of theirs and are renamed along with them.
+		tags map[domain.TagID]domain.Tag
+		// budgets holds the budgets of every user, scope pointers are never
+		// written through.
+		budgets map[domain.BudgetID]domain.Budget
+		// monthlyCosts holds the stored monthly costs up to costsHorizon, it
+		// is empty and costsHorizon zero before the first maintenance, which
+		// finished last at costsMaintainedAt.
+		monthlyCosts      map[monthlyCostKey]int
+		costsHorizon      time.Time
+		costsMaintainedAt time.Time
+	}
+)
+
+func NewProvider() *Provider {
+	return &Provider{state: newState()}
+}
+
+func newState() *state {
+	return &state{
+		subscriptions: make(map[domain.SubscriptionID]domain.Subscription),
+		prices:        make(map[domain.SubscriptionID][]domain.PriceChange),
+		nextEventID:   1,
+		services:      make(map[domain.ServiceID]domain.Service),
+		tags:          make(map[domain.TagID]domain.Tag),
+		budgets:       make(map[domain.BudgetID]domain.Budget),
+		monthlyCosts:  make(map[monthlyCostKey]int),
+	}
+}
+
+func (s *state) clone() *state {
+	return &state{
+		subscriptions:     maps.Clone(s.subscriptions),
+		prices:            maps.Clone(s.prices),
+		events:            slices.Clone(s.events),
+		nextEventID:       s.nextEventID,
+		rates:             s.rates,
+		services:          maps.Clone(s.services),
+		tags:              maps.Clone(s.tags),
+		budgets:           maps.Clone(s.budgets),
+		monthlyCosts:      maps.Clone(s.monthlyCosts),
+		costsHorizon:      s.costsHorizon,
+		costsMaintainedAt: s.costsMaintainedAt,
+	}
+}
+
+func (p *Provider) Execute(
+	ctx context.Context,
+	receiver func(context.Context, domain.Connection) error,
+) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Join(domain.ErrUnavailable, err)
+	}
+
+	return receiver(ctx, &Connection{provider: p})
+}
+
+func (p *Provider) ExecuteTx(
+	ctx context.Context,
+	receiver func(context.Context, domain.Connection) error,
+) error {
+	p.txMu.Lock()
+	defer p.txMu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return errors.Join(domain.ErrUnavailable, err)
+	}
+
+	p.mu.RLock()
+	snapshot := p.state.clone()
+	p.mu.RUnlock()
+
+	if err := receiver(ctx, &Connection{provider: p, tx: snapshot}); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.state = snapshot
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *Provider) Close() error {
+	return nil
+}
+
+func (c *Connection) GetContext(context.Context, any, string, ...any) error {
+	return ErrNoSQL
+}
+
+func (c *Connection) SelectContext(context.Context, any, string, ...any) error {
+	return ErrNoSQL
+}
+
+func (c *Connection) ExecContext(context.Context, string, ...any) (int64, error) {
+	return 0, ErrNoSQL
+}
+
+// read runs fn against the state visible to the connection.
+func (c *Connection) read(fn func(*state) error) error {
+	if c.tx != nil {
+		return fn(c.tx)
+	}
+
+	c.provider.mu.RLock()
+	defer c.provider.mu.RUnlock()
+
+	return fn(c.provider.state)
+}
+
+// write runs fn against the state visible to the connection, fn must not
+// change anything when it fails.
+func (c *Connection) write(fn func(*state) error) error {
+	if c.tx != nil {
+		return fn(c.tx)
+	}
+
+	c.provider.txMu.Lock()
+	defer c.provider.txMu.Unlock()
+	c.provider.mu.Lock()
+	defer c.provider.mu.Unlock()
+
+	return fn(c.provider.state)
+}
+
+func connectionOf(connection domain.Connection) (*Connection, error) {
+	memoryConnection, ok := connection.(*Connection)
+	if !ok || memoryConnection.provider == nil {
+		return nil, ErrForeignConnection
+	}
+
+	return memoryConnection, nil
+}
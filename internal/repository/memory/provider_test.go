@@ -0,0 +1,149 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/memory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubscription(name domain.ServiceName) domain.Subscription {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	return domain.Subscription{
+		ID:            uuid.New(),
+		Name:          name,
+		Cost:          100,
+		UserID:        uuid.New(),
+		StartDate:     start,
+		BillingPeriod: domain.BillingMonthly,
+		BillingAnchor: start,
+		Currency:      domain.DefaultCurrency,
+	}
+}
+
+func TestExecuteTxCommitsOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	provider := memory.NewProvider()
+	repo := memory.NewSubscription()
+	subscription := newSubscription("music")
+
+	err := provider.ExecuteTx(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		require.NoError(t, repo.Create(ctx, c, subscription))
+
+		// Not visible outside the transaction before it commits.
+		return provider.Execute(ctx, func(ctx context.Context, outside domain.Connection) error {
+			_, err := repo.Read(ctx, outside, subscription.ID)
+			require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+			return nil
+		})
+	})
+	require.NoError(t, err)
+
+	err = provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		stored, err := repo.Read(ctx, c, subscription.ID)
+		require.NoError(t, err)
+		require.Equal(t, subscription, stored)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestExecuteTxDiscardsOnError(t *testing.T) {
+	t.Parallel()
+
+	provider := memory.NewProvider()
+	repo := memory.NewSubscription()
+	subscription := newSubscription("music")
+	errAbort := errors.New("abort")
+
+	err := provider.ExecuteTx(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		require.NoError(t, repo.Create(ctx, c, subscription))
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	err = provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		_, err := repo.Read(ctx, c, subscription.ID)
+		require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestConcurrentTransactionsDoNotLoseWrites(t *testing.T) {
+	t.Parallel()
+
+	provider := memory.NewProvider()
+	repositories := memory.NewRepositories()
+	repo := repositories.Subscriptions
+	service := domain.NewSubscriptionService(provider, repositories)
+	userID := uuid.New()
+
+	const writers = 32
+	var wg sync.WaitGroup
+	created := make(chan error, 2*writers)
+	for range writers {
+		wg.Go(func() {
+			// Half of the writers go through the service and race on the same
+			// service, the rest get a service of their own.
+			subscription := newSubscription("shared")
+			subscription.UserID = userID
+			created <- service.Create(t.Context(), subscription)
+
+			own := newSubscription(domain.ServiceName(uuid.NewString()))
+			own.UserID = userID
+			created <- provider.Execute(
+				t.Context(),
+				func(ctx context.Context, c domain.Connection) error {
+					return repo.Create(ctx, c, own)
+				},
+			)
+		})
+	}
+	wg.Wait()
+	close(created)
+
+	var succeeded int
+	for err := range created {
+		if err != nil {
+			require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+			continue
+		}
+		succeeded++
+	}
+	require.Equal(t, writers+1, succeeded)
+
+	page, err := service.ReadAll(
+		t.Context(),
+		domain.SubscriptionFilter{UserID: userID},
+		domain.Pagination{Limit: 100, IncludeTotal: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, writers+1, *page.Total)
+}
+
+func TestRepositoryRejectsForeignConnection(t *testing.T) {
+	t.Parallel()
+
+	err := database.NewDummyProvider(nil).Execute(
+		t.Context(),
+		func(ctx context.Context, c domain.Connection) error {
+			return memory.NewSubscription().Create(ctx, c, newSubscription("music"))
+		},
+	)
+	require.ErrorIs(t, err, memory.ErrForeignConnection)
+}
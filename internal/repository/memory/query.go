@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// openEndDate stands in for a missing end date when sorting, open-ended
+// subscriptions sort after every finite one.
+var openEndDate = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// matches applies the filter the way the SQL query builder does, now decides
+// the status.
+func matches(
+	filter domain.SubscriptionFilter,
+	subscription domain.Subscription,
+	now time.Time,
+) bool {
+	if subscription.UserID != filter.UserID {
+		return false
+	}
+	if subscription.DeletedAt != nil && !filter.IncludeDeleted {
+		return false
+	}
+	if filter.ServiceName != nil && subscription.Name != *filter.ServiceName {
+		return false
+	}
+	if filter.ServiceNamePrefix != nil &&
+		!strings.HasPrefix(subscription.Name, *filter.ServiceNamePrefix) {
+		return false
+	}
+	if filter.Category != nil && subscription.Category != *filter.Category {
+		return false
+	}
+	if filter.Tag != nil && !slices.ContainsFunc(subscription.Tags, func(name string) bool {
+		return domain.TagKey(name) == domain.TagKey(*filter.Tag)
+	}) {
+		return false
+	}
+	if filter.ActiveAt != nil && !activeIn(subscription, domain.MonthStart(*filter.ActiveAt)) {
+		return false
+	}
+	if filter.Status != nil && status(subscription, now) != *filter.Status {
+		return false
+	}
+	if filter.MinPrice != nil && subscription.Cost < *filter.MinPrice {
+		return false
+	}
+	if filter.MaxPrice != nil && subscription.Cost > *filter.MaxPrice {
+		return false
+	}
+	if filter.StartFrom != nil &&
+		subscription.StartDate.Before(domain.MonthStart(*filter.StartFrom)) {
+		return false
+	}
+	if filter.StartTo != nil &&
+		!subscription.StartDate.Before(domain.MonthStart(*filter.StartTo).AddDate(0, 1, 0)) {
+		return false
+	}
+
+	return true
+}
+
+// activeIn reports whether the subscription touches the month.
+func activeIn(subscription domain.Subscription, month time.Time) bool {
+	return subscription.StartDate.Before(month.AddDate(0, 1, 0)) &&
+		(subscription.EndDate == nil || !subscription.EndDate.Before(month))
+}
+
+func status(subscription domain.Subscription, now time.Time) domain.SubscriptionStatus {
+	month := domain.MonthStart(now)
+	switch {
+	case activeIn(subscription, month):
+		return domain.SubscriptionStatusActive
+	case subscription.EndDate != nil && subscription.EndDate.Before(month):
+		return domain.SubscriptionStatusEnded
+	default:
+		return domain.SubscriptionStatusFuture
+	}
+}
+
+// paginate sorts the subscriptions, seeks past the cursor and cuts the page.
+func paginate(
+	subscriptions []domain.Subscription,
+	pagination domain.Pagination,
+) []domain.Subscription {
+	sort := pagination.Sort
+	if _, ok := sortKeys[sort.Field]; !ok {
+		sort = domain.DefaultSubscriptionSort
+	}
+
+	slices.SortFunc(subscriptions, func(a, b domain.Subscription) int {
+		return compareSubscriptions(sort, a, b)
+	})
+
+	if cursor := pagination.After; cursor != nil {
+		last := domain.Subscription{
+			ID:        cursor.ID,
+			Name:      cursor.Name,
+			Cost:      cursor.Cost,
+			StartDate: cursor.StartDate,
+			EndDate:   cursor.EndDate,
+		}
+		subscriptions = slices.DeleteFunc(subscriptions, func(s domain.Subscription) bool {
+			return compareSubscriptions(sort, s, last) <= 0
+		})
+	}
+
+	start := min(pagination.Offset, len(subscriptions))
+	end := min(start+pagination.Limit, len(subscriptions))
+
+	return subscriptions[start:end]
+}
+
+// sortKeys whitelists the sortable fields, as the SQL query builder does.
+var sortKeys = map[domain.SubscriptionSortField]func(a, b domain.Subscription) int{
+	domain.SortByStartDate: func(a, b domain.Subscription) int {
+		return a.StartDate.Compare(b.StartDate)
+	},
+	domain.SortByEndDate: func(a, b domain.Subscription) int {
+		return endOrOpen(a).Compare(endOrOpen(b))
+	},
+	domain.SortByPrice: func(a, b domain.Subscription) int {
+		return cmp.Compare(a.Cost, b.Cost)
+	},
+	domain.SortByServiceName: func(a, b domain.Subscription) int {
+		return strings.Compare(a.Name, b.Name)
+	},
+}
+
+// compareSubscriptions orders by the sort field, the id breaks ties.
+func compareSubscriptions(sort domain.SubscriptionSort, a, b domain.Subscription) int {
+	order := sortKeys[sort.Field](a, b)
+	if order == 0 {
+		order = bytes.Compare(a.ID[:], b.ID[:])
+	}
+	if sort.Descending {
+		order = -order
+	}
+
+	return order
+}
+
+func endOrOpen(subscription domain.Subscription) time.Time {
+	if subscription.EndDate == nil {
+		return openEndDate
+	}
+
+	return *subscription.EndDate
+}
@@ -0,0 +1,18 @@
+package memory
+
+import "github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+// NewRepositories returns every in-memory repository, for a provider made by
+// NewProvider.
+func NewRepositories() domain.Repositories {
+	return domain.Repositories{
+		Subscriptions: NewSubscription(),
+		Events:        NewEvents(),
+		ExchangeRates: NewExchangeRates(),
+		Services:      NewServices(),
+		Tags:          NewTags(),
+		Budgets:       NewBudgets(),
+		Analytics:     NewAnalytics(),
+		MonthlyCosts:  NewMonthlyCosts(),
+	}
+}
@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errService       = errors.New("memory service repository error")
+	ErrCreateService = errors.Join(errService, errors.New("create failed"))
+	ErrUpdateService = errors.Join(errService, errors.New("update failed"))
+	ErrDeleteService = errors.Join(errService, errors.New("delete failed"))
+	ErrReadService   = errors.Join(errService, errors.New("read failed"))
+	ErrListServices  = errors.Join(errService, errors.New("list failed"))
+	ErrLinkService   = errors.Join(errService, errors.New("link failed"))
+)
+
+var _ domain.ServicesRepository = (*ServiceRepository)(nil)
+
+// ServiceRepository mirrors repository.ServiceRepository on top of a
+// Provider.
+type ServiceRepository struct{}
+
+func NewServices() *ServiceRepository {
+	return &ServiceRepository{}
+}
+
+func (r *ServiceRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.services[service.ID]; ok {
+			return domain.ErrAlreadyExists
+		}
+
+		return state.putService(service)
+	})
+	if err != nil {
+		return errors.Join(ErrCreateService, err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Update(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.services[service.ID]; !ok {
+			return domain.ErrServiceNotFound
+		}
+
+		return state.putService(service)
+	})
+	if err != nil {
+		return errors.Join(ErrUpdateService, err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.ServiceID,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.services[id]; !ok {
+			return domain.ErrServiceNotFound
+		}
+		for _, subscription := range state.subscriptions {
+			if subscription.ServiceID != nil && *subscription.ServiceID == id {
+				return domain.ErrServiceInUse
+			}
+		}
+		delete(state.services, id)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrDeleteService, err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.ServiceID,
+) (domain.Service, error) {
+	var service domain.Service
+	err := read(connection, func(state *state) error {
+		var ok bool
+		service, ok = state.services[id]
+		if !ok {
+			return domain.ErrServiceNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Service{}, errors.Join(ErrReadService, err)
+	}
+
+	return detachedService(service), nil
+}
+
+func (r *ServiceRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	filter domain.ServiceFilter,
+) ([]domain.Service, error) {
+	var services []domain.Service
+	err := read(connection, func(state *state) error {
+		for _, service := range state.services {
+			if filter.Category != nil && service.Category != *filter.Category {
+				continue
+			}
+			services = append(services, detachedService(service))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListServices, err)
+	}
+
+	slices.SortFunc(services, func(a, b domain.Service) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), slices.Compare(a.ID[:], b.ID[:]))
+	})
+
+	return services, nil
+}
+
+func (r *ServiceRepository) Resolve(
+	ctx context.Context,
+	connection domain.Connection,
+	key string,
+) (domain.Service, error) {
+	var service domain.Service
+	err := read(connection, func(state *state) error {
+		var ok bool
+		service, ok = state.serviceByKey(key)
+		if !ok {
+			return domain.ErrServiceNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Service{}, errors.Join(ErrReadService, err)
+	}
+
+	return detachedService(service), nil
+}
+
+func (r *ServiceRepository) Link(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) ([]domain.SubscriptionChange, error) {
+	var linked []domain.SubscriptionChange
+	err := write(connection, func(state *state) error {
+		keys := make(map[string]bool)
+		for _, key := range service.Keys() {
+			keys[key] = true
+		}
+
+		var candidates []domain.Subscription
+		for _, subscription := range state.subscriptions {
+			if subscription.ServiceID != nil && *subscription.ServiceID == service.ID ||
+				keys[domain.ServiceKey(subscription.Name)] {
+				candidates = append(candidates, subscription)
+			}
+		}
+
+		// Decide against the state before any change, as a single update
+		// statement would.
+		var changed []domain.Subscription
+		for _, candidate := range candidates {
+			if candidate.Name == service.Name &&
+				candidate.ServiceID != nil && *candidate.ServiceID == service.ID {
+				continue
+			}
+			if candidate.Name != service.Name && candidate.DeletedAt == nil &&
+				state.blocksRename(candidate, service.Name, candidates) {
+				continue
+			}
+
+			candidate.Name = service.Name
+			candidate.ServiceID = &service.ID
+			changed = append(changed, candidate)
+		}
+
+		changes := make([]costChange, 0, len(changed))
+		for _, subscription := range changed {
+			before := state.withPrices(state.subscriptions[subscription.ID])
+			state.subscriptions[subscription.ID] = subscription
+			linked = append(linked, domain.SubscriptionChange{
+				Before: before,
+				After:  state.withPrices(subscription),
+			})
+			changes = append(changes, subscriptionChange(subscription))
+		}
+		state.refreshMonthlyCosts(changes...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrLinkService, err)
+	}
+
+	return linked, nil
+}
+
+// putService stores a catalog entry unless one of its keys belongs to
+// another entry.
+func (s *state) putService(service domain.Service) error {
+	for _, key := range service.Keys() {
+		if other, ok := s.serviceByKey(key); ok && other.ID != service.ID {
+			return domain.ErrAlreadyExists
+		}
+	}
+
+	s.services[service.ID] = detachedService(service)
+
+	return nil
+}
+
+func (s *state) serviceByKey(key string) (domain.Service, bool) {
+	for _, service := range s.services {
+		if slices.Contains(service.Keys(), key) {
+			return service, true
+		}
+	}
+
+	return domain.Service{}, false
+}
+
+// blocksRename reports whether renaming the live subscription to name would
+// make it overlap another live subscription of the same user, either one
+// already named so or another candidate for the rename.
+func (s *state) blocksRename(
+	subscription domain.Subscription,
+	name domain.ServiceName,
+	candidates []domain.Subscription,
+) bool {
+	for _, other := range s.subscriptions {
+		if other.ID == subscription.ID ||
+			other.DeletedAt != nil ||
+			other.UserID != subscription.UserID ||
+			!daysOverlap(other, subscription) {
+			continue
+		}
+		isCandidate := slices.ContainsFunc(candidates, func(c domain.Subscription) bool {
+			return c.ID == other.ID
+		})
+		if other.Name == name || isCandidate {
+			return true
+		}
+	}
+
+	return false
+}
+
+func detachedService(service domain.Service) domain.Service {
+	if service.DefaultPrice != nil {
+		price := *service.DefaultPrice
+		service.DefaultPrice = &price
+	}
+	service.Aliases = slices.Clone(service.Aliases)
+
+	return service
+}
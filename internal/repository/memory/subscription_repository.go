@@ -0,0 +1,614 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errSubscription         = errors.New("memory subscription repository error")
+	ErrCreateSubscription   = errors.Join(errSubscription, errors.New("create failed"))
+	ErrReadSubscription     = errors.Join(errSubscription, errors.New("read failed"))
+	ErrReadAllSubscriptions = errors.Join(
+		errSubscription,
+		errors.New("read all failed"),
+	)
+	ErrCountSubscriptions  = errors.Join(errSubscription, errors.New("count failed"))
+	ErrDeleteSubscription  = errors.Join(errSubscription, errors.New("delete failed"))
+	ErrUpdateSubscription  = errors.Join(errSubscription, errors.New("update failed"))
+	ErrRestoreSubscription = errors.Join(errSubscription, errors.New("restore failed"))
+	ErrPurgeSubscriptions  = errors.Join(errSubscription, errors.New("purge failed"))
+	ErrSchedulePrice       = errors.Join(errSubscription, errors.New("schedule price failed"))
+	ErrReadPrices          = errors.Join(errSubscription, errors.New("read prices failed"))
+	ErrCalculateCost       = errors.Join(
+		errSubscription,
+		errors.New("calculate cost failed"),
+	)
+	ErrReadOverlappingSubscriptions = errors.Join(
+		errSubscription,
+		errors.New("read overlapping failed"),
+	)
+)
+
+var _ domain.SubscriptionsRepository = (*SubscriptionRepository)(nil)
+
+// SubscriptionRepository mirrors repository.SubscriptionRepository on top of
+// a Provider, costs come from the domain billing engine.
+type SubscriptionRepository struct{}
+
+func NewSubscription() *SubscriptionRepository {
+	return &SubscriptionRepository{}
+}
+
+func (s *SubscriptionRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.subscriptions[subscription.ID]; ok {
+			return domain.ErrAlreadyExists
+		}
+		if err := state.putSubscription(subscription); err != nil {
+			return err
+		}
+		state.refreshMonthlyCosts(subscriptionChange(subscription))
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrCreateSubscription, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionRepository) Update(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) error {
+	err := write(connection, func(state *state) error {
+		previous, ok := state.live(subscription.ID)
+		if !ok {
+			return domain.ErrSubscriptionNotFound
+		}
+		if err := state.putSubscription(subscription); err != nil {
+			return err
+		}
+		state.refreshMonthlyCosts(
+			subscriptionChange(subscription),
+			subscriptionChange(previous),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrUpdateSubscription, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) error {
+	err := write(connection, func(state *state) error {
+		subscription, ok := state.live(subscriptionID)
+		if !ok {
+			return domain.ErrSubscriptionNotFound
+		}
+		// Postgres keeps microseconds.
+		deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+		subscription.DeletedAt = &deletedAt
+		state.subscriptions[subscriptionID] = subscription
+		state.refreshMonthlyCosts(subscriptionChange(subscription))
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrDeleteSubscription, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionRepository) Restore(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) (domain.Subscription, error) {
+	var subscription domain.Subscription
+	err := write(connection, func(state *state) error {
+		var ok bool
+		subscription, ok = state.subscriptions[subscriptionID]
+		if !ok || subscription.DeletedAt == nil {
+			return domain.ErrSubscriptionNotFound
+		}
+		if len(state.overlapping(subscription)) > 0 {
+			return domain.ErrSubscriptionOverlap
+		}
+		subscription.DeletedAt = nil
+		state.subscriptions[subscriptionID] = subscription
+		state.refreshMonthlyCosts(subscriptionChange(subscription))
+		subscription = state.withPrices(subscription)
+
+		return nil
+	})
+	if err != nil {
+		return domain.Subscription{}, errors.Join(ErrRestoreSubscription, err)
+	}
+
+	return subscription, nil
+}
+
+func (s *SubscriptionRepository) Purge(
+	ctx context.Context,
+	connection domain.Connection,
+	before time.Time,
+) (int, error) {
+	var purged int
+	err := write(connection, func(state *state) error {
+		for id, subscription := range state.subscriptions {
+			if subscription.DeletedAt != nil && subscription.DeletedAt.Before(before) {
+				delete(state.subscriptions, id)
+				delete(state.prices, id)
+				purged++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(ErrPurgeSubscriptions, err)
+	}
+
+	return purged, nil
+}
+
+func (s *SubscriptionRepository) SchedulePrice(
+	ctx context.Context,
+	connection domain.Connection,
+	change domain.PriceChange,
+) error {
+	change.EffectiveFrom = domain.MonthStart(change.EffectiveFrom)
+
+	err := write(connection, func(state *state) error {
+		subscription, ok := state.subscriptions[change.SubscriptionID]
+		if !ok {
+			return domain.ErrSubscriptionNotFound
+		}
+
+		prices := slices.DeleteFunc(
+			slices.Clone(state.prices[change.SubscriptionID]),
+			func(scheduled domain.PriceChange) bool {
+				return scheduled.EffectiveFrom.Equal(change.EffectiveFrom)
+			},
+		)
+		prices = append(prices, change)
+		slices.SortFunc(prices, func(a, b domain.PriceChange) int {
+			return a.EffectiveFrom.Compare(b.EffectiveFrom)
+		})
+		state.prices[change.SubscriptionID] = prices
+		// The months before the change keep their price.
+		refresh := subscriptionChange(subscription)
+		if refresh.period.Start.Before(change.EffectiveFrom) {
+			refresh.period.Start = change.EffectiveFrom
+		}
+		state.refreshMonthlyCosts(refresh)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrSchedulePrice, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionRepository) ReadPrices(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) ([]domain.PriceChange, error) {
+	var prices []domain.PriceChange
+	err := read(connection, func(state *state) error {
+		prices = slices.Clone(state.prices[subscriptionID])
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrReadPrices, err)
+	}
+
+	return prices, nil
+}
+
+func (s *SubscriptionRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) (domain.Subscription, error) {
+	var subscription domain.Subscription
+	err := read(connection, func(state *state) error {
+		stored, ok := state.live(subscriptionID)
+		if !ok {
+			return domain.ErrSubscriptionNotFound
+		}
+		subscription = state.withPrices(stored)
+
+		return nil
+	})
+	if err != nil {
+		return subscription, errors.Join(ErrReadSubscription, err)
+	}
+
+	return subscription, nil
+}
+
+func (s *SubscriptionRepository) ReadAll(
+	ctx context.Context,
+	connection domain.Connection,
+	filter domain.SubscriptionFilter,
+	pagination domain.Pagination,
+) ([]domain.Subscription, error) {
+	var subscriptions []domain.Subscription
+	err := read(connection, func(state *state) error {
+		subscriptions = state.filterSubscriptions(filter)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrReadAllSubscriptions, err)
+	}
+
+	return paginate(subscriptions, pagination), nil
+}
+
+func (s *SubscriptionRepository) Count(
+	ctx context.Context,
+	connection domain.Connection,
+	filter domain.SubscriptionFilter,
+) (int, error) {
+	var count int
+	err := read(connection, func(state *state) error {
+		count = len(state.filterSubscriptions(filter))
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(ErrCountSubscriptions, err)
+	}
+
+	return count, nil
+}
+
+func (s *SubscriptionRepository) ReadOverlapping(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) ([]domain.Subscription, error) {
+	var overlapping []domain.Subscription
+	err := read(connection, func(state *state) error {
+		overlapping = state.overlapping(subscription)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrReadOverlappingSubscriptions, err)
+	}
+
+	return overlapping, nil
+}
+
+func (s *SubscriptionRepository) CalculateTotalCost(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) (int, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return 0, err
+	}
+
+	return domain.TotalCost(subscriptions, billingPeriod(start, end)), nil
+}
+
+func (s *SubscriptionRepository) CalculateTotalCostByService(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.ServiceTotalCost, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CostByService(subscriptions, billingPeriod(start, end)), nil
+}
+
+func (s *SubscriptionRepository) CalculateTotalCostByGroup(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+	group domain.CostGroup,
+) ([]domain.GroupCost, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CostByGroup(subscriptions, billingPeriod(start, end), group), nil
+}
+
+func (s *SubscriptionRepository) CalculateMonthlyCharges(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyCharges, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.MonthlyChargeCounts(subscriptions, billingPeriod(start, end)), nil
+}
+
+func (s *SubscriptionRepository) CalculateCategoryCharges(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	category string,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyCharges, error) {
+	subscriptions, err := s.billed(connection, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	subscriptions = slices.DeleteFunc(subscriptions, func(subscription domain.Subscription) bool {
+		return subscription.Category != category
+	})
+
+	return domain.MonthlyChargeCounts(subscriptions, billingPeriod(start, end)), nil
+}
+
+func (s *SubscriptionRepository) BilledCurrencies(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.Currency, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var currencies []domain.Currency
+	period := billingPeriod(start, end)
+	for _, subscription := range subscriptions {
+		if subscription.Period().Overlaps(period) {
+			currencies = append(currencies, subscription.Currency.OrDefault())
+		}
+	}
+	slices.Sort(currencies)
+
+	return slices.Compact(currencies), nil
+}
+
+func (s *SubscriptionRepository) CalculateMonthlyCosts(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyServiceCost, error) {
+	subscriptions, err := s.billed(connection, userID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var costs []domain.MonthlyServiceCost
+	for _, month := range domain.MonthlyCosts(subscriptions, billingPeriod(start, end)) {
+		for _, service := range month.Services {
+			costs = append(costs, domain.MonthlyServiceCost{
+				Month: month.Month,
+				Name:  service.Name,
+				Cost:  service.Cost,
+			})
+		}
+	}
+
+	return costs, nil
+}
+
+// billed returns the subscriptions of the user with their price changes,
+// narrowed to one service unless serviceName is empty.
+func (s *SubscriptionRepository) billed(
+	connection domain.Connection,
+	userID domain.UserID,
+	serviceName domain.ServiceName,
+) ([]domain.Subscription, error) {
+	filter := domain.SubscriptionFilter{UserID: userID}
+	if serviceName != "" {
+		filter.ServiceName = &serviceName
+	}
+
+	var subscriptions []domain.Subscription
+	err := read(connection, func(state *state) error {
+		subscriptions = state.filterSubscriptions(filter)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrCalculateCost, err)
+	}
+
+	return subscriptions, nil
+}
+
+// billingPeriod bills up to the current month when end is not set.
+func billingPeriod(start time.Time, end *time.Time) domain.Period {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	return domain.NewPeriod(start, end)
+}
+
+// live returns the subscription unless it is missing or deleted.
+func (s *state) live(id domain.SubscriptionID) (domain.Subscription, bool) {
+	subscription, ok := s.subscriptions[id]
+	if !ok || subscription.DeletedAt != nil {
+		return domain.Subscription{}, false
+	}
+
+	return subscription, true
+}
+
+// putSubscription stores a live subscription with its dates truncated to
+// days, as the date columns do, and enforces the no-overlap rule. Tags the
+// user does not have yet are created.
+func (s *state) putSubscription(subscription domain.Subscription) error {
+	subscription.DeletedAt = nil
+	// Price changes live in state.prices.
+	subscription.Prices = nil
+	subscription = subscription.WithBillingDefaults()
+	subscription.StartDate = truncateToDay(subscription.StartDate)
+	if subscription.EndDate != nil {
+		end := truncateToDay(*subscription.EndDate)
+		if end.Before(subscription.StartDate) {
+			return domain.NewValidationError(
+				"constraint_violation",
+				"range lower bound must be less than or equal to range upper bound",
+			)
+		}
+		subscription.EndDate = &end
+	}
+
+	if subscription.ServiceID != nil {
+		if _, ok := s.services[*subscription.ServiceID]; !ok {
+			return domain.NewValidationError(
+				"constraint_violation",
+				"service_id does not reference a catalog service",
+			)
+		}
+	}
+
+	if len(s.overlapping(subscription)) > 0 {
+		return domain.ErrSubscriptionOverlap
+	}
+
+	subscription.Tags = s.ensureTags(subscription.UserID, subscription.Tags)
+	s.subscriptions[subscription.ID] = subscription
+
+	return nil
+}
+
+func (s *state) overlapping(subscription domain.Subscription) []domain.Subscription {
+	var overlapping []domain.Subscription
+	for _, other := range s.subscriptions {
+		if other.ID != subscription.ID &&
+			other.DeletedAt == nil &&
+			other.UserID == subscription.UserID &&
+			other.Name == subscription.Name &&
+			daysOverlap(other, subscription) {
+			overlapping = append(overlapping, detached(other))
+		}
+	}
+	slices.SortFunc(overlapping, func(a, b domain.Subscription) int {
+		return compareSubscriptions(domain.SubscriptionSort{Field: domain.SortByStartDate}, a, b)
+	})
+
+	return overlapping
+}
+
+func (s *state) filterSubscriptions(filter domain.SubscriptionFilter) []domain.Subscription {
+	now := time.Now()
+
+	var subscriptions []domain.Subscription
+	for _, subscription := range s.subscriptions {
+		if matches(filter, subscription, now) {
+			subscriptions = append(subscriptions, s.withPrices(subscription))
+		}
+	}
+
+	return subscriptions
+}
+
+// withPrices detaches a stored subscription along with its price changes.
+func (s *state) withPrices(subscription domain.Subscription) domain.Subscription {
+	subscription = detached(subscription)
+	subscription.Prices = slices.Clone(s.prices[subscription.ID])
+
+	return subscription
+}
+
+// detached copies a stored subscription so callers cannot change the store
+// through its pointers.
+func detached(subscription domain.Subscription) domain.Subscription {
+	if subscription.EndDate != nil {
+		end := *subscription.EndDate
+		subscription.EndDate = &end
+	}
+	if subscription.DeletedAt != nil {
+		deletedAt := *subscription.DeletedAt
+		subscription.DeletedAt = &deletedAt
+	}
+	if subscription.ServiceID != nil {
+		serviceID := *subscription.ServiceID
+		subscription.ServiceID = &serviceID
+	}
+	subscription.Discounts = slices.Clone(subscription.Discounts)
+	subscription.Tags = slices.Clone(subscription.Tags)
+
+	return subscription
+}
+
+// daysOverlap compares inclusive day ranges, a missing end date never ends.
+func daysOverlap(a, b domain.Subscription) bool {
+	endsBefore := func(x, y domain.Subscription) bool {
+		return x.EndDate != nil && truncateToDay(*x.EndDate).Before(truncateToDay(y.StartDate))
+	}
+
+	return !endsBefore(a, b) && !endsBefore(b, a)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func read(connection domain.Connection, fn func(*state) error) error {
+	memoryConnection, err := connectionOf(connection)
+	if err != nil {
+		return err
+	}
+
+	return memoryConnection.read(fn)
+}
+
+func write(connection domain.Connection, fn func(*state) error) error {
+	memoryConnection, err := connectionOf(connection)
+	if err != nil {
+		return err
+	}
+
+	return memoryConnection.write(fn)
+}
@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errTag       = errors.New("memory tag repository error")
+	ErrCreateTag = errors.Join(errTag, errors.New("create failed"))
+	ErrReadTag   = errors.Join(errTag, errors.New("read failed"))
+	ErrListTags  = errors.Join(errTag, errors.New("list failed"))
+	ErrRenameTag = errors.Join(errTag, errors.New("rename failed"))
+	ErrDeleteTag = errors.Join(errTag, errors.New("delete failed"))
+	ErrMergeTags = errors.Join(errTag, errors.New("merge failed"))
+)
+
+var _ domain.TagsRepository = (*TagRepository)(nil)
+
+// TagRepository mirrors repository.TagRepository on top of a Provider.
+type TagRepository struct{}
+
+func NewTags() *TagRepository {
+	return &TagRepository{}
+}
+
+func (r *TagRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	tag domain.Tag,
+) error {
+	err := write(connection, func(state *state) error {
+		if _, ok := state.tags[tag.ID]; ok {
+			return domain.ErrAlreadyExists
+		}
+		if _, ok := state.tagByKey(tag.UserID, domain.TagKey(tag.Name)); ok {
+			return domain.ErrAlreadyExists
+		}
+
+		tag.Subscriptions = 0
+		state.tags[tag.ID] = tag
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrCreateTag, err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+) (domain.Tag, error) {
+	var tag domain.Tag
+	err := read(connection, func(state *state) error {
+		var ok bool
+		tag, ok = state.tags[id]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+		tag.Subscriptions = state.tagged(tag)
+
+		return nil
+	})
+	if err != nil {
+		return domain.Tag{}, errors.Join(ErrReadTag, err)
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := read(connection, func(state *state) error {
+		for _, tag := range state.tags {
+			if tag.UserID == userID {
+				tag.Subscriptions = state.tagged(tag)
+				tags = append(tags, tag)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListTags, err)
+	}
+
+	slices.SortFunc(tags, func(a, b domain.Tag) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), slices.Compare(a.ID[:], b.ID[:]))
+	})
+
+	return tags, nil
+}
+
+func (r *TagRepository) Rename(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+	name string,
+) error {
+	err := write(connection, func(state *state) error {
+		tag, ok := state.tags[id]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+		if other, ok := state.tagByKey(tag.UserID, domain.TagKey(name)); ok && other.ID != id {
+			return domain.ErrAlreadyExists
+		}
+
+		state.retag(tag, func(names []string) []string {
+			return append(slices.DeleteFunc(names, isTag(tag.Name)), name)
+		})
+		tag.Name = name
+		state.tags[id] = tag
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrRenameTag, err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+) error {
+	err := write(connection, func(state *state) error {
+		tag, ok := state.tags[id]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+
+		state.retag(tag, func(names []string) []string {
+			return slices.DeleteFunc(names, isTag(tag.Name))
+		})
+		delete(state.tags, id)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrDeleteTag, err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Merge(
+	ctx context.Context,
+	connection domain.Connection,
+	from, into domain.TagID,
+) error {
+	err := write(connection, func(state *state) error {
+		source, ok := state.tags[from]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+		target, ok := state.tags[into]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+
+		state.retag(source, func(names []string) []string {
+			names = slices.DeleteFunc(names, isTag(source.Name))
+			if !slices.Contains(names, target.Name) {
+				names = append(names, target.Name)
+			}
+			return names
+		})
+		delete(state.tags, from)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrMergeTags, err)
+	}
+
+	return nil
+}
+
+// ensureTags returns the stored spelling of every tag of the user named, in
+// name order, creating the missing ones.
+func (s *state) ensureTags(userID domain.UserID, names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	stored := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, ok := s.tagByKey(userID, domain.TagKey(name))
+		if !ok {
+			tag = domain.Tag{ID: uuid.New(), UserID: userID, Name: name}
+			s.tags[tag.ID] = tag
+		}
+		if !slices.Contains(stored, tag.Name) {
+			stored = append(stored, tag.Name)
+		}
+	}
+	slices.Sort(stored)
+
+	return stored
+}
+
+func (s *state) tagByKey(userID domain.UserID, key string) (domain.Tag, bool) {
+	for _, tag := range s.tags {
+		if tag.UserID == userID && domain.TagKey(tag.Name) == key {
+			return tag, true
+		}
+	}
+
+	return domain.Tag{}, false
+}
+
+// tagged counts the live subscriptions with the tag.
+func (s *state) tagged(tag domain.Tag) int {
+	var count int
+	for _, subscription := range s.subscriptions {
+		if subscription.DeletedAt == nil &&
+			subscription.UserID == tag.UserID &&
+			slices.Contains(subscription.Tags, tag.Name) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// retag replaces the tags of every subscription with the tag, deleted ones
+// included, by what change makes of a copy of them.
+func (s *state) retag(tag domain.Tag, change func([]string) []string) {
+	for id, subscription := range s.subscriptions {
+		if subscription.UserID != tag.UserID || !slices.Contains(subscription.Tags, tag.Name) {
+			continue
+		}
+
+		names := change(slices.Clone(subscription.Tags))
+		slices.Sort(names)
+		if len(names) == 0 {
+			names = nil
+		}
+		subscription.Tags = names
+		s.subscriptions[id] = subscription
+	}
+}
+
+func isTag(name string) func(string) bool {
+	return func(other string) bool {
+		return other == name
+	}
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errMonthlyCosts          = errors.New("monthly cost repository error")
+	ErrClaimMonthlyCosts     = errors.Join(errMonthlyCosts, errors.New("claim failed"))
+	ErrMaintainMonthlyCosts  = errors.Join(errMonthlyCosts, errors.New("maintain failed"))
+	ErrRefreshMonthlyCosts   = errors.Join(errMonthlyCosts, errors.New("refresh failed"))
+	ErrMonthlyCostMismatches = errors.Join(errMonthlyCosts, errors.New("mismatches failed"))
+	ErrMonthlyCostsHorizon   = errors.Join(errMonthlyCosts, errors.New("horizon failed"))
+)
+
+var _ domain.MonthlyCostsRepository = (*MonthlyCostRepository)(nil)
+
+// beforeAnySubscription is where the stored months start, billedCharges
+// never bills a month before the start of a subscription.
+var beforeAnySubscription = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// materializeMonthlyCosts stores the months billedCharges yields for user $1,
+// for every user when $1 is null, from $3 to $4. Rows that are already there
+// are overwritten.
+const materializeMonthlyCosts = `with ` + billedCharges + `
+insert into monthly_costs (user_id, service_name, month, currency, amount)
+select user_id, service_name, month, currency, sum(price * charges)
+from charges
+where charges > 0
+group by user_id, service_name, month, currency
+on conflict (user_id, service_name, month, currency) do update set amount = excluded.amount`
+
+type MonthlyCostRepository struct{}
+
+func NewMonthlyCosts() *MonthlyCostRepository {
+	return &MonthlyCostRepository{}
+}
+
+// costChange is a range of months of a user whose stored costs a write may
+// have changed, the range of a subscription before or after the write.
+type costChange struct {
+	userID domain.UserID
+	start  time.Time
+	end    *time.Time
+}
+
+func subscriptionChange(subscription domain.Subscription) costChange {
+	return costChange{
+		userID: subscription.UserID,
+		start:  subscription.StartDate,
+		end:    subscription.EndDate,
+	}
+}
+
+// Claim takes the maintenance for the transaction unless another instance
+// holds it or the last one finished after since.
+func (r *MonthlyCostRepository) Claim(
+	ctx context.Context,
+	connection domain.Connection,
+	since time.Time,
+) (bool, error) {
+	const (
+		lockQuery = `select pg_try_advisory_xact_lock(hashtext('monthly_costs_maintenance'), 0)`
+		// A separate statement, so it sees a maintenance that committed
+		// while the lock was taken.
+		dueQuery = `select count(*) = 0 from monthly_costs_state where maintained_at > $1`
+	)
+
+	var claimed bool
+	for _, step := range []struct {
+		query string
+		args  []any
+	}{
+		{query: lockQuery},
+		{query: dueQuery, args: []any{since}},
+	} {
+		if err := connection.GetContext(ctx, &claimed, step.query, step.args...); err != nil {
+			return false, errors.Join(
+				ErrClaimMonthlyCosts,
+				classify(err, domain.ErrSubscriptionNotFound),
+			)
+		}
+		if !claimed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Maintain locks the state row for update, which waits for the writes
+// refreshing stored months and holds off the next ones until it commits.
+// The months of the mismatches are recalculated and the horizon moves
+// forward, only the months past the old horizon are calculated for it.
+func (r *MonthlyCostRepository) Maintain(
+	ctx context.Context,
+	connection domain.Connection,
+	mismatches []domain.MonthlyCostMismatch,
+	horizon time.Time,
+	at time.Time,
+) error {
+	const (
+		stateQuery    = `select horizon from monthly_costs_state for update`
+		repairQuery   = `delete from monthly_costs where user_id = $1 and month = date_trunc('month', $2::date)`
+		clearQuery    = `delete from monthly_costs where month > $1`
+		clearAllQuery = `delete from monthly_costs`
+		updateQuery   = `update monthly_costs_state set horizon = $1, maintained_at = $2`
+	)
+
+	var horizons []*time.Time
+	if err := connection.SelectContext(ctx, &horizons, stateQuery); err != nil {
+		return errors.Join(
+			ErrMaintainMonthlyCosts,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	var stored *time.Time
+	if len(horizons) > 0 {
+		stored = horizons[0]
+	}
+
+	type (
+		statement struct {
+			query string
+			args  []any
+		}
+		userMonth struct {
+			userID domain.UserID
+			month  time.Time
+		}
+	)
+	var statements []statement
+	repaired := make(map[userMonth]bool, len(mismatches))
+	for _, mismatch := range mismatches {
+		key := userMonth{userID: mismatch.UserID, month: domain.MonthStart(mismatch.Month)}
+		if stored == nil || repaired[key] {
+			continue
+		}
+		repaired[key] = true
+		statements = append(
+			statements,
+			statement{query: repairQuery, args: []any{key.userID, key.month}},
+			statement{
+				query: materializeMonthlyCosts,
+				args:  []any{key.userID, "", key.month, key.month},
+			},
+		)
+	}
+
+	horizon = domain.MonthStart(horizon)
+	switch {
+	case stored == nil:
+		statements = append(
+			statements,
+			statement{query: clearAllQuery},
+			statement{
+				query: materializeMonthlyCosts,
+				args:  []any{nil, "", beforeAnySubscription, horizon},
+			},
+		)
+	case horizon.After(*stored):
+		statements = append(
+			statements,
+			statement{query: clearQuery, args: []any{*stored}},
+			statement{
+				query: materializeMonthlyCosts,
+				args:  []any{nil, "", stored.AddDate(0, 1, 0), horizon},
+			},
+		)
+	default:
+		horizon = *stored
+	}
+	statements = append(statements, statement{query: updateQuery, args: []any{horizon, at}})
+
+	for _, statement := range statements {
+		if _, err := connection.ExecContext(ctx, statement.query, statement.args...); err != nil {
+			return errors.Join(
+				ErrMaintainMonthlyCosts,
+				classify(err, domain.ErrSubscriptionNotFound),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *MonthlyCostRepository) Mismatches(
+	ctx context.Context,
+	connection domain.Connection,
+) ([]domain.MonthlyCostMismatch, error) {
+	horizon, ok, err := monthlyCostsHorizon(ctx, connection)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	const query = `with ` + billedCharges + `,
+live as (
+    select user_id, service_name, month, currency, sum(price * charges) as amount
+    from charges
+    where charges > 0
+    group by user_id, service_name, month, currency
+)
+select * from (
+    select
+        coalesce(l.user_id, m.user_id) as user_id,
+        coalesce(l.service_name, m.service_name) as service_name,
+        coalesce(l.month, m.month) as month,
+        coalesce(l.currency, m.currency) as currency,
+        coalesce(m.amount, 0) as stored,
+        coalesce(l.amount, 0) as live
+    from live l
+    full join monthly_costs m
+        on m.user_id = l.user_id and m.service_name = l.service_name
+        and m.month = l.month and m.currency = l.currency
+    where l.amount is distinct from m.amount
+) mismatches
+order by user_id, service_name collate "C", month, currency collate "C"`
+	var mismatches []domain.MonthlyCostMismatch
+	if err := connection.SelectContext(ctx, &mismatches, query, nil, "", beforeAnySubscription, horizon); err != nil {
+		return nil, errors.Join(
+			ErrMonthlyCostMismatches,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+
+	return mismatches, nil
+}
+
+// refreshMonthlyCosts recalculates the stored months of the changes after a
+// write, in the transaction of the write. Refreshes of one user are
+// serialized, so the one committing last has seen the subscriptions written
+// by the others, and the locks are taken in user order so writes touching
+// several users never wait on each other in a cycle.
+func refreshMonthlyCosts(
+	ctx context.Context,
+	connection domain.Connection,
+	changes ...costChange,
+) error {
+	const (
+		lockQuery = `select pg_advisory_xact_lock(hashtext('monthly_costs'), hashtext($1::text))`
+		// Waits for a running maintenance to move the horizon.
+		horizonQuery = `select horizon from monthly_costs_state for share`
+		clearQuery   = `delete from monthly_costs
+	where user_id = $1 and month between date_trunc('month', $2::date) and date_trunc('month', $3::date)`
+	)
+
+	userIDs := make([]domain.UserID, 0, len(changes))
+	for _, change := range changes {
+		userIDs = append(userIDs, change.userID)
+	}
+	slices.SortFunc(userIDs, func(a, b domain.UserID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for _, userID := range slices.Compact(userIDs) {
+		if _, err := connection.ExecContext(ctx, lockQuery, userID); err != nil {
+			return errors.Join(
+				ErrRefreshMonthlyCosts,
+				classify(err, domain.ErrSubscriptionNotFound),
+			)
+		}
+	}
+
+	var horizons []*time.Time
+	if err := connection.SelectContext(ctx, &horizons, horizonQuery); err != nil {
+		return errors.Join(
+			ErrRefreshMonthlyCosts,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	if len(horizons) == 0 || horizons[0] == nil {
+		return nil
+	}
+
+	for _, change := range changes {
+		// Months past the horizon are never stored, a range starting after
+		// it ends before it starts and touches nothing.
+		end := *horizons[0]
+		if change.end != nil && change.end.Before(end) {
+			end = *change.end
+		}
+		if _, err := connection.ExecContext(ctx, clearQuery, change.userID, change.start, end); err != nil {
+			return errors.Join(
+				ErrRefreshMonthlyCosts,
+				classify(err, domain.ErrSubscriptionNotFound),
+			)
+		}
+		if _, err := connection.ExecContext(ctx, materializeMonthlyCosts, change.userID, "", change.start, end); err != nil {
+			return errors.Join(
+				ErrRefreshMonthlyCosts,
+				classify(err, domain.ErrSubscriptionNotFound),
+			)
+		}
+	}
+
+	return nil
+}
+
+// monthlyCostsHorizon returns the last stored month, ok is false before the
+// first maintenance.
+func monthlyCostsHorizon(
+	ctx context.Context,
+	connection domain.Connection,
+) (time.Time, bool, error) {
+	const query = `select horizon from monthly_costs_state where horizon is not null`
+
+	var horizons []time.Time
+	if err := connection.SelectContext(ctx, &horizons, query); err != nil {
+		return time.Time{}, false, errors.Join(
+			ErrMonthlyCostsHorizon,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	if len(horizons) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	return horizons[0], true, nil
+}
+
+// storedThrough reports whether monthly_costs holds every month up to the
+// month of end, so a cost read can be served from it.
+func storedThrough(ctx context.Context, connection domain.Connection, end time.Time) (bool, error) {
+	horizon, ok, err := monthlyCostsHorizon(ctx, connection)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return !domain.MonthStart(end).After(domain.MonthStart(horizon)), nil
+}
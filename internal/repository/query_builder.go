@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+// openEndDate stands in for a NULL end date wherever end dates are compared
+// or sorted, open-ended subscriptions sort after every finite one.
+var openEndDate = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// sortColumns whitelists sortable expressions, user input never reaches the
+// query text.
+var sortColumns = map[domain.SubscriptionSortField]string{
+	domain.SortByStartDate:   "subs_start_date",
+	domain.SortByEndDate:     "coalesce(subs_end_date, '9999-12-31'::date)",
+	domain.SortByPrice:       "month_cost",
+	domain.SortByServiceName: "service_name",
+}
+
+// queryBuilder collects where conditions and binds every value as a
+// positional parameter.
+type queryBuilder struct {
+	conditions []string
+	args       []any
+}
+
+// arg binds a value and returns its placeholder.
+func (b *queryBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// where adds a condition, every %s in format is replaced by a placeholder for
+// the matching value.
+func (b *queryBuilder) where(format string, values ...any) {
+	placeholders := make([]any, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, b.arg(value))
+	}
+
+	b.conditions = append(b.conditions, fmt.Sprintf(format, placeholders...))
+}
+
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+
+	return " where " + strings.Join(b.conditions, " and ")
+}
+
+func (b *queryBuilder) applyFilter(filter domain.SubscriptionFilter) {
+	b.where("user_id = %s", filter.UserID)
+
+	if !filter.IncludeDeleted {
+		b.where("deleted_at is null")
+	}
+
+	if filter.ServiceName != nil {
+		b.where("service_name = %s", *filter.ServiceName)
+	}
+	if filter.ServiceNamePrefix != nil {
+		b.where(`service_name like %s escape '\'`, escapeLike(*filter.ServiceNamePrefix)+"%")
+	}
+	if filter.Category != nil {
+		b.where("category = %s", *filter.Category)
+	}
+	if filter.Tag != nil {
+		b.where(`exists (
+	select 1 from subscription_tags st join tags t on t.id = st.tag_id
+	where st.subscription_id = subscriptions.id and t.name_key = %s
+)`, domain.TagKey(*filter.Tag))
+	}
+	if filter.ActiveAt != nil {
+		month := domain.MonthStart(*filter.ActiveAt)
+		b.where(
+			"subs_start_date < %s and (subs_end_date is null or subs_end_date >= %s)",
+			month.AddDate(0, 1, 0),
+			month,
+		)
+	}
+	if filter.Status != nil {
+		// A subscription is active during every month it touches.
+		const (
+			currentMonth = "date_trunc('month', current_date)::date"
+			nextMonth    = "(date_trunc('month', current_date) + interval '1 month')::date"
+		)
+		switch *filter.Status {
+		case domain.SubscriptionStatusActive:
+			b.where("subs_start_date < " + nextMonth +
+				" and (subs_end_date is null or subs_end_date >= " + currentMonth + ")")
+		case domain.SubscriptionStatusEnded:
+			b.where("subs_end_date < " + currentMonth)
+		case domain.SubscriptionStatusFuture:
+			b.where("subs_start_date >= " + nextMonth)
+		}
+	}
+	if filter.MinPrice != nil {
+		b.where("month_cost >= %s", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		b.where("month_cost <= %s", *filter.MaxPrice)
+	}
+	if filter.StartFrom != nil {
+		b.where("subs_start_date >= %s", domain.MonthStart(*filter.StartFrom))
+	}
+	if filter.StartTo != nil {
+		b.where("subs_start_date < %s", domain.MonthStart(*filter.StartTo).AddDate(0, 1, 0))
+	}
+}
+
+// applySeek skips everything up to and including the cursor in sort order.
+func (b *queryBuilder) applySeek(sort domain.SubscriptionSort, cursor *domain.SubscriptionCursor) {
+	if cursor == nil {
+		return
+	}
+
+	var value any
+	switch sort.Field {
+	case domain.SortByStartDate:
+		value = cursor.StartDate
+	case domain.SortByEndDate:
+		value = openEndDate
+		if cursor.EndDate != nil {
+			value = *cursor.EndDate
+		}
+	case domain.SortByPrice:
+		value = cursor.Cost
+	case domain.SortByServiceName:
+		value = cursor.Name
+	}
+
+	operator := ">"
+	if sort.Descending {
+		operator = "<"
+	}
+
+	b.where("("+sortColumns[sort.Field]+", id) "+operator+" (%s, %s)", value, cursor.ID)
+}
+
+func orderByClause(sort domain.SubscriptionSort) string {
+	column, ok := sortColumns[sort.Field]
+	if !ok {
+		sort = domain.DefaultSubscriptionSort
+		column = sortColumns[sort.Field]
+	}
+
+	direction := "asc"
+	if sort.Descending {
+		direction = "desc"
+	}
+
+	return " order by " + column + " " + direction + ", id " + direction
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+// NewRepositories returns every Postgres repository, for a provider made by
+// database.NewPostgresProvider.
+func NewRepositories() domain.Repositories {
+	return domain.Repositories{
+		Subscriptions: NewSubscription(),
+		Events:        NewEvents(),
+		ExchangeRates: NewExchangeRates(),
+		Services:      NewServices(),
+		Tags:          NewTags(),
+		Budgets:       NewBudgets(),
+		Analytics:     NewAnalytics(),
+		MonthlyCosts:  NewMonthlyCosts(),
+	}
+}
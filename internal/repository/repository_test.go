@@ -8,6 +8,8 @@ import (
 
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/database"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/repository/repositorytest"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
@@ -37,7 +39,14 @@ func cleanTablesAndCreateProvider(t *testing.T) domain.ConnectionProvider {
 	}
 	require.NoError(t, godotenv.Load(pathToEnv))
 
-	tablesToClean := []string{"subscriptions"}
+	tablesToClean := []string{
+		"subscriptions",
+		"exchange_rates",
+		"services",
+		"tags",
+		"budgets",
+		"monthly_costs",
+	}
 
 	pool, err := pgxpool.New(context.Background(), os.Getenv("DB_CONNECTION"))
 	require.NoError(t, err)
@@ -51,6 +60,12 @@ func cleanTablesAndCreateProvider(t *testing.T) domain.ConnectionProvider {
 				_, err = connection.ExecContext(ctx, fmt.Sprintf("delete from %s cascade", table))
 				require.NoError(t, err)
 			}
+			// The state row stays, nothing is stored while its horizon is null.
+			_, err = connection.ExecContext(
+				ctx,
+				"update monthly_costs_state set horizon = null, maintained_at = null",
+			)
+			require.NoError(t, err)
 
 			return nil
 		},
@@ -59,3 +74,43 @@ func cleanTablesAndCreateProvider(t *testing.T) domain.ConnectionProvider {
 
 	return provider
 }
+
+// newBackend is the repositorytest.Factory of the Postgres backend.
+func newBackend(t *testing.T) (domain.ConnectionProvider, domain.Repositories) {
+	provider := cleanTablesAndCreateProvider(t)
+	t.Cleanup(func() { _ = provider.Close() })
+
+	return provider, repository.NewRepositories()
+}
+
+func TestSubscriptionRepositoryContractIntegration(t *testing.T) {
+	repositorytest.Run(t, newBackend)
+}
+
+func TestEventRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunEvents(t, newBackend)
+}
+
+func TestExchangeRateRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunExchangeRates(t, newBackend)
+}
+
+func TestServiceRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunServices(t, newBackend)
+}
+
+func TestTagRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunTags(t, newBackend)
+}
+
+func TestBudgetRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunBudgets(t, newBackend)
+}
+
+func TestAnalyticsRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunAnalytics(t, newBackend)
+}
+
+func TestMonthlyCostRepositoryContractIntegration(t *testing.T) {
+	repositorytest.RunMonthlyCosts(t, newBackend)
+}
@@ -0,0 +1,254 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// RunAnalytics runs the analytics part of the suite.
+func RunAnalytics(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(
+			*testing.T,
+			*backend,
+			domain.AnalyticsRepository,
+			domain.MonthlyCostsRepository,
+		)
+	}{
+		{"revenue matches user totals", testRevenueMatchesUserTotals},
+		{"revenue by currency", testRevenueByCurrency},
+		{"activity", testActivity},
+		{"activity at month boundaries", testActivityAtMonthBoundaries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			tt.test(
+				t,
+				newBackend(t, provider, repositories),
+				repositories.Analytics,
+				repositories.MonthlyCosts,
+			)
+		})
+	}
+}
+
+// createFleet stores the subscriptions of two users, one of them with a price
+// change and a deleted subscription, and returns the users.
+func (b *backend) createFleet() []domain.UserID {
+	b.t.Helper()
+
+	first, second := uuid.New(), uuid.New()
+	music := b.create(subscription(first, "music", 100, month(2025, time.January), nil))
+	b.schedulePrice(music.ID, month(2025, time.March), 150)
+	b.create(subscription(
+		first,
+		"video",
+		300,
+		month(2025, time.February),
+		pointer.Ref(date(2025, time.March, 20)),
+	))
+	yearly := subscription(second, "cloud", 1200, month(2024, time.April), nil)
+	yearly.BillingPeriod = domain.BillingYearly
+	b.create(yearly)
+	b.create(subscription(second, "music", 100, month(2025, time.March), nil))
+	deleted := b.create(subscription(second, "video", 300, month(2025, time.January), nil))
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, deleted.ID)
+	}))
+
+	return []domain.UserID{first, second}
+}
+
+func testRevenueMatchesUserTotals(
+	t *testing.T,
+	b *backend,
+	analytics domain.AnalyticsRepository,
+	_ domain.MonthlyCostsRepository,
+) {
+	users := b.createFleet()
+	start, end := month(2025, time.January), month(2025, time.April)
+
+	expected := make(map[domain.MonthlyServiceCost]int)
+	for _, userID := range users {
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			costs, err := b.repo.CalculateMonthlyCosts(ctx, c, userID, "", start, &end)
+			for _, cost := range costs {
+				key := cost
+				key.Month, key.Cost = key.Month.UTC(), 0
+				expected[key] += cost.Cost
+			}
+			return err
+		}))
+	}
+
+	var revenue []domain.MonthlyServiceCost
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		revenue, err = analytics.MonthlyRevenue(ctx, c, start, end)
+		return err
+	}))
+
+	actual := make(map[domain.MonthlyServiceCost]int)
+	for _, cost := range revenue {
+		require.Equal(t, domain.DefaultCurrency, cost.Currency)
+		key := cost
+		key.Month, key.Currency, key.Cost = key.Month.UTC(), "", 0
+		actual[key] += cost.Cost
+	}
+	require.Equal(t, expected, actual)
+	require.Equal(
+		t,
+		150+100,
+		actual[domain.MonthlyServiceCost{Month: month(2025, time.March), Name: "music"}],
+	)
+	require.Equal(
+		t,
+		1200,
+		actual[domain.MonthlyServiceCost{Month: month(2025, time.April), Name: "cloud"}],
+	)
+}
+
+func testActivity(
+	t *testing.T,
+	b *backend,
+	analytics domain.AnalyticsRepository,
+	_ domain.MonthlyCostsRepository,
+) {
+	b.createFleet()
+
+	var activity []domain.MonthlyActivity
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		activity, err = analytics.MonthlyActivity(
+			ctx,
+			c,
+			month(2024, time.December),
+			month(2025, time.April),
+		)
+		return err
+	}))
+	for i := range activity {
+		activity[i].Month = activity[i].Month.UTC()
+	}
+
+	require.Equal(t, []domain.MonthlyActivity{
+		{Month: month(2024, time.December), Active: 1},
+		{Month: month(2025, time.January), Active: 2, New: 1},
+		{Month: month(2025, time.February), Active: 3, New: 1},
+		{Month: month(2025, time.March), Active: 4, New: 1, Cancelled: 1},
+		{Month: month(2025, time.April), Active: 3},
+	}, activity)
+}
+
+// testActivityAtMonthBoundaries starts and ends subscriptions on the first
+// and the last day of months, the day never moves them into another month.
+func testActivityAtMonthBoundaries(
+	t *testing.T,
+	b *backend,
+	analytics domain.AnalyticsRepository,
+	_ domain.MonthlyCostsRepository,
+) {
+	userID := uuid.New()
+	for _, s := range []domain.Subscription{
+		subscription(
+			userID,
+			"music",
+			100,
+			date(2025, time.January, 31),
+			pointer.Ref(date(2025, time.February, 28)),
+		),
+		subscription(
+			userID,
+			"video",
+			300,
+			date(2025, time.March, 1),
+			pointer.Ref(date(2025, time.March, 31)),
+		),
+		subscription(
+			userID,
+			"cloud",
+			200,
+			date(2025, time.February, 1),
+			pointer.Ref(date(2025, time.March, 1)),
+		),
+		subscription(userID, "books", 50, date(2024, time.December, 31), nil),
+	} {
+		b.create(s)
+	}
+
+	var activity []domain.MonthlyActivity
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		activity, err = analytics.MonthlyActivity(
+			ctx,
+			c,
+			month(2025, time.January),
+			month(2025, time.April),
+		)
+		return err
+	}))
+	for i := range activity {
+		activity[i].Month = activity[i].Month.UTC()
+	}
+
+	require.Equal(t, []domain.MonthlyActivity{
+		{Month: month(2025, time.January), Active: 2, New: 1},
+		{Month: month(2025, time.February), Active: 3, New: 1, Cancelled: 1},
+		{Month: month(2025, time.March), Active: 3, New: 1, Cancelled: 2},
+		{Month: month(2025, time.April), Active: 1},
+	}, activity)
+}
+
+// testRevenueByCurrency bills one service in two currencies, revenue keeps
+// them apart whether it is calculated live or read from the stored months.
+func testRevenueByCurrency(
+	t *testing.T,
+	b *backend,
+	analytics domain.AnalyticsRepository,
+	monthlyCosts domain.MonthlyCostsRepository,
+) {
+	rubles := subscription(uuid.New(), "music", 19900, month(2025, time.January), nil)
+	dollars := subscription(uuid.New(), "music", 299, month(2025, time.February), nil)
+	dollars.Currency = "USD"
+	b.create(rubles)
+	b.create(dollars)
+
+	revenue := func() []domain.MonthlyServiceCost {
+		var costs []domain.MonthlyServiceCost
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			costs, err = analytics.MonthlyRevenue(
+				ctx,
+				c,
+				month(2025, time.January),
+				month(2025, time.February),
+			)
+			return err
+		}))
+		for i := range costs {
+			costs[i].Month = costs[i].Month.UTC()
+		}
+		return costs
+	}
+
+	expected := []domain.MonthlyServiceCost{
+		{Month: month(2025, time.January), Name: "music", Currency: "RUB", Cost: 19900},
+		{Month: month(2025, time.February), Name: "music", Currency: "RUB", Cost: 19900},
+		{Month: month(2025, time.February), Name: "music", Currency: "USD", Cost: 299},
+	}
+	require.Equal(t, expected, revenue())
+
+	b.maintain(monthlyCosts, month(2025, time.December), time.Now())
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, expected, revenue())
+}
@@ -0,0 +1,244 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// RunBudgets runs the budget part of the suite.
+func RunBudgets(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, domain.ConnectionProvider, domain.BudgetsRepository)
+	}{
+		{"crud", testBudgetCRUD},
+		{"list order", testBudgetListOrder},
+		{"rename service", testBudgetRenameService},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			repo := repositories.Budgets
+			tt.test(t, provider, repo)
+		})
+	}
+}
+
+func readBudget(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.BudgetsRepository,
+	id domain.BudgetID,
+) (domain.Budget, error) {
+	var budget domain.Budget
+	err := provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		var err error
+		budget, err = repo.Read(ctx, c, id)
+		return err
+	})
+
+	return budget, err
+}
+
+func listBudgets(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.BudgetsRepository,
+	userID domain.UserID,
+) []domain.Budget {
+	t.Helper()
+
+	var budgets []domain.Budget
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			var err error
+			budgets, err = repo.List(ctx, c, userID)
+			return err
+		}),
+	)
+
+	return budgets
+}
+
+func testBudgetCRUD(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.BudgetsRepository,
+) {
+	budget := domain.Budget{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Period:   domain.BudgetMonthly,
+		Limit:    150000,
+		Currency: domain.DefaultCurrency,
+		Category: pointer.Ref("video"),
+	}
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Create(ctx, c, budget)
+		}),
+	)
+
+	stored, err := readBudget(t, provider, repo, budget.ID)
+	require.NoError(t, err)
+	require.Equal(t, budget, stored)
+
+	require.ErrorIs(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Create(ctx, c, budget)
+		}),
+		domain.ErrAlreadyExists,
+	)
+
+	// Updates keep the user.
+	updated := budget
+	updated.UserID = uuid.New()
+	updated.Period = domain.BudgetYearly
+	updated.Limit = 1200000
+	updated.Currency = "USD"
+	updated.Category = nil
+	updated.ServiceName = pointer.Ref("Music")
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Update(ctx, c, updated)
+		}),
+	)
+	updated.UserID = budget.UserID
+	stored, err = readBudget(t, provider, repo, budget.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated, stored)
+
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Delete(ctx, c, budget.ID)
+		}),
+	)
+	_, err = readBudget(t, provider, repo, budget.ID)
+	require.ErrorIs(t, err, domain.ErrBudgetNotFound)
+	require.ErrorIs(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Delete(ctx, c, budget.ID)
+		}),
+		domain.ErrBudgetNotFound,
+	)
+	require.ErrorIs(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			return repo.Update(ctx, c, budget)
+		}),
+		domain.ErrBudgetNotFound,
+	)
+}
+
+func testBudgetListOrder(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.BudgetsRepository,
+) {
+	userID := uuid.New()
+	budget := func(period domain.BudgetPeriod, category, service *string) domain.Budget {
+		return domain.Budget{
+			ID:          uuid.New(),
+			UserID:      userID,
+			Period:      period,
+			Limit:       1000,
+			Currency:    domain.DefaultCurrency,
+			Category:    category,
+			ServiceName: service,
+		}
+	}
+	video := budget(domain.BudgetMonthly, pointer.Ref("video"), nil)
+	music := budget(domain.BudgetMonthly, nil, pointer.Ref("Music"))
+	yearly := budget(domain.BudgetYearly, nil, nil)
+	monthly := budget(domain.BudgetMonthly, nil, nil)
+	other := budget(domain.BudgetMonthly, nil, nil)
+	other.UserID = uuid.New()
+	for _, budget := range []domain.Budget{video, music, yearly, monthly, other} {
+		require.NoError(
+			t,
+			provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+				return repo.Create(ctx, c, budget)
+			}),
+		)
+	}
+
+	require.Equal(
+		t,
+		[]domain.Budget{monthly, yearly, music, video},
+		listBudgets(t, provider, repo, userID),
+	)
+	require.Empty(t, listBudgets(t, provider, repo, uuid.New()))
+}
+
+func testBudgetRenameService(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.BudgetsRepository,
+) {
+	budget := func(service *string, category *string) domain.Budget {
+		return domain.Budget{
+			ID:          uuid.New(),
+			UserID:      uuid.New(),
+			Period:      domain.BudgetMonthly,
+			Limit:       1000,
+			Currency:    domain.DefaultCurrency,
+			Category:    category,
+			ServiceName: service,
+		}
+	}
+	music := budget(pointer.Ref("Music"), nil)
+	alias := budget(pointer.Ref("yandex  music"), nil)
+	video := budget(pointer.Ref("Video"), nil)
+	category := budget(nil, pointer.Ref("music"))
+	for _, budget := range []domain.Budget{music, alias, video, category} {
+		require.NoError(
+			t,
+			provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+				return repo.Create(ctx, c, budget)
+			}),
+		)
+	}
+
+	var renamed int
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			var err error
+			renamed, err = repo.RenameService(
+				ctx,
+				c,
+				[]string{"music", "yandex music"},
+				"Yandex Music",
+			)
+			return err
+		}),
+	)
+	require.Equal(t, 2, renamed)
+
+	for _, tt := range []struct {
+		budget  domain.Budget
+		service *string
+	}{
+		{music, pointer.Ref("Yandex Music")},
+		{alias, pointer.Ref("Yandex Music")},
+		{video, pointer.Ref("Video")},
+		{category, nil},
+	} {
+		stored, err := readBudget(t, provider, repo, tt.budget.ID)
+		require.NoError(t, err)
+		require.Equal(t, tt.service, stored.ServiceName)
+	}
+}
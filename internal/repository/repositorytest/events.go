@@ -0,0 +1,196 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var errRollback = errors.New("rollback")
+
+// RunEvents runs the history part of the suite. Every test works on fresh
+// subscription and user IDs, so factory may hand out a database with
+// events of earlier runs.
+func RunEvents(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, domain.ConnectionProvider, domain.SubscriptionEventsRepository)
+	}{
+		{"append and list", testAppendAndList},
+		{"seek", testEventSeek},
+		{"rolled back", testEventRolledBack},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			repo := repositories.Events
+			tt.test(t, provider, repo)
+		})
+	}
+}
+
+func newEvent(
+	subscription domain.Subscription,
+	eventType domain.SubscriptionEventType,
+	before, after *domain.Subscription,
+) domain.SubscriptionEvent {
+	return domain.SubscriptionEvent{
+		SubscriptionID: subscription.ID,
+		UserID:         subscription.UserID,
+		Type:           eventType,
+		Before:         before,
+		After:          after,
+		Actor:          "tester",
+		RequestID:      "request-" + subscription.ID.String(),
+	}
+}
+
+func appendEvents(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.SubscriptionEventsRepository,
+	events ...domain.SubscriptionEvent,
+) {
+	t.Helper()
+
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			for _, event := range events {
+				if err := repo.Append(ctx, c, event); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}),
+	)
+}
+
+func listEvents(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.SubscriptionEventsRepository,
+	query domain.EventQuery,
+) []domain.SubscriptionEvent {
+	t.Helper()
+
+	var events []domain.SubscriptionEvent
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			var err error
+			events, err = repo.List(ctx, c, query)
+			return err
+		}),
+	)
+
+	return events
+}
+
+func testAppendAndList(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.SubscriptionEventsRepository,
+) {
+	userID := uuid.New()
+	created := subscription(userID, "Music", 100, month(2025, time.January), nil)
+	updated := created
+	updated.Cost = 200
+	other := subscription(userID, "Video", 300, month(2025, time.March), nil)
+
+	appendEvents(t, provider, repo,
+		newEvent(created, domain.SubscriptionEventCreated, nil, &created),
+		newEvent(other, domain.SubscriptionEventCreated, nil, &other),
+		newEvent(created, domain.SubscriptionEventUpdated, &created, &updated),
+		newEvent(created, domain.SubscriptionEventDeleted, &updated, nil),
+	)
+
+	events := listEvents(t, provider, repo, domain.EventQuery{
+		SubscriptionID: &created.ID,
+		Limit:          10,
+	})
+	require.Len(t, events, 3)
+	require.Equal(t, []domain.SubscriptionEventType{
+		domain.SubscriptionEventDeleted,
+		domain.SubscriptionEventUpdated,
+		domain.SubscriptionEventCreated,
+	}, []domain.SubscriptionEventType{events[0].Type, events[1].Type, events[2].Type})
+	require.Greater(t, events[0].ID, events[1].ID)
+	require.Greater(t, events[1].ID, events[2].ID)
+
+	update := events[1]
+	require.Equal(t, created.ID, update.SubscriptionID)
+	require.Equal(t, userID, update.UserID)
+	require.Equal(t, "tester", update.Actor)
+	require.Equal(t, "request-"+created.ID.String(), update.RequestID)
+	require.False(t, update.OccurredAt.IsZero())
+	require.Equal(t, 100, update.Before.Cost)
+	require.Equal(t, 200, update.After.Cost)
+	require.True(t, created.StartDate.Equal(update.After.StartDate))
+	require.Nil(t, events[0].After)
+	require.Nil(t, events[2].Before)
+
+	events = listEvents(t, provider, repo, domain.EventQuery{UserID: &userID, Limit: 10})
+	require.Len(t, events, 4)
+	require.Equal(t, other.ID, events[2].SubscriptionID)
+}
+
+func testEventSeek(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.SubscriptionEventsRepository,
+) {
+	subscription := subscription(uuid.New(), "Music", 100, month(2025, time.January), nil)
+	for range 5 {
+		appendEvents(t, provider, repo,
+			newEvent(subscription, domain.SubscriptionEventUpdated, &subscription, &subscription),
+		)
+	}
+
+	query := domain.EventQuery{SubscriptionID: &subscription.ID, Limit: 2}
+	var ids []int64
+	for {
+		events := listEvents(t, provider, repo, query)
+		if len(events) == 0 {
+			break
+		}
+		require.LessOrEqual(t, len(events), 2)
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		query.Before = &events[len(events)-1].ID
+	}
+
+	require.Len(t, ids, 5)
+	require.IsDecreasing(t, ids)
+}
+
+func testEventRolledBack(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.SubscriptionEventsRepository,
+) {
+	subscription := subscription(uuid.New(), "Music", 100, month(2025, time.January), nil)
+
+	err := provider.ExecuteTx(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		if err := repo.Append(ctx, c, newEvent(subscription, domain.SubscriptionEventCreated, nil, &subscription)); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	require.Empty(t, listEvents(t, provider, repo, domain.EventQuery{
+		SubscriptionID: &subscription.ID,
+		Limit:          10,
+	}))
+}
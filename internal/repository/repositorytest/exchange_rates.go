@@ -0,0 +1,139 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RunExchangeRates runs the exchange rates part of the suite.
+func RunExchangeRates(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, domain.ConnectionProvider, domain.ExchangeRatesRepository)
+	}{
+		{"save and list", testSaveAndListRates},
+		{"rolled back", testRatesRolledBack},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			repo := repositories.ExchangeRates
+			tt.test(t, provider, repo)
+		})
+	}
+}
+
+func listRates(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.ExchangeRatesRepository,
+	currency domain.Currency,
+	until time.Time,
+) []domain.ExchangeRate {
+	t.Helper()
+
+	var rates []domain.ExchangeRate
+	require.NoError(
+		t,
+		provider.Execute(t.Context(), func(ctx context.Context, c domain.Connection) error {
+			var err error
+			rates, err = repo.List(ctx, c, currency, until)
+			return err
+		}),
+	)
+	for i := range rates {
+		rates[i].EffectiveFrom = rates[i].EffectiveFrom.UTC()
+	}
+
+	return rates
+}
+
+func testSaveAndListRates(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.ExchangeRatesRepository,
+) {
+	save := func(rates ...domain.ExchangeRate) {
+		t.Helper()
+		require.NoError(
+			t,
+			provider.ExecuteTx(t.Context(), func(ctx context.Context, c domain.Connection) error {
+				return repo.Save(ctx, c, rates)
+			}),
+		)
+	}
+
+	save(
+		domain.ExchangeRate{
+			Base:          "USD",
+			Quote:         "RUB",
+			EffectiveFrom: month(2025, time.March),
+			Rate:          80,
+		},
+		domain.ExchangeRate{
+			Base:          "EUR",
+			Quote:         "RUB",
+			EffectiveFrom: month(2025, time.January),
+			Rate:          95.5,
+		},
+		domain.ExchangeRate{
+			Base:          "USD",
+			Quote:         "RUB",
+			EffectiveFrom: month(2025, time.January),
+			Rate:          90,
+		},
+		domain.ExchangeRate{
+			Base:          "USD",
+			Quote:         "EUR",
+			EffectiveFrom: month(2025, time.January),
+			Rate:          0.9,
+		},
+	)
+	// Saving a pair and month again replaces its rate, days are dropped.
+	save(
+		domain.ExchangeRate{
+			Base:          "USD",
+			Quote:         "RUB",
+			EffectiveFrom: date(2025, time.March, 20),
+			Rate:          85.25,
+		},
+	)
+
+	require.Equal(t, []domain.ExchangeRate{
+		{Base: "EUR", Quote: "RUB", EffectiveFrom: month(2025, time.January), Rate: 95.5},
+		{Base: "USD", Quote: "RUB", EffectiveFrom: month(2025, time.January), Rate: 90},
+		{Base: "USD", Quote: "RUB", EffectiveFrom: month(2025, time.March), Rate: 85.25},
+	}, listRates(t, provider, repo, "RUB", month(2025, time.December)))
+
+	require.Equal(t, []domain.ExchangeRate{
+		{Base: "USD", Quote: "EUR", EffectiveFrom: month(2025, time.January), Rate: 0.9},
+		{Base: "USD", Quote: "RUB", EffectiveFrom: month(2025, time.January), Rate: 90},
+	}, listRates(t, provider, repo, "USD", month(2025, time.February)))
+
+	require.Empty(t, listRates(t, provider, repo, "RUB", month(2024, time.December)))
+}
+
+func testRatesRolledBack(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repo domain.ExchangeRatesRepository,
+) {
+	err := provider.ExecuteTx(t.Context(), func(ctx context.Context, c domain.Connection) error {
+		rates := []domain.ExchangeRate{
+			{Base: "USD", Quote: "RUB", EffectiveFrom: month(2025, time.January), Rate: 90},
+		}
+		if err := repo.Save(ctx, c, rates); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	require.Empty(t, listRates(t, provider, repo, "RUB", month(2025, time.December)))
+}
@@ -0,0 +1,212 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// RunMonthlyCosts runs the monthly costs part of the suite.
+func RunMonthlyCosts(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, *backend, domain.ServicesRepository, domain.MonthlyCostsRepository)
+	}{
+		{"follow writes", testMonthlyCostsFollowWrites},
+		{"past the horizon", testMonthlyCostsPastHorizon},
+		{"claim", testMonthlyCostsClaim},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			tt.test(
+				t,
+				newBackend(t, provider, repositories),
+				repositories.Services,
+				repositories.MonthlyCosts,
+			)
+		})
+	}
+}
+
+// maintain runs a maintenance finishing at the time, the way the service
+// does, and requires it to be claimed.
+func (b *backend) maintain(
+	monthlyCosts domain.MonthlyCostsRepository,
+	horizon time.Time,
+	at time.Time,
+) {
+	b.t.Helper()
+
+	require.NoError(b.t, b.provider.ExecuteTx(
+		b.t.Context(),
+		func(ctx context.Context, c domain.Connection) error {
+			claimed, err := monthlyCosts.Claim(ctx, c, at)
+			if err != nil {
+				return err
+			}
+			require.True(b.t, claimed)
+			mismatches, err := monthlyCosts.Mismatches(ctx, c)
+			if err != nil {
+				return err
+			}
+			return monthlyCosts.Maintain(ctx, c, mismatches, horizon, at)
+		},
+	))
+}
+
+func (b *backend) requireStoredCostsMatch(monthlyCosts domain.MonthlyCostsRepository) {
+	b.t.Helper()
+
+	var mismatches []domain.MonthlyCostMismatch
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		mismatches, err = monthlyCosts.Mismatches(ctx, c)
+		return err
+	}))
+	require.Empty(b.t, mismatches)
+}
+
+func testMonthlyCostsFollowWrites(
+	t *testing.T,
+	b *backend,
+	services domain.ServicesRepository,
+	monthlyCosts domain.MonthlyCostsRepository,
+) {
+	user, other := uuid.New(), uuid.New()
+	start, end := month(2025, time.January), month(2025, time.June)
+
+	music := b.create(subscription(user, "music", 100, month(2025, time.January), nil))
+	b.requireStoredCostsMatch(monthlyCosts)
+	b.maintain(monthlyCosts, month(2025, time.December), time.Now())
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 6*100, b.totalCost(user, "", start, end))
+
+	video := b.create(subscription(
+		user,
+		"video",
+		300,
+		month(2025, time.February),
+		pointer.Ref(date(2025, time.March, 20)),
+	))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 6*100+2*300, b.totalCost(user, "", start, end))
+
+	b.schedulePrice(music.ID, month(2025, time.April), 150)
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 3*100+3*150+2*300, b.totalCost(user, "", start, end))
+
+	video.UserID, video.Cost = other, 200
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, video)
+	}))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 3*100+3*150, b.totalCost(user, "", start, end))
+	require.Equal(t, 2*200, b.totalCost(other, "", start, end))
+
+	// Moving the dates refreshes the months of both ranges.
+	video.StartDate, video.EndDate = month(2025, time.May), nil
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, video)
+	}))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 2*200, b.totalCost(other, "", start, end))
+
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, music.ID)
+	}))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Zero(t, b.totalCost(user, "", start, end))
+
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		_, err := b.repo.Restore(ctx, c, music.ID)
+		return err
+	}))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 3*100+3*150, b.totalCost(user, "", start, end))
+
+	catalog := catalogService("Music", "music")
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Create(ctx, c, catalog)
+	}))
+	require.Len(t, b.link(services, catalog), 1)
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(t, 3*100+3*150, b.totalCost(user, "Music", start, end))
+	require.Zero(t, b.totalCost(user, "music", start, end))
+}
+
+func testMonthlyCostsPastHorizon(
+	t *testing.T,
+	b *backend,
+	_ domain.ServicesRepository,
+	monthlyCosts domain.MonthlyCostsRepository,
+) {
+	user := uuid.New()
+	b.create(subscription(user, "music", 100, month(2025, time.January), nil))
+	b.maintain(monthlyCosts, month(2025, time.March), time.Now())
+
+	// Months after the horizon are calculated live.
+	require.Equal(
+		t,
+		3*100,
+		b.totalCost(user, "", month(2025, time.January), month(2025, time.March)),
+	)
+	require.Equal(
+		t,
+		12*100,
+		b.totalCost(user, "", month(2025, time.January), month(2025, time.December)),
+	)
+
+	b.create(subscription(user, "video", 300, month(2025, time.June), nil))
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(
+		t,
+		12*100+7*300,
+		b.totalCost(user, "", month(2025, time.January), month(2025, time.December)),
+	)
+
+	// Moving the horizon stores the months it adds.
+	b.maintain(monthlyCosts, month(2025, time.December), time.Now())
+	b.requireStoredCostsMatch(monthlyCosts)
+	require.Equal(
+		t,
+		12*100+7*300,
+		b.totalCost(user, "", month(2025, time.January), month(2025, time.December)),
+	)
+}
+
+func testMonthlyCostsClaim(
+	t *testing.T,
+	b *backend,
+	_ domain.ServicesRepository,
+	monthlyCosts domain.MonthlyCostsRepository,
+) {
+	claim := func(since time.Time) bool {
+		var claimed bool
+		require.NoError(t, b.provider.ExecuteTx(
+			t.Context(),
+			func(ctx context.Context, c domain.Connection) error {
+				var err error
+				claimed, err = monthlyCosts.Claim(ctx, c, since)
+				return err
+			},
+		))
+		return claimed
+	}
+
+	at := time.Now().UTC().Truncate(time.Second)
+	require.True(t, claim(at))
+	b.maintain(monthlyCosts, month(2025, time.March), at)
+
+	// A maintenance that finished after since is not repeated.
+	require.False(t, claim(at.Add(-time.Hour)))
+	require.True(t, claim(at))
+	require.True(t, claim(at.Add(time.Hour)))
+}
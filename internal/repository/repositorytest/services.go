@@ -0,0 +1,260 @@
+package repositorytest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// RunServices runs the service catalog part of the suite.
+func RunServices(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, *backend, domain.ServicesRepository)
+	}{
+		{"crud", testServiceCRUD},
+		{"resolve", testResolveService},
+		{"link", testLinkService},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			tt.test(t, newBackend(t, provider, repositories), repositories.Services)
+		})
+	}
+}
+
+func catalogService(name string, aliases ...string) domain.Service {
+	return domain.Service{
+		ID:      uuid.New(),
+		Name:    name,
+		Aliases: aliases,
+	}.WithDefaults()
+}
+
+func (b *backend) readService(
+	services domain.ServicesRepository,
+	id domain.ServiceID,
+) (domain.Service, error) {
+	var service domain.Service
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		service, err = services.Read(ctx, c, id)
+		return err
+	})
+
+	return service, err
+}
+
+func (b *backend) resolveService(
+	services domain.ServicesRepository,
+	name domain.ServiceName,
+) (domain.Service, error) {
+	var service domain.Service
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		service, err = services.Resolve(ctx, c, domain.ServiceKey(name))
+		return err
+	})
+
+	return service, err
+}
+
+func (b *backend) link(
+	services domain.ServicesRepository,
+	service domain.Service,
+) []domain.SubscriptionChange {
+	b.t.Helper()
+
+	var linked []domain.SubscriptionChange
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		linked, err = services.Link(ctx, c, service)
+		return err
+	}))
+
+	return linked
+}
+
+func testServiceCRUD(t *testing.T, b *backend, services domain.ServicesRepository) {
+	music := catalogService("Yandex Music", "Яндекс Музыка")
+	music.Category = "music"
+	music.DefaultPrice = pointer.Ref(29900)
+	video := catalogService("Kinopoisk")
+	video.Category = "video"
+	video.BillingPeriod = domain.BillingYearly
+	for _, service := range []domain.Service{music, video} {
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			return services.Create(ctx, c, service)
+		}))
+	}
+
+	stored, err := b.readService(services, music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+
+	var listed, inCategory []domain.Service
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		if listed, err = services.List(ctx, c, domain.ServiceFilter{}); err != nil {
+			return err
+		}
+		inCategory, err = services.List(
+			ctx,
+			c,
+			domain.ServiceFilter{Category: pointer.Ref("video")},
+		)
+		return err
+	}))
+	require.Equal(t, []domain.Service{video, music}, listed)
+	require.Equal(t, []domain.Service{video}, inCategory)
+
+	// A key of another service is rejected.
+	clash := catalogService("Music", "yandex  music")
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Create(ctx, c, clash)
+	}), domain.ErrAlreadyExists)
+
+	music.Name = "Yandex Music Plus"
+	music.Aliases = []string{"Yandex Music"}
+	music.DefaultPrice = nil
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Update(ctx, c, music)
+	}))
+	stored, err = b.readService(services, music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Update(ctx, c, catalogService("Missing"))
+	}), domain.ErrServiceNotFound)
+
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Delete(ctx, c, video.ID)
+	}))
+	_, err = b.readService(services, video.ID)
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Delete(ctx, c, video.ID)
+	}), domain.ErrServiceNotFound)
+}
+
+func testResolveService(t *testing.T, b *backend, services domain.ServicesRepository) {
+	plus := catalogService("Yandex Plus", "Yandex+", "Яндекс Плюс")
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Create(ctx, c, plus)
+	}))
+
+	for _, name := range []domain.ServiceName{
+		"Yandex Plus",
+		"  yandex   PLUS ",
+		"yandex+",
+		"яндекс плюс",
+	} {
+		resolved, err := b.resolveService(services, name)
+		require.NoError(t, err, name)
+		require.Equal(t, plus, resolved, name)
+	}
+
+	_, err := b.resolveService(services, "Yandex")
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+
+	// Dropped aliases no longer resolve.
+	plus.Aliases = []string{"Yandex+"}
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Update(ctx, c, plus)
+	}))
+	_, err = b.resolveService(services, "Яндекс Плюс")
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+}
+
+func testLinkService(t *testing.T, b *backend, services domain.ServicesRepository) {
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	// Two live spellings of the same service overlap for the first user, so
+	// neither is renamed.
+	spaced := b.create(subscription(
+		first,
+		"yandex plus ",
+		100,
+		month(2025, time.January),
+		pointer.Ref(month(2025, time.March)),
+	))
+	shouting := b.create(subscription(first, "YANDEX PLUS", 100, month(2025, time.February), nil))
+
+	alias := b.create(subscription(second, "Yandex+", 100, month(2025, time.January), nil))
+	deleted := b.create(subscription(second, "yandex+ ", 100, month(2025, time.January), nil))
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, deleted.ID)
+	}))
+
+	canonical := b.create(subscription(third, "Yandex Plus", 100, month(2025, time.January), nil))
+	other := b.create(subscription(third, "Music", 100, month(2025, time.January), nil))
+
+	plus := catalogService("Yandex Plus", "Yandex+")
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Create(ctx, c, plus)
+	}))
+	changes := b.link(services, plus)
+	require.Len(t, changes, 3)
+	require.Empty(t, b.link(services, plus))
+
+	names := func(userID domain.UserID) map[domain.SubscriptionID]domain.Subscription {
+		byID := make(map[domain.SubscriptionID]domain.Subscription)
+		for _, subscription := range b.readAll(
+			domain.SubscriptionFilter{UserID: userID, IncludeDeleted: true},
+			domain.Pagination{Limit: 10, Sort: domain.DefaultSubscriptionSort},
+		) {
+			byID[subscription.ID] = subscription
+		}
+		return byID
+	}
+	linked := func(subscription domain.Subscription, name domain.ServiceName) {
+		t.Helper()
+		require.Equal(t, name, subscription.Name)
+		require.Equal(t, &plus.ID, subscription.ServiceID)
+	}
+
+	firsts := names(first)
+	require.Equal(t, spaced.Name, firsts[spaced.ID].Name)
+	require.Nil(t, firsts[spaced.ID].ServiceID)
+	require.Equal(t, shouting.Name, firsts[shouting.ID].Name)
+	require.Nil(t, firsts[shouting.ID].ServiceID)
+
+	seconds := names(second)
+	linked(seconds[alias.ID], "Yandex Plus")
+	linked(seconds[deleted.ID], "Yandex Plus")
+
+	thirds := names(third)
+	linked(thirds[canonical.ID], "Yandex Plus")
+	require.Nil(t, thirds[other.ID].ServiceID)
+
+	// Changes carry the previous name and the stored state after the link.
+	i := slices.IndexFunc(changes, func(change domain.SubscriptionChange) bool {
+		return change.After.ID == alias.ID
+	})
+	require.NotEqual(t, -1, i)
+	require.Equal(t, alias.Name, changes[i].Before.Name)
+	require.Nil(t, changes[i].Before.ServiceID)
+	require.Equal(t, seconds[alias.ID], changes[i].After)
+
+	// Linked subscriptions follow a rename.
+	plus.Name = "Yandex Plus Multi"
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Update(ctx, c, plus)
+	}))
+	require.Len(t, b.link(services, plus), 3)
+	linked(names(third)[canonical.ID], "Yandex Plus Multi")
+
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return services.Delete(ctx, c, plus.ID)
+	}), domain.ErrServiceInUse)
+}
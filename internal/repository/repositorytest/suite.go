@@ -0,0 +1,1292 @@
+// Package repositorytest is a conformance suite for the domain repository
+// implementations. Every backend runs it, so they all behave the same.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/infra/pointer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns an empty backend for a single test, cleanup is registered
+// on t. The repositories share the storage of the provider, so tags, catalog
+// links and figures cover the subscriptions of the same backend.
+type Factory func(t *testing.T) (domain.ConnectionProvider, domain.Repositories)
+
+// Run runs the suite against the backends made by factory. Subtests do not
+// run in parallel, so factory may hand out one shared database.
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, *backend)
+	}{
+		{"crud", testCRUD},
+		{"not found", testNotFound},
+		{"duplicate id", testDuplicateID},
+		{"pagination order", testPaginationOrder},
+		{"offset", testOffset},
+		{"filter", testFilter},
+		{"total cost", testTotalCost},
+		{"cost breakdowns", testCostBreakdowns},
+		{"price changes", testPriceChanges},
+		{"billing periods", testBillingPeriods},
+		{"monthly charges", testMonthlyCharges},
+		{"billed currencies", testBilledCurrencies},
+		{"discounts", testDiscounts},
+		{"categories and tags", testCategoriesAndTags},
+		{"overlapping", testOverlapping},
+		{"soft delete", testSoftDelete},
+		{"purge", testPurge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			tt.test(t, newBackend(t, provider, repositories))
+		})
+	}
+}
+
+// backend runs every repository call on a connection of its own.
+type backend struct {
+	t        *testing.T
+	provider domain.ConnectionProvider
+	repo     domain.SubscriptionsRepository
+}
+
+func newBackend(
+	t *testing.T,
+	provider domain.ConnectionProvider,
+	repositories domain.Repositories,
+) *backend {
+	return &backend{t: t, provider: provider, repo: repositories.Subscriptions}
+}
+
+func (b *backend) do(fn func(context.Context, domain.Connection) error) error {
+	return b.provider.Execute(b.t.Context(), fn)
+}
+
+func (b *backend) create(subscription domain.Subscription) domain.Subscription {
+	b.t.Helper()
+
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Create(ctx, c, subscription)
+	}))
+
+	return subscription
+}
+
+func (b *backend) read(id domain.SubscriptionID) (domain.Subscription, error) {
+	var subscription domain.Subscription
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		subscription, err = b.repo.Read(ctx, c, id)
+		return err
+	})
+
+	return subscription, err
+}
+
+func (b *backend) readAll(
+	filter domain.SubscriptionFilter,
+	pagination domain.Pagination,
+) []domain.Subscription {
+	b.t.Helper()
+
+	var subscriptions []domain.Subscription
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		subscriptions, err = b.repo.ReadAll(ctx, c, filter, pagination)
+		return err
+	}))
+
+	return subscriptions
+}
+
+func (b *backend) totalCost(
+	userID domain.UserID,
+	name domain.ServiceName,
+	start, end time.Time,
+) int {
+	b.t.Helper()
+
+	var total int
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		total, err = b.repo.CalculateTotalCost(ctx, c, userID, name, start, &end)
+		return err
+	}))
+
+	return total
+}
+
+func month(year int, m time.Month) time.Time {
+	return date(year, m, 1)
+}
+
+func date(year int, m time.Month, day int) time.Time {
+	return time.Date(year, m, day, 0, 0, 0, 0, time.UTC)
+}
+
+func subscription(
+	userID domain.UserID,
+	name domain.ServiceName,
+	cost int,
+	start time.Time,
+	end *time.Time,
+) domain.Subscription {
+	return domain.Subscription{
+		ID:            uuid.New(),
+		Name:          name,
+		Cost:          cost,
+		UserID:        userID,
+		StartDate:     start,
+		EndDate:       end,
+		BillingPeriod: domain.BillingMonthly,
+		BillingAnchor: domain.MonthStart(start),
+		Currency:      domain.DefaultCurrency,
+	}
+}
+
+func testCRUD(t *testing.T, b *backend) {
+	userID := uuid.New()
+	created := b.create(subscription(userID, "music", 100, month(2025, time.January), nil))
+
+	stored, err := b.read(created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created, stored)
+
+	updated := created
+	updated.Cost = 150
+	updated.StartDate = month(2025, time.February)
+	updated.EndDate = pointer.Ref(date(2025, time.June, 30))
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, updated)
+	}))
+
+	stored, err = b.read(created.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated, stored)
+
+	require.Equal(
+		t,
+		[]domain.Subscription{updated},
+		b.readAll(domain.SubscriptionFilter{UserID: userID}, domain.Pagination{Limit: 10}),
+	)
+
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, created.ID)
+	}))
+
+	_, err = b.read(created.ID)
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	require.Empty(
+		t,
+		b.readAll(domain.SubscriptionFilter{UserID: userID}, domain.Pagination{Limit: 10}),
+	)
+}
+
+func testNotFound(t *testing.T, b *backend) {
+	missing := subscription(uuid.New(), "music", 100, month(2025, time.January), nil)
+
+	_, err := b.read(missing.ID)
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	require.Equal(t, domain.ErrorKindNotFound, domain.KindOf(err))
+
+	err = b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, missing)
+	})
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+	err = b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, missing.ID)
+	})
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+}
+
+func testDuplicateID(t *testing.T, b *backend) {
+	first := b.create(subscription(uuid.New(), "music", 100, month(2025, time.January), nil))
+
+	duplicate := subscription(uuid.New(), "video", 100, month(2025, time.January), nil)
+	duplicate.ID = first.ID
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Create(ctx, c, duplicate)
+	})
+	require.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func testPaginationOrder(t *testing.T, b *backend) {
+	userID := uuid.New()
+	for i, name := range []domain.ServiceName{"cloud", "music", "video", "books", "games", "news"} {
+		var end *time.Time
+		if i%2 == 0 {
+			end = pointer.Ref(month(2025, time.Month(6+i)))
+		}
+		// Prices and start dates repeat, so the id has to break ties.
+		b.create(subscription(userID, name, 100*(i%3), month(2025, time.Month(1+i%2)), end))
+	}
+	filter := domain.SubscriptionFilter{UserID: userID}
+
+	fields := []domain.SubscriptionSortField{
+		domain.SortByStartDate,
+		domain.SortByEndDate,
+		domain.SortByPrice,
+		domain.SortByServiceName,
+	}
+	for _, field := range fields {
+		for _, descending := range []bool{false, true} {
+			sort := domain.SubscriptionSort{Field: field, Descending: descending}
+			t.Run(fmt.Sprintf("%s descending %t", field, descending), func(t *testing.T) {
+				all := b.readAll(filter, domain.Pagination{Limit: 100, Sort: sort})
+				require.Len(t, all, 6)
+				require.True(t, slices.IsSortedFunc(all, func(x, y domain.Subscription) int {
+					return compare(sort, x, y)
+				}), all)
+
+				var paged []domain.Subscription
+				pagination := domain.Pagination{Limit: 4, Sort: sort}
+				for {
+					page := b.readAll(filter, pagination)
+					if len(page) == 0 {
+						break
+					}
+					paged = append(paged, page...)
+
+					cursor := domain.NewSubscriptionCursor(sort, page[len(page)-1])
+					pagination.After = &cursor
+				}
+				require.Equal(t, all, paged)
+			})
+		}
+	}
+}
+
+func testOffset(t *testing.T, b *backend) {
+	userID := uuid.New()
+	for i := range 5 {
+		b.create(
+			subscription(
+				userID,
+				"music",
+				100,
+				month(2020+i, time.January),
+				pointer.Ref(month(2020+i, time.June)),
+			),
+		)
+	}
+	filter := domain.SubscriptionFilter{UserID: userID}
+	sort := domain.SubscriptionSort{Field: domain.SortByStartDate}
+
+	all := b.readAll(filter, domain.Pagination{Limit: 100, Sort: sort})
+	require.Equal(
+		t,
+		all[1:3],
+		b.readAll(filter, domain.Pagination{Limit: 2, Offset: 1, Sort: sort}),
+	)
+	require.Empty(t, b.readAll(filter, domain.Pagination{Limit: 2, Offset: 5, Sort: sort}))
+
+	var count int
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		count, err = b.repo.Count(ctx, c, filter)
+		return err
+	}))
+	require.Equal(t, 5, count)
+}
+
+func testFilter(t *testing.T, b *backend) {
+	userID := uuid.New()
+	cheap := b.create(subscription(userID, "yandex plus", 100, month(2025, time.January), nil))
+	expensive := b.create(subscription(userID, "yandex_music", 500, month(2025, time.March), nil))
+	ended := b.create(subscription(
+		userID,
+		"netflix",
+		300,
+		month(2024, time.January),
+		pointer.Ref(date(2024, time.May, 15)),
+	))
+	b.create(subscription(uuid.New(), "yandex plus", 100, month(2025, time.January), nil))
+
+	matching := func(filter domain.SubscriptionFilter) []domain.Subscription {
+		filter.UserID = userID
+		return b.readAll(filter, domain.Pagination{
+			Limit: 100,
+			Sort:  domain.SubscriptionSort{Field: domain.SortByPrice},
+		})
+	}
+
+	require.Equal(
+		t,
+		[]domain.Subscription{cheap, expensive},
+		matching(domain.SubscriptionFilter{ServiceNamePrefix: pointer.Ref("yandex")}),
+	)
+	// The underscore is matched literally.
+	require.Equal(
+		t,
+		[]domain.Subscription{expensive},
+		matching(domain.SubscriptionFilter{ServiceNamePrefix: pointer.Ref("yandex_")}),
+	)
+	require.Equal(
+		t,
+		[]domain.Subscription{ended},
+		matching(domain.SubscriptionFilter{ServiceName: pointer.Ref("netflix")}),
+	)
+	require.Equal(
+		t,
+		[]domain.Subscription{ended, expensive},
+		matching(domain.SubscriptionFilter{MinPrice: pointer.Ref(200), MaxPrice: pointer.Ref(500)}),
+	)
+	// Active at any day of May 2024, the end month counts as a whole.
+	require.Equal(
+		t,
+		[]domain.Subscription{ended},
+		matching(domain.SubscriptionFilter{ActiveAt: pointer.Ref(date(2024, time.May, 31))}),
+	)
+	require.Equal(
+		t,
+		[]domain.Subscription{cheap, expensive},
+		matching(domain.SubscriptionFilter{
+			StartFrom: pointer.Ref(month(2025, time.January)),
+			StartTo:   pointer.Ref(month(2025, time.March)),
+		}),
+	)
+	require.Equal(
+		t,
+		[]domain.Subscription{ended},
+		matching(domain.SubscriptionFilter{Status: pointer.Ref(domain.SubscriptionStatusEnded)}),
+	)
+}
+
+func testTotalCost(t *testing.T, b *backend) {
+	userID := uuid.New()
+	// Open-ended since November 2024.
+	b.create(subscription(userID, "music", 100, month(2024, time.November), nil))
+	// February and March 2025, mid-month dates bill whole months.
+	b.create(subscription(
+		userID,
+		"video",
+		300,
+		date(2025, time.February, 20),
+		pointer.Ref(date(2025, time.March, 3)),
+	))
+	// Across the new year.
+	b.create(subscription(
+		userID,
+		"cloud",
+		10,
+		month(2024, time.October),
+		pointer.Ref(month(2025, time.February)),
+	))
+	// Another user is never billed.
+	b.create(subscription(uuid.New(), "music", 1000, month(2024, time.January), nil))
+
+	tests := []struct {
+		name       string
+		service    domain.ServiceName
+		start, end time.Time
+		want       int
+	}{
+		{
+			name:  "open-ended and partial overlap",
+			start: month(2025, time.January), end: month(2025, time.April),
+			want: 4*100 + 2*300 + 2*10,
+		},
+		{
+			name:    "open-ended subscription only",
+			service: "music",
+			start:   month(2025, time.January), end: month(2025, time.April),
+			want: 4 * 100,
+		},
+		{
+			name:    "year boundary",
+			service: "cloud",
+			start:   month(2024, time.December), end: month(2025, time.January),
+			want: 2 * 10,
+		},
+		{
+			name:    "period starts before the subscription",
+			service: "music",
+			start:   month(2024, time.January), end: month(2024, time.December),
+			want: 2 * 100,
+		},
+		{
+			name:  "mid-month period bounds",
+			start: date(2025, time.February, 15), end: date(2025, time.March, 1),
+			want: 2*100 + 2*300 + 10,
+		},
+		{
+			name:    "single month",
+			service: "video",
+			start:   date(2025, time.March, 31), end: date(2025, time.March, 31),
+			want: 300,
+		},
+		{
+			name:  "before any subscription",
+			start: month(2020, time.January), end: month(2020, time.December),
+			want: 0,
+		},
+		{
+			name:    "unknown service",
+			service: "games",
+			start:   month(2025, time.January), end: month(2025, time.April),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, b.totalCost(userID, tt.service, tt.start, tt.end))
+		})
+	}
+}
+
+func testCostBreakdowns(t *testing.T, b *backend) {
+	userID := uuid.New()
+	b.create(subscription(
+		userID,
+		"music",
+		100,
+		month(2024, time.November),
+		pointer.Ref(date(2025, time.January, 31)),
+	))
+	b.create(subscription(userID, "music", 120, month(2025, time.February), nil))
+	b.create(subscription(
+		userID,
+		"video",
+		300,
+		month(2025, time.February),
+		pointer.Ref(month(2025, time.March)),
+	))
+
+	start, end := month(2025, time.January), month(2025, time.April)
+
+	var byService []domain.ServiceTotalCost
+	var monthly []domain.MonthlyServiceCost
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		if byService, err = b.repo.CalculateTotalCostByService(ctx, c, userID, "", start, &end); err != nil {
+			return err
+		}
+		monthly, err = b.repo.CalculateMonthlyCosts(ctx, c, userID, "", start, &end)
+		return err
+	}))
+
+	require.Equal(t, []domain.ServiceTotalCost{
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       1,
+			Price:         100,
+			Cost:          100,
+		},
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       3,
+			Price:         120,
+			Cost:          360,
+		},
+		{
+			Name:          "video",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       2,
+			Price:         300,
+			Cost:          600,
+		},
+	}, byService)
+
+	for i := range monthly {
+		monthly[i].Month = monthly[i].Month.UTC()
+	}
+	require.ElementsMatch(t, []domain.MonthlyServiceCost{
+		{Month: month(2025, time.January), Name: "music", Cost: 100},
+		{Month: month(2025, time.February), Name: "music", Cost: 120},
+		{Month: month(2025, time.February), Name: "video", Cost: 300},
+		{Month: month(2025, time.March), Name: "music", Cost: 120},
+		{Month: month(2025, time.March), Name: "video", Cost: 300},
+		{Month: month(2025, time.April), Name: "music", Cost: 120},
+	}, monthly)
+
+	require.Equal(t, 100+360+600, b.totalCost(userID, "", start, end))
+}
+
+func (b *backend) schedulePrice(id domain.SubscriptionID, effective time.Time, price int) {
+	b.t.Helper()
+
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.SchedulePrice(ctx, c, domain.PriceChange{
+			SubscriptionID: id,
+			EffectiveFrom:  effective,
+			Price:          price,
+		})
+	}))
+}
+
+func testPriceChanges(t *testing.T, b *backend) {
+	userID := uuid.New()
+	music := b.create(subscription(userID, "music", 100, month(2025, time.January), nil))
+	video := b.create(subscription(
+		userID,
+		"video",
+		300,
+		month(2025, time.March),
+		pointer.Ref(month(2025, time.May)),
+	))
+
+	b.schedulePrice(music.ID, date(2025, time.April, 15), 150)
+	b.schedulePrice(music.ID, month(2025, time.July), 200)
+	// Rescheduling the same month replaces the price.
+	b.schedulePrice(music.ID, month(2025, time.July), 180)
+	// A change in the start month replaces the initial price, one before it
+	// is never billed.
+	b.schedulePrice(video.ID, month(2025, time.March), 250)
+	b.schedulePrice(video.ID, month(2025, time.January), 1000)
+
+	var prices []domain.PriceChange
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		prices, err = b.repo.ReadPrices(ctx, c, music.ID)
+		return err
+	}))
+	require.Len(t, prices, 2)
+	require.Equal(t, music.ID, prices[0].SubscriptionID)
+	require.True(t, month(2025, time.April).Equal(prices[0].EffectiveFrom))
+	require.Equal(t, 150, prices[0].Price)
+	require.True(t, month(2025, time.July).Equal(prices[1].EffectiveFrom))
+	require.Equal(t, 180, prices[1].Price)
+
+	// Reads carry the price changes, so the current price can be told.
+	read, err := b.read(music.ID)
+	require.NoError(t, err)
+	require.Len(t, read.Prices, 2)
+	require.Equal(t, 180, read.PriceAt(month(2025, time.August)))
+	listed := b.readAll(
+		domain.SubscriptionFilter{UserID: userID},
+		domain.Pagination{Limit: 10, Sort: domain.DefaultSubscriptionSort},
+	)
+	require.Len(t, listed, 2)
+	for _, subscription := range listed {
+		if subscription.ID == music.ID {
+			require.Equal(t, 180, subscription.PriceAt(month(2025, time.August)))
+		}
+	}
+
+	start, end := month(2025, time.February), month(2025, time.August)
+
+	var byService []domain.ServiceTotalCost
+	var monthly []domain.MonthlyServiceCost
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		if byService, err = b.repo.CalculateTotalCostByService(ctx, c, userID, "", start, &end); err != nil {
+			return err
+		}
+		monthly, err = b.repo.CalculateMonthlyCosts(ctx, c, userID, "music", start, &end)
+		return err
+	}))
+
+	require.Equal(t, []domain.ServiceTotalCost{
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       2,
+			Price:         100,
+			Cost:          200,
+		},
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       3,
+			Price:         150,
+			Cost:          450,
+		},
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       2,
+			Price:         180,
+			Cost:          360,
+		},
+		{
+			Name:          "video",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       3,
+			Price:         250,
+			Cost:          750,
+		},
+	}, byService)
+
+	for i := range monthly {
+		monthly[i].Month = monthly[i].Month.UTC()
+	}
+	require.Equal(t, []domain.MonthlyServiceCost{
+		{Month: month(2025, time.February), Name: "music", Cost: 100},
+		{Month: month(2025, time.March), Name: "music", Cost: 100},
+		{Month: month(2025, time.April), Name: "music", Cost: 150},
+		{Month: month(2025, time.May), Name: "music", Cost: 150},
+		{Month: month(2025, time.June), Name: "music", Cost: 150},
+		{Month: month(2025, time.July), Name: "music", Cost: 180},
+		{Month: month(2025, time.August), Name: "music", Cost: 180},
+	}, monthly)
+
+	require.Equal(t, 200+450+360+750, b.totalCost(userID, "", start, end))
+	require.Equal(
+		t,
+		150+150+180,
+		b.totalCost(userID, "music", month(2025, time.May), month(2025, time.July)),
+	)
+}
+
+func testBillingPeriods(t *testing.T, b *backend) {
+	userID := uuid.New()
+
+	// Charged every seven days from January 1st: five times in January, four
+	// in February and March, 53 times in 2025 with the last on December 31st.
+	weekly := subscription(userID, "news", 10, month(2025, time.January), nil)
+	weekly.BillingPeriod = domain.BillingWeekly
+	b.create(weekly)
+
+	// Anchored to January, so charged in April, July and October.
+	quarterly := subscription(userID, "cloud", 90, month(2025, time.February), nil)
+	quarterly.BillingPeriod = domain.BillingQuarterly
+	quarterly.BillingAnchor = month(2025, time.January)
+	b.create(quarterly)
+
+	// Anchored to the start month, charged every March.
+	yearly := subscription(userID, "video", 1200, month(2024, time.March), nil)
+	yearly.BillingPeriod = domain.BillingYearly
+	b.create(yearly)
+
+	stored, err := b.read(quarterly.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.BillingQuarterly, stored.BillingPeriod)
+	require.True(t, month(2025, time.January).Equal(stored.BillingAnchor))
+
+	start, end := month(2025, time.January), month(2025, time.December)
+
+	var byService []domain.ServiceTotalCost
+	var monthly []domain.MonthlyServiceCost
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		if byService, err = b.repo.CalculateTotalCostByService(ctx, c, userID, "", start, &end); err != nil {
+			return err
+		}
+		monthly, err = b.repo.CalculateMonthlyCosts(
+			ctx,
+			c,
+			userID,
+			"news",
+			start,
+			pointer.Ref(month(2025, time.March)),
+		)
+		return err
+	}))
+
+	require.Equal(t, []domain.ServiceTotalCost{
+		{
+			Name:          "cloud",
+			BillingPeriod: domain.BillingQuarterly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       3,
+			Price:         90,
+			Cost:          270,
+		},
+		{
+			Name:          "news",
+			BillingPeriod: domain.BillingWeekly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       53,
+			Price:         10,
+			Cost:          530,
+		},
+		{
+			Name:          "video",
+			BillingPeriod: domain.BillingYearly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       1,
+			Price:         1200,
+			Cost:          1200,
+		},
+	}, byService)
+
+	for i := range monthly {
+		monthly[i].Month = monthly[i].Month.UTC()
+	}
+	require.Equal(t, []domain.MonthlyServiceCost{
+		{Month: month(2025, time.January), Name: "news", Cost: 50},
+		{Month: month(2025, time.February), Name: "news", Cost: 40},
+		{Month: month(2025, time.March), Name: "news", Cost: 40},
+	}, monthly)
+
+	require.Equal(t, 270+530+1200, b.totalCost(userID, "", start, end))
+	require.Zero(
+		t,
+		b.totalCost(userID, "video", month(2025, time.April), month(2025, time.December)),
+	)
+}
+
+func testMonthlyCharges(t *testing.T, b *backend) {
+	userID := uuid.New()
+	music := subscription(userID, "music", 999, month(2025, time.January), nil)
+	music.Currency = "USD"
+	b.create(music)
+	b.schedulePrice(music.ID, month(2025, time.March), 1299)
+
+	video := subscription(userID, "video", 3000, month(2025, time.February), nil)
+	video.BillingPeriod = domain.BillingQuarterly
+	b.create(video)
+
+	var charges []domain.MonthlyCharges
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		charges, err = b.repo.CalculateMonthlyCharges(
+			ctx,
+			c,
+			userID,
+			"",
+			month(2025, time.February),
+			pointer.Ref(month(2025, time.April)),
+		)
+		return err
+	}))
+	for i := range charges {
+		charges[i].Month = charges[i].Month.UTC()
+	}
+
+	require.Equal(t, []domain.MonthlyCharges{
+		{
+			Month:         month(2025, time.February),
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      "USD",
+			Price:         999,
+			Charges:       1,
+		},
+		{
+			Month:         month(2025, time.February),
+			Name:          "video",
+			BillingPeriod: domain.BillingQuarterly,
+			Currency:      domain.DefaultCurrency,
+			Price:         3000,
+			Charges:       1,
+		},
+		{
+			Month:         month(2025, time.March),
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      "USD",
+			Price:         1299,
+			Charges:       1,
+		},
+		{
+			Month:         month(2025, time.April),
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      "USD",
+			Price:         1299,
+			Charges:       1,
+		},
+	}, charges)
+}
+
+func testBilledCurrencies(t *testing.T, b *backend) {
+	userID := uuid.New()
+	music := subscription(
+		userID,
+		"music",
+		999,
+		date(2025, time.January, 20),
+		pointer.Ref(date(2025, time.March, 10)),
+	)
+	music.Currency = "USD"
+	b.create(music)
+	b.create(subscription(userID, "video", 3000, month(2025, time.April), nil))
+	euro := subscription(userID, "cloud", 500, month(2025, time.January), nil)
+	euro.Currency = "EUR"
+	b.create(euro)
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, euro.ID)
+	}))
+	b.create(subscription(uuid.New(), "music", 100, month(2025, time.January), nil))
+
+	billedCurrencies := func(name domain.ServiceName, start, end time.Time) []domain.Currency {
+		t.Helper()
+
+		var currencies []domain.Currency
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			currencies, err = b.repo.BilledCurrencies(ctx, c, userID, name, start, &end)
+			return err
+		}))
+
+		return currencies
+	}
+
+	require.Equal(
+		t,
+		[]domain.Currency{domain.DefaultCurrency, "USD"},
+		billedCurrencies("", month(2025, time.January), month(2025, time.December)),
+	)
+	// Months are whole, the days of the end dates do not matter.
+	require.Equal(
+		t,
+		[]domain.Currency{"USD"},
+		billedCurrencies("", month(2025, time.January), month(2025, time.January)),
+	)
+	require.Equal(
+		t,
+		[]domain.Currency{domain.DefaultCurrency},
+		billedCurrencies("", date(2025, time.April, 15), month(2025, time.December)),
+	)
+	require.Equal(
+		t,
+		[]domain.Currency{"USD"},
+		billedCurrencies("music", month(2025, time.January), month(2025, time.December)),
+	)
+	require.Empty(t, billedCurrencies("", month(2024, time.January), month(2024, time.December)))
+}
+
+func testDiscounts(t *testing.T, b *backend) {
+	userID := uuid.New()
+	music := subscription(userID, "music", 1000, month(2025, time.January), nil)
+	music.Discounts = []domain.Discount{
+		{
+			SubscriptionID: music.ID,
+			Kind:           domain.DiscountTrial,
+			StartMonth:     month(2025, time.January),
+			EndMonth:       month(2025, time.February),
+		},
+		{
+			SubscriptionID: music.ID,
+			Kind:           domain.DiscountPercent,
+			Value:          25,
+			StartMonth:     month(2025, time.March),
+			EndMonth:       month(2025, time.March),
+		},
+		{
+			SubscriptionID: music.ID,
+			Kind:           domain.DiscountFixed,
+			Value:          1500,
+			StartMonth:     month(2025, time.May),
+			EndMonth:       month(2025, time.June),
+		},
+	}
+	b.create(music)
+
+	stored, err := b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+
+	// Free in January and February, 750 in March, full price in April and
+	// nothing left after the fixed discount in May and June.
+	require.Equal(
+		t,
+		750+1000,
+		b.totalCost(userID, "", month(2025, time.January), month(2025, time.June)),
+	)
+
+	var byService []domain.ServiceTotalCost
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		byService, err = b.repo.CalculateTotalCostByService(
+			ctx,
+			c,
+			userID,
+			"",
+			month(2025, time.March),
+			pointer.Ref(month(2025, time.July)),
+		)
+		return err
+	}))
+	require.Equal(t, []domain.ServiceTotalCost{
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       2,
+			Price:         0,
+			Cost:          0,
+		},
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       1,
+			Price:         750,
+			Cost:          750,
+		},
+		{
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Charges:       2,
+			Price:         1000,
+			Cost:          2000,
+		},
+	}, byService)
+
+	// Updates replace the discounts as a whole.
+	music.Discounts = music.Discounts[1:2]
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, music)
+	}))
+	stored, err = b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+	require.Equal(
+		t,
+		1000+750,
+		b.totalCost(userID, "", month(2025, time.February), month(2025, time.March)),
+	)
+}
+
+func testCategoriesAndTags(t *testing.T, b *backend) {
+	userID := uuid.New()
+	music := subscription(userID, "music", 1000, month(2025, time.January), nil)
+	music.Category = "entertainment"
+	music.Tags = []string{"Family", "work"}
+	b.create(music)
+	video := subscription(userID, "video", 500, month(2025, time.March), nil)
+	video.Category = "entertainment"
+	video.Tags = []string{"family"}
+	b.create(video)
+	cloud := b.create(subscription(userID, "cloud", 200, month(2025, time.January), nil))
+
+	stored, err := b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+
+	// The tag is matched ignoring case and keeps its first spelling.
+	stored, err = b.read(video.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Family"}, stored.Tags)
+	video.Tags = stored.Tags
+
+	matching := func(filter domain.SubscriptionFilter) []domain.Subscription {
+		filter.UserID = userID
+		return b.readAll(filter, domain.Pagination{
+			Limit: 100,
+			Sort:  domain.SubscriptionSort{Field: domain.SortByPrice},
+		})
+	}
+	require.Equal(
+		t,
+		[]domain.Subscription{video, music},
+		matching(domain.SubscriptionFilter{Category: pointer.Ref("entertainment")}),
+	)
+	require.Equal(
+		t,
+		[]domain.Subscription{music},
+		matching(domain.SubscriptionFilter{Tag: pointer.Ref(" WORK ")}),
+	)
+	require.Empty(t, matching(domain.SubscriptionFilter{Tag: pointer.Ref("fun")}))
+
+	byGroup := func(group domain.CostGroup) []domain.GroupCost {
+		var groups []domain.GroupCost
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			groups, err = b.repo.CalculateTotalCostByGroup(
+				ctx,
+				c,
+				userID,
+				"",
+				month(2025, time.January),
+				pointer.Ref(month(2025, time.April)),
+				group,
+			)
+			return err
+		}))
+		return groups
+	}
+	require.Equal(t, []domain.GroupCost{
+		{Key: "", Cost: 4 * 200},
+		{Key: "entertainment", Cost: 4*1000 + 2*500},
+	}, byGroup(domain.CostGroupCategory))
+	// Subscriptions count in each of their tags.
+	require.Equal(t, []domain.GroupCost{
+		{Key: "", Cost: 4 * 200},
+		{Key: "Family", Cost: 4*1000 + 2*500},
+		{Key: "work", Cost: 4 * 1000},
+	}, byGroup(domain.CostGroupTag))
+
+	var charges []domain.MonthlyCharges
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		charges, err = b.repo.CalculateCategoryCharges(
+			ctx,
+			c,
+			userID,
+			"entertainment",
+			month(2025, time.March),
+			pointer.Ref(month(2025, time.March)),
+		)
+		return err
+	}))
+	for i := range charges {
+		charges[i].Month = charges[i].Month.UTC()
+	}
+	require.Equal(t, []domain.MonthlyCharges{
+		{
+			Month:         month(2025, time.March),
+			Name:          "music",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Price:         1000,
+			Charges:       1,
+		},
+		{
+			Month:         month(2025, time.March),
+			Name:          "video",
+			BillingPeriod: domain.BillingMonthly,
+			Currency:      domain.DefaultCurrency,
+			Price:         500,
+			Charges:       1,
+		},
+	}, charges)
+
+	// Updates replace the tags as a whole.
+	cloud.Tags = []string{"work"}
+	music.Tags = nil
+	for _, subscription := range []domain.Subscription{cloud, music} {
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			return b.repo.Update(ctx, c, subscription)
+		}))
+	}
+	require.Equal(
+		t,
+		[]domain.Subscription{cloud},
+		matching(domain.SubscriptionFilter{Tag: pointer.Ref("work")}),
+	)
+	stored, err = b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, music, stored)
+}
+
+func testOverlapping(t *testing.T, b *backend) {
+	userID := uuid.New()
+	closed := b.create(subscription(
+		userID,
+		"music",
+		100,
+		month(2024, time.January),
+		pointer.Ref(date(2024, time.June, 30)),
+	))
+	open := b.create(subscription(userID, "music", 100, month(2025, time.January), nil))
+	b.create(subscription(userID, "video", 100, month(2020, time.January), nil))
+
+	overlapping := func(candidate domain.Subscription) []domain.Subscription {
+		var found []domain.Subscription
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			found, err = b.repo.ReadOverlapping(ctx, c, candidate)
+			return err
+		}))
+
+		return found
+	}
+
+	tests := []struct {
+		name      string
+		candidate domain.Subscription
+		want      []domain.Subscription
+	}{
+		{
+			name: "back-dated",
+			candidate: subscription(
+				userID,
+				"music",
+				100,
+				month(2023, time.June),
+				pointer.Ref(month(2024, time.February)),
+			),
+			want: []domain.Subscription{closed},
+		},
+		{
+			name:      "open-ended predecessor",
+			candidate: subscription(userID, "music", 100, month(2030, time.January), nil),
+			want:      []domain.Subscription{open},
+		},
+		{
+			name:      "open-ended candidate",
+			candidate: subscription(userID, "music", 100, month(2024, time.March), nil),
+			want:      []domain.Subscription{closed, open},
+		},
+		{
+			name: "end date is inclusive",
+			candidate: subscription(
+				userID,
+				"music",
+				100,
+				date(2024, time.June, 30),
+				pointer.Ref(month(2024, time.July)),
+			),
+			want: []domain.Subscription{closed},
+		},
+		{
+			name: "gap between subscriptions",
+			candidate: subscription(
+				userID,
+				"music",
+				100,
+				month(2024, time.July),
+				pointer.Ref(date(2024, time.December, 31)),
+			),
+		},
+		{
+			name:      "itself",
+			candidate: closed,
+		},
+		{
+			name:      "another user",
+			candidate: subscription(uuid.New(), "music", 100, month(2024, time.January), nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := overlapping(tt.candidate)
+			if tt.want == nil {
+				require.Empty(t, found)
+				return
+			}
+			require.Equal(t, tt.want, found)
+		})
+	}
+}
+
+func testSoftDelete(t *testing.T, b *backend) {
+	userID := uuid.New()
+	live := b.create(subscription(userID, "music", 100, month(2025, time.January), nil))
+	filter := domain.SubscriptionFilter{UserID: userID}
+	start, end := month(2025, time.January), month(2025, time.March)
+	require.Equal(t, 300, b.totalCost(userID, "", start, end))
+
+	deleteSubscription := func(id domain.SubscriptionID) error {
+		return b.do(func(ctx context.Context, c domain.Connection) error {
+			return b.repo.Delete(ctx, c, id)
+		})
+	}
+	restore := func(id domain.SubscriptionID) (domain.Subscription, error) {
+		var restored domain.Subscription
+		err := b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			restored, err = b.repo.Restore(ctx, c, id)
+			return err
+		})
+		return restored, err
+	}
+
+	require.NoError(t, deleteSubscription(live.ID))
+	require.ErrorIs(t, deleteSubscription(live.ID), domain.ErrSubscriptionNotFound)
+
+	_, err := b.read(live.ID)
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Update(ctx, c, live)
+	}), domain.ErrSubscriptionNotFound)
+	require.Empty(t, b.readAll(filter, domain.Pagination{Limit: 10}))
+	require.Zero(t, b.totalCost(userID, "", start, end))
+
+	withDeleted := filter
+	withDeleted.IncludeDeleted = true
+	listed := b.readAll(withDeleted, domain.Pagination{Limit: 10})
+	require.Len(t, listed, 1)
+	require.NotNil(t, listed[0].DeletedAt)
+	listed[0].DeletedAt = nil
+	require.Equal(t, live, listed[0])
+
+	restored, err := restore(live.ID)
+	require.NoError(t, err)
+	require.Equal(t, live, restored)
+	_, err = restore(live.ID)
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	require.Equal(t, 300, b.totalCost(userID, "", start, end))
+
+	// A deleted subscription does not block the days it covered, restoring it
+	// afterwards would overlap.
+	require.NoError(t, deleteSubscription(live.ID))
+	replacement := b.create(subscription(userID, "music", 120, month(2025, time.February), nil))
+	_, err = restore(live.ID)
+	require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+
+	stored, err := b.read(replacement.ID)
+	require.NoError(t, err)
+	require.Equal(t, replacement, stored)
+}
+
+func testPurge(t *testing.T, b *backend) {
+	userID := uuid.New()
+	deleted := b.create(subscription(userID, "music", 100, month(2025, time.January), nil))
+	kept := b.create(subscription(userID, "video", 100, month(2025, time.January), nil))
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return b.repo.Delete(ctx, c, deleted.ID)
+	}))
+
+	purge := func(before time.Time) int {
+		var purged int
+		require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+			var err error
+			purged, err = b.repo.Purge(ctx, c, before)
+			return err
+		}))
+		return purged
+	}
+
+	// Margins leave room for clock skew between the test and the database.
+	require.Zero(t, purge(time.Now().Add(-time.Hour)))
+	require.Equal(t, 1, purge(time.Now().Add(time.Hour)))
+
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		_, err := b.repo.Restore(ctx, c, deleted.ID)
+		return err
+	})
+	require.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	require.Equal(t, []domain.Subscription{kept}, b.readAll(
+		domain.SubscriptionFilter{UserID: userID, IncludeDeleted: true},
+		domain.Pagination{Limit: 10},
+	))
+}
+
+// compare mirrors the documented listing order, the id breaks ties.
+func compare(sort domain.SubscriptionSort, a, b domain.Subscription) int {
+	var order int
+	switch sort.Field {
+	case domain.SortByStartDate:
+		order = a.StartDate.Compare(b.StartDate)
+	case domain.SortByEndDate:
+		order = endOrOpen(a).Compare(endOrOpen(b))
+	case domain.SortByPrice:
+		order = a.Cost - b.Cost
+	case domain.SortByServiceName:
+		order = strings.Compare(a.Name, b.Name)
+	}
+	if order == 0 {
+		order = slices.Compare(a.ID[:], b.ID[:])
+	}
+	if sort.Descending {
+		order = -order
+	}
+
+	return order
+}
+
+func endOrOpen(subscription domain.Subscription) time.Time {
+	if subscription.EndDate == nil {
+		return time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	return *subscription.EndDate
+}
@@ -0,0 +1,186 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTags runs the tag part of the suite.
+func RunTags(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, *backend, domain.TagsRepository)
+	}{
+		{"crud", testTagCRUD},
+		{"rename", testRenameTag},
+		{"merge", testMergeTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repositories := factory(t)
+			tt.test(t, newBackend(t, provider, repositories), repositories.Tags)
+		})
+	}
+}
+
+func (b *backend) listTags(tags domain.TagsRepository, userID domain.UserID) []domain.Tag {
+	b.t.Helper()
+
+	var listed []domain.Tag
+	require.NoError(b.t, b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		listed, err = tags.List(ctx, c, userID)
+		return err
+	}))
+
+	return listed
+}
+
+func (b *backend) readTag(tags domain.TagsRepository, id domain.TagID) (domain.Tag, error) {
+	var tag domain.Tag
+	err := b.do(func(ctx context.Context, c domain.Connection) error {
+		var err error
+		tag, err = tags.Read(ctx, c, id)
+		return err
+	})
+
+	return tag, err
+}
+
+// tagByName finds the tag of the user named exactly name.
+func (b *backend) tagByName(
+	tags domain.TagsRepository,
+	userID domain.UserID,
+	name string,
+) domain.Tag {
+	b.t.Helper()
+
+	for _, tag := range b.listTags(tags, userID) {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	b.t.Fatalf("no tag %q", name)
+
+	return domain.Tag{}
+}
+
+func testTagCRUD(t *testing.T, b *backend, tags domain.TagsRepository) {
+	userID := uuid.New()
+	work := domain.Tag{ID: uuid.New(), UserID: userID, Name: "work"}
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Create(ctx, c, work)
+	}))
+
+	// Saving a subscription creates its missing tags and reuses the others.
+	music := subscription(userID, "music", 100, month(2025, time.January), nil)
+	music.Tags = []string{"Family", "WORK"}
+	b.create(music)
+	deleted := b.create(subscription(userID, "video", 100, month(2025, time.January), nil))
+	deleted.Tags = []string{"family"}
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		if err := b.repo.Update(ctx, c, deleted); err != nil {
+			return err
+		}
+		return b.repo.Delete(ctx, c, deleted.ID)
+	}))
+	b.create(subscription(uuid.New(), "music", 100, month(2025, time.January), nil))
+
+	listed := b.listTags(tags, userID)
+	require.Len(t, listed, 2)
+	family := listed[0]
+	require.Equal(t, "Family", family.Name)
+	// Deleted subscriptions are not counted.
+	require.Equal(t, 1, family.Subscriptions)
+	work.Subscriptions = 1
+	require.Equal(t, work, listed[1])
+
+	stored, err := b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Family", "work"}, stored.Tags)
+
+	tag, err := b.readTag(tags, work.ID)
+	require.NoError(t, err)
+	require.Equal(t, work, tag)
+
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Create(ctx, c, domain.Tag{ID: uuid.New(), UserID: userID, Name: "Work"})
+	}), domain.ErrAlreadyExists)
+
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Delete(ctx, c, work.ID)
+	}))
+	_, err = b.readTag(tags, work.ID)
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Delete(ctx, c, work.ID)
+	}), domain.ErrTagNotFound)
+
+	stored, err = b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Family"}, stored.Tags)
+}
+
+func testRenameTag(t *testing.T, b *backend, tags domain.TagsRepository) {
+	userID := uuid.New()
+	music := subscription(userID, "music", 100, month(2025, time.January), nil)
+	music.Tags = []string{"famly", "work"}
+	b.create(music)
+
+	famly := b.tagByName(tags, userID, "famly")
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Rename(ctx, c, famly.ID, "family")
+	}))
+
+	renamed, err := b.readTag(tags, famly.ID)
+	require.NoError(t, err)
+	require.Equal(t, "family", renamed.Name)
+	require.Equal(t, 1, renamed.Subscriptions)
+
+	stored, err := b.read(music.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"family", "work"}, stored.Tags)
+
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Rename(ctx, c, uuid.New(), "missing")
+	}), domain.ErrTagNotFound)
+}
+
+func testMergeTags(t *testing.T, b *backend, tags domain.TagsRepository) {
+	userID := uuid.New()
+	music := subscription(userID, "music", 100, month(2025, time.January), nil)
+	music.Tags = []string{"family", "home"}
+	b.create(music)
+	video := subscription(userID, "video", 100, month(2025, time.January), nil)
+	video.Tags = []string{"home"}
+	b.create(video)
+
+	home := b.tagByName(tags, userID, "home")
+	family := b.tagByName(tags, userID, "family")
+	require.NoError(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Merge(ctx, c, home.ID, family.ID)
+	}))
+
+	_, err := b.readTag(tags, home.ID)
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+	merged, err := b.readTag(tags, family.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, merged.Subscriptions)
+
+	for _, id := range []domain.SubscriptionID{music.ID, video.ID} {
+		stored, err := b.read(id)
+		require.NoError(t, err)
+		require.Equal(t, []string{"family"}, stored.Tags)
+	}
+
+	require.ErrorIs(t, b.do(func(ctx context.Context, c domain.Connection) error {
+		return tags.Merge(ctx, c, home.ID, family.ID)
+	}), domain.ErrTagNotFound)
+}
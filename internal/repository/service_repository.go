@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errService       = errors.New("service repository error")
+	ErrCreateService = errors.Join(errService, errors.New("create failed"))
+	ErrUpdateService = errors.Join(errService, errors.New("update failed"))
+	ErrDeleteService = errors.Join(errService, errors.New("delete failed"))
+	ErrReadService   = errors.Join(errService, errors.New("read failed"))
+	ErrListServices  = errors.Join(errService, errors.New("list failed"))
+	ErrLinkService   = errors.Join(errService, errors.New("link failed"))
+)
+
+var _ domain.ServicesRepository = (*ServiceRepository)(nil)
+
+const serviceColumns = `id, name, category, default_price, currency, billing_period`
+
+type ServiceRepository struct{}
+
+func NewServices() *ServiceRepository {
+	return &ServiceRepository{}
+}
+
+func (r *ServiceRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) error {
+	const query = `insert into services (` + serviceColumns + `)
+	values ($1, $2, $3, $4, $5, $6)`
+
+	if _, err := connection.ExecContext(ctx, query, service.ID, service.Name, service.Category, service.DefaultPrice, service.Currency, service.BillingPeriod); err != nil {
+		return errors.Join(ErrCreateService, classify(err, domain.ErrServiceNotFound))
+	}
+	if err := saveAliases(ctx, connection, service); err != nil {
+		return errors.Join(ErrCreateService, err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Update(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) error {
+	const query = `update services
+	set name = $2, category = $3, default_price = $4, currency = $5, billing_period = $6
+	where id = $1`
+
+	rowsAffected, err := connection.ExecContext(
+		ctx,
+		query,
+		service.ID,
+		service.Name,
+		service.Category,
+		service.DefaultPrice,
+		service.Currency,
+		service.BillingPeriod,
+	)
+	if err != nil {
+		return errors.Join(ErrUpdateService, classify(err, domain.ErrServiceNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrUpdateService, domain.ErrServiceNotFound)
+	}
+	if err := saveAliases(ctx, connection, service); err != nil {
+		return errors.Join(ErrUpdateService, err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.ServiceID,
+) error {
+	const (
+		inUseQuery  = `select exists (select 1 from subscriptions where service_id = $1)`
+		deleteQuery = `delete from services where id = $1`
+	)
+
+	var inUse bool
+	if err := connection.GetContext(ctx, &inUse, inUseQuery, id); err != nil {
+		return errors.Join(ErrDeleteService, classify(err, domain.ErrServiceNotFound))
+	}
+	if inUse {
+		return errors.Join(ErrDeleteService, domain.ErrServiceInUse)
+	}
+
+	rowsAffected, err := connection.ExecContext(ctx, deleteQuery, id)
+	if err != nil {
+		return errors.Join(ErrDeleteService, classify(err, domain.ErrServiceNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrDeleteService, domain.ErrServiceNotFound)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.ServiceID,
+) (domain.Service, error) {
+	const query = `select ` + serviceColumns + ` from services where id = $1`
+
+	var service domain.Service
+	if err := connection.GetContext(ctx, &service, query, id); err != nil {
+		return domain.Service{}, errors.Join(
+			ErrReadService,
+			classify(err, domain.ErrServiceNotFound),
+		)
+	}
+
+	read := []domain.Service{service}
+	if err := readAliases(ctx, connection, read); err != nil {
+		return domain.Service{}, errors.Join(ErrReadService, err)
+	}
+
+	return read[0], nil
+}
+
+func (r *ServiceRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	filter domain.ServiceFilter,
+) ([]domain.Service, error) {
+	var builder queryBuilder
+	if filter.Category != nil {
+		builder.where("category = %s", *filter.Category)
+	}
+
+	query := `select ` + serviceColumns + ` from services` + builder.whereClause() + ` order by name collate "C", id`
+
+	var services []domain.Service
+	if err := connection.SelectContext(ctx, &services, query, builder.args...); err != nil {
+		return nil, errors.Join(ErrListServices, classify(err, domain.ErrServiceNotFound))
+	}
+	if err := readAliases(ctx, connection, services); err != nil {
+		return nil, errors.Join(ErrListServices, err)
+	}
+
+	return services, nil
+}
+
+func (r *ServiceRepository) Resolve(
+	ctx context.Context,
+	connection domain.Connection,
+	key string,
+) (domain.Service, error) {
+	const query = `select s.id, s.name, s.category, s.default_price, s.currency, s.billing_period
+	from services s
+	join service_aliases a on a.service_id = s.id
+	where a.alias_key = $1`
+
+	var service domain.Service
+	if err := connection.GetContext(ctx, &service, query, key); err != nil {
+		return domain.Service{}, errors.Join(
+			ErrReadService,
+			classify(err, domain.ErrServiceNotFound),
+		)
+	}
+
+	read := []domain.Service{service}
+	if err := readAliases(ctx, connection, read); err != nil {
+		return domain.Service{}, errors.Join(ErrReadService, err)
+	}
+
+	return read[0], nil
+}
+
+// Link matches names by domain.ServiceKey in Go, so the key never has to be
+// computed by the database. Only the name and the service ID change, so the
+// state before a change is its state after with the returned previous ones.
+func (r *ServiceRepository) Link(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) ([]domain.SubscriptionChange, error) {
+	const (
+		namesQuery = `select distinct service_name from subscriptions
+	where service_id is distinct from $1`
+		// Candidates are the subscriptions linked to the service or named
+		// after one of its keys. A live candidate is only renamed when no
+		// other live subscription of the same user, already named so or a
+		// candidate itself, shares a day with it.
+		linkQuery = `with candidates as (
+    select id, service_name, service_id from subscriptions
+    where service_id = $1 or service_name = any($3)
+)
+update subscriptions s
+set service_name = $2, service_id = $1
+from candidates c
+where s.id = c.id
+    and (s.service_name <> $2 or s.service_id is distinct from $1)
+    and (
+        s.service_name = $2
+        or s.deleted_at is not null
+        or not exists (
+            select 1 from subscriptions o
+            where o.id <> s.id
+                and o.user_id = s.user_id
+                and o.deleted_at is null
+                and (o.service_name = $2 or o.id in (select id from candidates))
+                and daterange(o.subs_start_date, o.subs_end_date, '[]')
+                    && daterange(s.subs_start_date, s.subs_end_date, '[]')
+        )
+    )
+returning s.id, c.service_name, c.service_id`
+		changedQuery = `select ` + subscriptionColumns + ` from subscriptions
+	where id = any($1)
+	order by id`
+	)
+
+	var names []domain.ServiceName
+	if err := connection.SelectContext(ctx, &names, namesQuery, service.ID); err != nil {
+		return nil, errors.Join(ErrLinkService, classify(err, domain.ErrServiceNotFound))
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range service.Keys() {
+		keys[key] = true
+	}
+	matching := make([]domain.ServiceName, 0, len(names))
+	for _, name := range names {
+		if keys[domain.ServiceKey(name)] {
+			matching = append(matching, name)
+		}
+	}
+
+	var previous []struct {
+		ID        domain.SubscriptionID `db:"id"`
+		Name      domain.ServiceName    `db:"service_name"`
+		ServiceID *domain.ServiceID     `db:"service_id"`
+	}
+	if err := connection.SelectContext(ctx, &previous, linkQuery, service.ID, service.Name, matching); err != nil {
+		return nil, errors.Join(ErrLinkService, classify(err, domain.ErrSubscriptionOverlap))
+	}
+	if len(previous) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]domain.SubscriptionID, 0, len(previous))
+	before := make(map[domain.SubscriptionID]domain.Subscription, len(previous))
+	for _, subscription := range previous {
+		ids = append(ids, subscription.ID)
+		before[subscription.ID] = domain.Subscription{
+			Name:      subscription.Name,
+			ServiceID: subscription.ServiceID,
+		}
+	}
+	var linked []domain.Subscription
+	if err := connection.SelectContext(ctx, &linked, changedQuery, ids); err != nil {
+		return nil, errors.Join(ErrLinkService, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	if err := readDetails(ctx, connection, linked); err != nil {
+		return nil, errors.Join(ErrLinkService, err)
+	}
+
+	// Renamed subscriptions move their costs to the catalog name.
+	changes := make([]costChange, 0, len(linked))
+	linkedChanges := make([]domain.SubscriptionChange, 0, len(linked))
+	for _, after := range linked {
+		change := domain.SubscriptionChange{Before: after, After: after}
+		change.Before.Name = before[after.ID].Name
+		change.Before.ServiceID = before[after.ID].ServiceID
+		linkedChanges = append(linkedChanges, change)
+		changes = append(changes, subscriptionChange(after))
+	}
+	if err := refreshMonthlyCosts(ctx, connection, changes...); err != nil {
+		return nil, errors.Join(ErrLinkService, err)
+	}
+
+	return linkedChanges, nil
+}
+
+// saveAliases replaces the keys of the service, the name is stored as one of
+// them so names and aliases share one uniqueness constraint.
+func saveAliases(
+	ctx context.Context,
+	connection domain.Connection,
+	service domain.Service,
+) error {
+	const (
+		deleteQuery = `delete from service_aliases where service_id = $1`
+		insertQuery = `insert into service_aliases (alias_key, service_id, alias) values ($1, $2, $3)`
+	)
+
+	if _, err := connection.ExecContext(ctx, deleteQuery, service.ID); err != nil {
+		return classify(err, domain.ErrServiceNotFound)
+	}
+	for _, alias := range append([]string{service.Name}, service.Aliases...) {
+		if _, err := connection.ExecContext(ctx, insertQuery, domain.ServiceKey(alias), service.ID, alias); err != nil {
+			return classify(err, domain.ErrServiceNotFound)
+		}
+	}
+
+	return nil
+}
+
+// readAliases fills in the aliases of the services, leaving out their names.
+func readAliases(
+	ctx context.Context,
+	connection domain.Connection,
+	services []domain.Service,
+) error {
+	if len(services) == 0 {
+		return nil
+	}
+
+	const query = `select a.service_id, a.alias
+	from service_aliases a
+	join services s on s.id = a.service_id
+	where a.service_id = any($1) and a.alias <> s.name
+	order by a.service_id, a.alias collate "C"`
+
+	ids := make([]domain.ServiceID, 0, len(services))
+	for _, service := range services {
+		ids = append(ids, service.ID)
+	}
+
+	var aliases []struct {
+		ServiceID domain.ServiceID `db:"service_id"`
+		Alias     string           `db:"alias"`
+	}
+	if err := connection.SelectContext(ctx, &aliases, query, ids); err != nil {
+		return classify(err, domain.ErrServiceNotFound)
+	}
+
+	byService := make(map[domain.ServiceID][]string)
+	for _, alias := range aliases {
+		byService[alias.ServiceID] = append(byService[alias.ServiceID], alias.Alias)
+	}
+	for i := range services {
+		services[i].Aliases = byService[services[i].ID]
+	}
+
+	return nil
+}
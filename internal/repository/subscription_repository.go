@@ -5,8 +5,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-
 	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
 )
 
@@ -18,20 +16,139 @@ var (
 		errSubscription,
 		errors.New("read all failed"),
 	)
-	ErrDeleteSubscription                = errors.Join(errSubscription, errors.New("delete failed"))
-	ErrUpdateSubscription                = errors.Join(errSubscription, errors.New("update failed"))
+	ErrCountSubscriptions  = errors.Join(errSubscription, errors.New("count failed"))
+	ErrDeleteSubscription  = errors.Join(errSubscription, errors.New("delete failed"))
+	ErrUpdateSubscription  = errors.Join(errSubscription, errors.New("update failed"))
+	ErrRestoreSubscription = errors.Join(
+		errSubscription,
+		errors.New("restore failed"),
+	)
+	ErrPurgeSubscriptions = errors.Join(errSubscription, errors.New("purge failed"))
+	ErrSchedulePrice      = errors.Join(
+		errSubscription,
+		errors.New("schedule price failed"),
+	)
+	ErrReadPrices = errors.Join(
+		errSubscription,
+		errors.New("read prices failed"),
+	)
 	ErrAllMatchingSubscriptionsForPeriod = errors.Join(
 		errSubscription,
 		errors.New("all matching subscriptions failed"),
 	)
-	ErrGetLatestDateSubscription = errors.Join(
+	ErrMonthlyCharges = errors.Join(
+		errSubscription,
+		errors.New("monthly charges failed"),
+	)
+	ErrBilledCurrencies = errors.Join(
+		errSubscription,
+		errors.New("billed currencies failed"),
+	)
+	ErrMonthlyCosts = errors.Join(
+		errSubscription,
+		errors.New("monthly costs failed"),
+	)
+	ErrCostByGroup = errors.Join(
+		errSubscription,
+		errors.New("cost by group failed"),
+	)
+	ErrReadOverlappingSubscriptions = errors.Join(
 		errSubscription,
-		errors.New("get latest date failed"),
+		errors.New("read overlapping failed"),
 	)
 )
 
 var _ domain.SubscriptionsRepository = (*SubscriptionRepository)(nil)
 
+const subscriptionColumns = `id, service_name, month_cost, user_id, subs_start_date, subs_end_date, billing_period, billing_anchor, currency, service_id, category, deleted_at`
+
+// billedCharges splits the live subscriptions of user $1, of every user when
+// $1 is null, narrowed to service $2 unless it is empty, into runs of months
+// at one price between the months of $3 and $4. The initial price runs from
+// the start month until the first price change, each change until the next
+// one. Changes before the start month are never billed and one in the start
+// month replaces the initial price, as in domain.Subscription.PriceAt.
+// Segments that fall outside of the period end before they start and yield
+// no months.
+//
+// Every month of a segment is then counted the charges falling in it, the
+// same way domain.Subscription.Charges does: one for monthly billing, one in
+// the anchored months for quarterly and yearly billing and one every seven
+// days from the start date for weekly billing. The discount covering the
+// month, if any, is taken off the price as in domain.Discount.Apply.
+const billedCharges = `segments as (
+    select
+        s.id as subscription_id,
+        s.user_id,
+        s.service_name,
+        s.category,
+        s.billing_period,
+        s.billing_anchor,
+        s.currency,
+        s.subs_start_date,
+        prices.month_cost as price,
+        greatest(
+            prices.effective_from,
+            date_trunc('month', s.subs_start_date),
+            date_trunc('month', $3::date)
+        ) as first_month,
+        least(
+            coalesce(
+                lead(prices.effective_from) over (
+                    partition by s.id order by prices.effective_from, prices.is_change
+                ) - interval '1 month',
+                'infinity'::timestamptz
+            ),
+            date_trunc('month', coalesce(s.subs_end_date, $4::date)),
+            date_trunc('month', $4::date)
+        ) as last_month
+    from subscriptions s
+    cross join lateral (
+        select date_trunc('month', s.subs_start_date) as effective_from, s.month_cost, false as is_change
+        union all
+        select date_trunc('month', p.effective_from), p.month_cost, true
+        from subscription_prices p
+        where p.subscription_id = s.id
+    ) prices
+    where ($1::uuid IS NULL OR s.user_id = $1)
+      and s.deleted_at IS NULL
+      and ($2 = '' OR s.service_name = $2)
+),
+charges as (
+    select
+        seg.subscription_id,
+        seg.user_id,
+        seg.service_name,
+        seg.category,
+        seg.billing_period,
+        seg.currency,
+        case d.kind
+            when 'trial' then 0
+            when 'percent' then seg.price - (seg.price * d.value + 50) / 100
+            when 'fixed' then greatest(seg.price - d.value, 0)
+            else seg.price
+        end as price,
+        m.month::date as month,
+        case seg.billing_period
+            when 'weekly' then (
+                floor(((m.month + interval '1 month' - interval '1 day')::date - seg.subs_start_date) / 7.0) -
+                floor((greatest(m.month::date, seg.subs_start_date) - 1 - seg.subs_start_date) / 7.0)
+            )::int
+            when 'quarterly' then (mod(` + monthsFromAnchor + `, 3) = 0)::int
+            when 'yearly' then (mod(` + monthsFromAnchor + `, 12) = 0)::int
+            else 1
+        end as charges
+    from segments seg
+    cross join lateral generate_series(seg.first_month, seg.last_month, interval '1 month') as m(month)
+    left join subscription_discounts d
+        on d.subscription_id = seg.subscription_id and m.month between d.start_month and d.end_month
+)`
+
+// monthsFromAnchor counts the months from the anchor of a segment to the
+// month of a charges row.
+const monthsFromAnchor = `((extract(year from m.month)::int - extract(year from seg.billing_anchor)::int) * 12 +
+                extract(month from m.month)::int - extract(month from seg.billing_anchor)::int)`
+
 type SubscriptionRepository struct{}
 
 func NewSubscription() *SubscriptionRepository {
@@ -44,11 +161,21 @@ func (s *SubscriptionRepository) Create(
 	subscription domain.Subscription,
 ) error {
 	const query = `insert into subscriptions
-	(id, service_name, month_cost, user_id, subs_start_date, subs_end_date)
+	(id, service_name, month_cost, user_id, subs_start_date, subs_end_date, billing_period, billing_anchor, currency, service_id, category)
 	values
-	($1, $2, $3, $4, $5, $6)`
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
-	if _, err := connection.ExecContext(ctx, query, subscription.ID, subscription.Name, subscription.Cost, subscription.UserID, subscription.StartDate, subscription.EndDate); err != nil {
+	subscription = subscription.WithBillingDefaults()
+	if _, err := connection.ExecContext(ctx, query, subscription.ID, subscription.Name, subscription.Cost, subscription.UserID, subscription.StartDate, subscription.EndDate, subscription.BillingPeriod, subscription.BillingAnchor, subscription.Currency, subscription.ServiceID, subscription.Category); err != nil {
+		return errors.Join(ErrCreateSubscription, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	if err := saveDiscounts(ctx, connection, subscription); err != nil {
+		return errors.Join(ErrCreateSubscription, err)
+	}
+	if err := saveTags(ctx, connection, subscription); err != nil {
+		return errors.Join(ErrCreateSubscription, err)
+	}
+	if err := refreshMonthlyCosts(ctx, connection, subscriptionChange(subscription)); err != nil {
 		return errors.Join(ErrCreateSubscription, err)
 	}
 
@@ -60,60 +187,218 @@ func (s *SubscriptionRepository) Delete(
 	connection domain.Connection,
 	subscriptionID domain.SubscriptionID,
 ) error {
-	const query = `delete from subscriptions where id = $1`
-	rowsAffected, err := connection.ExecContext(ctx, query, subscriptionID)
-	if err != nil {
+	const query = `update subscriptions set deleted_at = now() where id = $1 and deleted_at is null
+	returning ` + subscriptionColumns
+	var subscription domain.Subscription
+	if err := connection.GetContext(ctx, &subscription, query, subscriptionID); err != nil {
+		return errors.Join(ErrDeleteSubscription, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	if err := refreshMonthlyCosts(ctx, connection, subscriptionChange(subscription)); err != nil {
 		return errors.Join(ErrDeleteSubscription, err)
 	}
-	if rowsAffected == 0 {
-		return errors.Join(ErrDeleteSubscription, errors.New("subscription not found"))
+	return nil
+}
+
+func (s *SubscriptionRepository) Restore(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) (domain.Subscription, error) {
+	const query = `update subscriptions set deleted_at = null
+	where id = $1 and deleted_at is not null
+	returning ` + subscriptionColumns
+
+	var subscription domain.Subscription
+	if err := connection.GetContext(ctx, &subscription, query, subscriptionID); err != nil {
+		return subscription, errors.Join(
+			ErrRestoreSubscription,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	read := []domain.Subscription{subscription}
+	if err := readDetails(ctx, connection, read); err != nil {
+		return subscription, errors.Join(ErrRestoreSubscription, err)
 	}
+	if err := refreshMonthlyCosts(ctx, connection, subscriptionChange(subscription)); err != nil {
+		return subscription, errors.Join(ErrRestoreSubscription, err)
+	}
+	return read[0], nil
+}
+
+func (s *SubscriptionRepository) Purge(
+	ctx context.Context,
+	connection domain.Connection,
+	before time.Time,
+) (int, error) {
+	const query = `delete from subscriptions where deleted_at < $1`
+	purged, err := connection.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, errors.Join(ErrPurgeSubscriptions, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	return int(purged), nil
+}
+
+func (s *SubscriptionRepository) SchedulePrice(
+	ctx context.Context,
+	connection domain.Connection,
+	change domain.PriceChange,
+) error {
+	const query = `with price as (
+	insert into subscription_prices (subscription_id, effective_from, month_cost)
+	values ($1, date_trunc('month', $2::date), $3)
+	on conflict (subscription_id, effective_from) do update set month_cost = excluded.month_cost
+	returning subscription_id
+)
+select ` + subscriptionColumns + ` from subscriptions where id = (select subscription_id from price)`
+
+	var subscription domain.Subscription
+	if err := connection.GetContext(ctx, &subscription, query, change.SubscriptionID, change.EffectiveFrom, change.Price); err != nil {
+		return errors.Join(ErrSchedulePrice, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	// The months before the change keep their price.
+	refresh := subscriptionChange(subscription)
+	if refresh.start.Before(change.EffectiveFrom) {
+		refresh.start = change.EffectiveFrom
+	}
+	if err := refreshMonthlyCosts(ctx, connection, refresh); err != nil {
+		return errors.Join(ErrSchedulePrice, err)
+	}
+
 	return nil
 }
 
+func (s *SubscriptionRepository) ReadPrices(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptionID domain.SubscriptionID,
+) ([]domain.PriceChange, error) {
+	const query = `select subscription_id, effective_from, month_cost
+	from subscription_prices
+	where subscription_id = $1
+	order by effective_from`
+
+	var prices []domain.PriceChange
+	if err := connection.SelectContext(ctx, &prices, query, subscriptionID); err != nil {
+		return nil, errors.Join(ErrReadPrices, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	return prices, nil
+}
+
 func (s *SubscriptionRepository) Read(ctx context.Context,
 	connection domain.Connection,
 	subscriptionID domain.SubscriptionID,
 ) (domain.Subscription, error) {
 	var subscription domain.Subscription
-	const query = `select id, service_name, month_cost, user_id, subs_start_date, subs_end_date from subscriptions
-	where id = $1`
+	const query = `select ` + subscriptionColumns + ` from subscriptions
+	where id = $1 and deleted_at is null`
 
 	if err := connection.GetContext(ctx, &subscription, query, subscriptionID); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return subscription, errors.Join(
-				ErrReadSubscription,
-				errors.New("subscription not found"),
-			)
-		}
+		return subscription, errors.Join(
+			ErrReadSubscription,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	read := []domain.Subscription{subscription}
+	if err := readDetails(ctx, connection, read); err != nil {
 		return subscription, errors.Join(ErrReadSubscription, err)
 	}
-	return subscription, nil
+	return read[0], nil
 }
 
 func (s *SubscriptionRepository) ReadAll(
 	ctx context.Context,
 	connection domain.Connection,
-	userID domain.UserID,
-	limit int,
-	offset int,
+	filter domain.SubscriptionFilter,
+	pagination domain.Pagination,
 ) ([]domain.Subscription, error) {
-	const query = `select id, service_name, month_cost, user_id, subs_start_date, subs_end_date from subscriptions where user_id=$1 order by subs_start_date desc limit $2 offset $3`
+	var builder queryBuilder
+	builder.applyFilter(filter)
+	builder.applySeek(pagination.Sort, pagination.After)
+
+	orderBy := orderByClause(pagination.Sort)
+	limit := builder.arg(pagination.Limit)
+	offset := builder.arg(pagination.Offset)
+
+	query := `select ` + subscriptionColumns + `
+	from subscriptions` + builder.whereClause() + orderBy + " limit " + limit + " offset " + offset
+
 	var allUserSubscriptions []domain.Subscription
-	if err := connection.SelectContext(ctx, &allUserSubscriptions, query, userID, limit, offset); err != nil {
-		return allUserSubscriptions, errors.Join(ErrReadAllSubscriptions, err)
+	if err := connection.SelectContext(ctx, &allUserSubscriptions, query, builder.args...); err != nil {
+		return allUserSubscriptions, errors.Join(
+			ErrReadAllSubscriptions,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	if err := readDetails(ctx, connection, allUserSubscriptions); err != nil {
+		return nil, errors.Join(ErrReadAllSubscriptions, err)
 	}
 	return allUserSubscriptions, nil
 }
 
+func (s *SubscriptionRepository) Count(
+	ctx context.Context,
+	connection domain.Connection,
+	filter domain.SubscriptionFilter,
+) (int, error) {
+	var builder queryBuilder
+	builder.applyFilter(filter)
+
+	query := `select count(*) from subscriptions` + builder.whereClause()
+	var total int
+	if err := connection.GetContext(ctx, &total, query, builder.args...); err != nil {
+		return total, errors.Join(
+			ErrCountSubscriptions,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return total, nil
+}
+
 func (s *SubscriptionRepository) Update(ctx context.Context,
 	connection domain.Connection,
 	subscription domain.Subscription,
 ) error {
-	const query = `update subscriptions set service_name = $2 , user_id = $3, month_cost = $4, subs_end_date=$5
-	where id = $1`
+	// Joining the row to itself returns the row as it was before the update.
+	const query = `update subscriptions s set service_name = $2 , user_id = $3, month_cost = $4, subs_start_date = $5, subs_end_date=$6,
+	billing_period = $7, billing_anchor = $8, currency = $9, service_id = $10, category = $11
+	from subscriptions previous
+	where s.id = $1 and s.deleted_at is null and previous.id = s.id
+	returning previous.user_id, previous.subs_start_date, previous.subs_end_date`
 
-	if _, err := connection.ExecContext(ctx, query, subscription.ID, subscription.Name, subscription.UserID, subscription.Cost, subscription.EndDate); err != nil {
+	subscription = subscription.WithBillingDefaults()
+	var previous domain.Subscription
+	err := connection.GetContext(
+		ctx,
+		&previous,
+		query,
+		subscription.ID,
+		subscription.Name,
+		subscription.UserID,
+		subscription.Cost,
+		subscription.StartDate,
+		subscription.EndDate,
+		subscription.BillingPeriod,
+		subscription.BillingAnchor,
+		subscription.Currency,
+		subscription.ServiceID,
+		subscription.Category,
+	)
+	if err != nil {
+		return errors.Join(ErrUpdateSubscription, classify(err, domain.ErrSubscriptionNotFound))
+	}
+	if err := saveDiscounts(ctx, connection, subscription); err != nil {
+		return errors.Join(ErrUpdateSubscription, err)
+	}
+	if err := saveTags(ctx, connection, subscription); err != nil {
+		return errors.Join(ErrUpdateSubscription, err)
+	}
+	err = refreshMonthlyCosts(
+		ctx,
+		connection,
+		subscriptionChange(subscription),
+		subscriptionChange(previous),
+	)
+	if err != nil {
 		return errors.Join(ErrUpdateSubscription, err)
 	}
 
@@ -132,39 +417,376 @@ func (s *SubscriptionRepository) CalculateTotalCost(ctx context.Context,
 		end = &now
 	}
 
-	const query = `select 
-    COALESCE(sum(
-        month_cost * (
-            -- Calculate number of months between start and end
-            (extract(year from least(COALESCE(subs_end_date, $4), $4))::int - 
-             extract(year from greatest(subs_start_date, $3))::int) * 12 +
-            (extract(month from least(COALESCE(subs_end_date, $4), $4))::int - 
-             extract(month from greatest(subs_start_date, $3))::int) + 1
-        )
-    ), 0) as total_cost
-from subscriptions 
-where user_id = $1 
+	stored, err := storedThrough(ctx, connection, *end)
+	if err != nil {
+		return 0, errors.Join(ErrAllMatchingSubscriptionsForPeriod, err)
+	}
+
+	query := `with ` + billedCharges + `
+select COALESCE(sum(price * charges), 0) as total_cost from charges`
+	if stored {
+		query = `select coalesce(sum(amount), 0)::bigint as total_cost
+from monthly_costs
+where user_id = $1
   and ($2 = '' OR service_name = $2)
-  and subs_start_date <= $4 
-  and (subs_end_date IS NULL OR subs_end_date >= $3)`
+  and month between date_trunc('month', $3::date) and date_trunc('month', $4::date)`
+	}
 	var totalCost int
 	if err := connection.GetContext(ctx, &totalCost, query, subscriptionUserID, subscriptionName, start, end); err != nil {
-		return totalCost, errors.Join(ErrAllMatchingSubscriptionsForPeriod, err)
+		return totalCost, errors.Join(
+			ErrAllMatchingSubscriptionsForPeriod,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
 	}
 	return totalCost, nil
 }
 
-func (s *SubscriptionRepository) GetLatestSubscriptionEndDate(ctx context.Context,
+func (s *SubscriptionRepository) CalculateTotalCostByService(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	subscriptionName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.ServiceTotalCost, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	const query = `with ` + billedCharges + `
+select
+    service_name,
+    billing_period,
+    currency,
+    price,
+    sum(charges)::int as charges,
+    sum(price * charges)::int as cost
+from charges
+where charges > 0
+group by service_name, billing_period, currency, price
+order by service_name, billing_period, currency, price`
+	var costs []domain.ServiceTotalCost
+	if err := connection.SelectContext(ctx, &costs, query, subscriptionUserID, subscriptionName, start, end); err != nil {
+		return costs, errors.Join(
+			ErrAllMatchingSubscriptionsForPeriod,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return costs, nil
+}
+
+// costGroups whitelists what costs are grouped by, user input never reaches
+// the query text. A charge counts once for every tag of its subscription.
+var costGroups = map[domain.CostGroup]struct{ key, join string }{
+	domain.CostGroupCategory: {key: "c.category"},
+	domain.CostGroupTag: {
+		key: "coalesce(t.name, '')",
+		join: `
+left join subscription_tags st on st.subscription_id = c.subscription_id
+left join tags t on t.id = st.tag_id`,
+	},
+}
+
+func (s *SubscriptionRepository) CalculateTotalCostByGroup(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	subscriptionName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+	group domain.CostGroup,
+) ([]domain.GroupCost, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	grouping, ok := costGroups[group]
+	if !ok {
+		return nil, errors.Join(
+			ErrCostByGroup,
+			domain.NewValidationError("invalid_group_by", "unsupported grouping"),
+		)
+	}
+
+	query := `with ` + billedCharges + `
+select ` + grouping.key + ` as group_key, sum(c.price * c.charges)::int as cost
+from charges c` + grouping.join + `
+where c.charges > 0
+group by group_key
+order by group_key collate "C"`
+	var costs []domain.GroupCost
+	if err := connection.SelectContext(ctx, &costs, query, subscriptionUserID, subscriptionName, start, end); err != nil {
+		return costs, errors.Join(
+			ErrCostByGroup,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return costs, nil
+}
+
+func (s *SubscriptionRepository) CalculateMonthlyCharges(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	subscriptionName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyCharges, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	const query = `with ` + billedCharges + `
+select month, service_name, billing_period, currency, price, sum(charges)::int as charges
+from charges
+where charges > 0
+group by month, service_name, billing_period, currency, price
+order by month, service_name, billing_period, currency, price`
+	var charges []domain.MonthlyCharges
+	if err := connection.SelectContext(ctx, &charges, query, subscriptionUserID, subscriptionName, start, end); err != nil {
+		return charges, errors.Join(
+			ErrMonthlyCharges,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return charges, nil
+}
+
+func (s *SubscriptionRepository) CalculateCategoryCharges(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	category string,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyCharges, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	const query = `with ` + billedCharges + `
+select month, service_name, billing_period, currency, price, sum(charges)::int as charges
+from charges
+where charges > 0 and category = $5
+group by month, service_name, billing_period, currency, price
+order by month, service_name, billing_period, currency, price`
+	var charges []domain.MonthlyCharges
+	if err := connection.SelectContext(ctx, &charges, query, subscriptionUserID, "", start, end, category); err != nil {
+		return charges, errors.Join(
+			ErrMonthlyCharges,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return charges, nil
+}
+
+func (s *SubscriptionRepository) BilledCurrencies(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	subscriptionName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.Currency, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	const query = `select distinct currency
+from subscriptions
+where user_id = $1
+  and deleted_at IS NULL
+  and ($2 = '' OR service_name = $2)
+  and subs_start_date < date_trunc('month', $4::date) + interval '1 month'
+  and (subs_end_date IS NULL OR subs_end_date >= date_trunc('month', $3::date))
+order by currency`
+	var currencies []domain.Currency
+	if err := connection.SelectContext(ctx, &currencies, query, subscriptionUserID, subscriptionName, start, end); err != nil {
+		return nil, errors.Join(
+			ErrBilledCurrencies,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return currencies, nil
+}
+
+func (s *SubscriptionRepository) CalculateMonthlyCosts(ctx context.Context,
+	connection domain.Connection,
+	subscriptionUserID domain.UserID,
+	subscriptionName domain.ServiceName,
+	start time.Time,
+	end *time.Time,
+) ([]domain.MonthlyServiceCost, error) {
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	stored, err := storedThrough(ctx, connection, *end)
+	if err != nil {
+		return nil, errors.Join(ErrMonthlyCosts, err)
+	}
+
+	query := `with ` + billedCharges + `
+select month, service_name, sum(price * charges)::int as cost
+from charges
+where charges > 0
+group by month, service_name
+order by month, service_name`
+	if stored {
+		query = `select month, service_name, amount as cost
+from monthly_costs
+where user_id = $1
+  and ($2 = '' OR service_name = $2)
+  and month between date_trunc('month', $3::date) and date_trunc('month', $4::date)
+order by month, service_name`
+	}
+	var costs []domain.MonthlyServiceCost
+	if err := connection.SelectContext(ctx, &costs, query, subscriptionUserID, subscriptionName, start, end); err != nil {
+		return costs, errors.Join(
+			ErrMonthlyCosts,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return costs, nil
+}
+
+func (s *SubscriptionRepository) ReadOverlapping(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) ([]domain.Subscription, error) {
+	// Mirrors the subscriptions_no_overlap exclusion constraint.
+	const query = `select ` + subscriptionColumns + `
+	from subscriptions
+	where user_id = $1 and service_name = $2 and id <> $3 and deleted_at is null
+	  and daterange(subs_start_date, subs_end_date, '[]') && daterange($4::date, $5::date, '[]')
+	order by subs_start_date, id`
+
+	var overlapping []domain.Subscription
+	if err := connection.SelectContext(ctx, &overlapping, query, subscription.UserID, subscription.Name, subscription.ID, subscription.StartDate, subscription.EndDate); err != nil {
+		return nil, errors.Join(
+			ErrReadOverlappingSubscriptions,
+			classify(err, domain.ErrSubscriptionNotFound),
+		)
+	}
+	return overlapping, nil
+}
+
+// saveDiscounts replaces the stored discounts of the subscription.
+func saveDiscounts(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) error {
+	const (
+		deleteQuery = `delete from subscription_discounts where subscription_id = $1`
+		insertQuery = `insert into subscription_discounts
+	(subscription_id, kind, value, start_month, end_month)
+	values
+	($1, $2, $3, date_trunc('month', $4::date), date_trunc('month', $5::date))`
+	)
+
+	if _, err := connection.ExecContext(ctx, deleteQuery, subscription.ID); err != nil {
+		return classify(err, domain.ErrSubscriptionNotFound)
+	}
+	for _, discount := range subscription.Discounts {
+		if _, err := connection.ExecContext(ctx, insertQuery, subscription.ID, discount.Kind, discount.Value, discount.StartMonth, discount.EndMonth); err != nil {
+			return classify(err, domain.ErrSubscriptionNotFound)
+		}
+	}
+
+	return nil
+}
+
+// readDetails fills in the price changes, discounts and tags of the
+// subscriptions.
+func readDetails(
+	ctx context.Context,
 	connection domain.Connection,
-	userID domain.UserID,
-	serviceName domain.ServiceName,
-) (*time.Time, error) {
-	const query = `select (subs_end_date) from subscriptions
-	where user_id = $1 and service_name = $2 order by subs_start_date desc limit 1`
-	var latestDate *time.Time
-	if err := connection.GetContext(ctx, &latestDate, query, userID, serviceName); err != nil &&
-		!errors.Is(err, pgx.ErrNoRows) {
-		return nil, errors.Join(ErrGetLatestDateSubscription, err)
+	subscriptions []domain.Subscription,
+) error {
+	if err := readPriceChanges(ctx, connection, subscriptions); err != nil {
+		return err
 	}
-	return latestDate, nil
+	if err := readDiscounts(ctx, connection, subscriptions); err != nil {
+		return err
+	}
+
+	return readTags(ctx, connection, subscriptions)
+}
+
+// readPriceChanges fills in the price changes of the subscriptions.
+func readPriceChanges(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptions []domain.Subscription,
+) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	const query = `select subscription_id, effective_from, month_cost
+	from subscription_prices
+	where subscription_id = any($1)
+	order by subscription_id, effective_from`
+
+	ids := make([]domain.SubscriptionID, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+
+	var prices []domain.PriceChange
+	if err := connection.SelectContext(ctx, &prices, query, ids); err != nil {
+		return classify(err, domain.ErrSubscriptionNotFound)
+	}
+
+	bySubscription := make(map[domain.SubscriptionID][]domain.PriceChange)
+	for _, price := range prices {
+		bySubscription[price.SubscriptionID] = append(bySubscription[price.SubscriptionID], price)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Prices = bySubscription[subscriptions[i].ID]
+	}
+
+	return nil
+}
+
+// readDiscounts fills in the discounts of the subscriptions.
+func readDiscounts(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptions []domain.Subscription,
+) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	const query = `select subscription_id, kind, value, start_month, end_month
+	from subscription_discounts
+	where subscription_id = any($1)
+	order by subscription_id, start_month`
+
+	ids := make([]domain.SubscriptionID, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+
+	var discounts []domain.Discount
+	if err := connection.SelectContext(ctx, &discounts, query, ids); err != nil {
+		return classify(err, domain.ErrSubscriptionNotFound)
+	}
+
+	bySubscription := make(map[domain.SubscriptionID][]domain.Discount)
+	for _, discount := range discounts {
+		bySubscription[discount.SubscriptionID] = append(
+			bySubscription[discount.SubscriptionID],
+			discount,
+		)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Discounts = bySubscription[subscriptions[i].ID]
+	}
+
+	return nil
 }
@@ -2,6 +2,8 @@ package repository_test
 
 import (
 	"context"
+	"fmt"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -45,10 +47,20 @@ func TestSubscriptionIntegration(t *testing.T) {
 			serviseName1,
 		)
 
-		subscriptionsFromDBUser1, err := repoSubscription.ReadAll(ctx, connection, userID1, 100, 0)
+		subscriptionsFromDBUser1, err := repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID1},
+			domain.Pagination{Limit: 100},
+		)
 		require.NoError(t, err)
 
-		subscriptionsFromDBUser2, err := repoSubscription.ReadAll(ctx, connection, userID2, 100, 0)
+		subscriptionsFromDBUser2, err := repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID2},
+			domain.Pagination{Limit: 100},
+		)
 
 		require.NoError(t, err)
 		require.Len(t, subscriptionsFromDBUser1, 2)
@@ -59,7 +71,12 @@ func TestSubscriptionIntegration(t *testing.T) {
 		err = repoSubscription.Delete(ctx, connection, subsID1)
 		require.NoError(t, err)
 
-		subscriptionsFromDBUser1, err = repoSubscription.ReadAll(ctx, connection, userID1, 100, 0)
+		subscriptionsFromDBUser1, err = repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID1},
+			domain.Pagination{Limit: 100},
+		)
 		require.NoError(t, err)
 		require.Len(t, subscriptionsFromDBUser1, 1)
 
@@ -72,8 +89,8 @@ func TestSubscriptionIntegration(t *testing.T) {
 		subscriptionsFromDBUser2, err = repoSubscription.ReadAll(
 			ctx,
 			connection,
-			subscription1User2.UserID,
-			100, 0,
+			domain.SubscriptionFilter{UserID: subscription1User2.UserID},
+			domain.Pagination{Limit: 100},
 		)
 		require.NoError(t, err)
 		require.Equal(t, pointer.Ref(newEndDate), subscriptionsFromDBUser2[0].EndDate)
@@ -100,6 +117,276 @@ func TestSubscriptionIntegration(t *testing.T) {
 	})
 }
 
+func TestSubscriptionCursorPaginationIntegration(t *testing.T) {
+	rollback(t, func(ctx context.Context, connection domain.Connection) {
+		repoSubscription := repository.NewSubscription()
+		userID := uuid.New()
+
+		// All fixtures share the start date, so only the id breaks ties. Each
+		// gets its own service, subscriptions to one service cannot overlap.
+		for i := range 5 {
+			name := domain.ServiceName(fmt.Sprintf("service %d", i))
+			_ = fixtureCreateSubscription(t, connection, uuid.New(), userID, name)
+		}
+
+		all, err := repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID},
+			domain.Pagination{Limit: 100},
+		)
+		require.NoError(t, err)
+		require.Len(t, all, 5)
+
+		total, err := repoSubscription.Count(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+
+		var paged []domain.Subscription
+		pagination := domain.Pagination{Limit: 2, Sort: domain.DefaultSubscriptionSort}
+		for {
+			page, err := repoSubscription.ReadAll(
+				ctx,
+				connection,
+				domain.SubscriptionFilter{UserID: userID},
+				pagination,
+			)
+			require.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			paged = append(paged, page...)
+
+			cursor := domain.NewSubscriptionCursor(pagination.Sort, page[len(page)-1])
+			pagination.After = &cursor
+		}
+
+		require.Equal(t, all, paged)
+	})
+}
+
+func TestSubscriptionFilterIntegration(t *testing.T) {
+	rollback(t, func(ctx context.Context, connection domain.Connection) {
+		repoSubscription := repository.NewSubscription()
+		userID := uuid.New()
+
+		cheap := fixtureCreateSubscription(t, connection, uuid.New(), userID, "Yandex Plus")
+		expensive := fixtureCreateSubscription(t, connection, uuid.New(), userID, "Yandex_Music")
+		expensive.Cost = 500
+		require.NoError(t, repoSubscription.Update(ctx, connection, expensive))
+		_ = fixtureCreateSubscription(t, connection, uuid.New(), userID, "Netflix")
+
+		byPrefix, err := repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID, ServiceNamePrefix: pointer.Ref("Yandex")},
+			domain.Pagination{
+				Limit: 100,
+				Sort:  domain.SubscriptionSort{Field: domain.SortByPrice, Descending: true},
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, []domain.Subscription{expensive, cheap}, byPrefix)
+
+		// The underscore is matched literally, not as a LIKE wildcard.
+		byEscapedPrefix, err := repoSubscription.ReadAll(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID, ServiceNamePrefix: pointer.Ref("Yandex_")},
+			domain.Pagination{Limit: 100, Sort: domain.DefaultSubscriptionSort},
+		)
+		require.NoError(t, err)
+		require.Equal(t, []domain.Subscription{expensive}, byEscapedPrefix)
+
+		byPrice, err := repoSubscription.Count(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{UserID: userID, MinPrice: pointer.Ref(100)},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 1, byPrice)
+
+		active, err := repoSubscription.Count(
+			ctx,
+			connection,
+			domain.SubscriptionFilter{
+				UserID: userID,
+				Status: pointer.Ref(domain.SubscriptionStatusActive),
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 3, active)
+	})
+}
+
+func TestMonthlyCostsMatchTotalIntegration(t *testing.T) {
+	rollback(t, func(ctx context.Context, connection domain.Connection) {
+		repoSubscription := repository.NewSubscription()
+		userID := uuid.New()
+
+		month := func(year int, m time.Month) time.Time {
+			return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		for _, subscription := range []domain.Subscription{
+			{Name: "Music", Cost: 100, StartDate: month(2024, time.November)},
+			{
+				Name:      "Video",
+				Cost:      300,
+				StartDate: month(2025, time.February),
+				EndDate:   pointer.Ref(month(2025, time.March)),
+			},
+		} {
+			subscription.ID = uuid.New()
+			subscription.UserID = userID
+			require.NoError(t, repoSubscription.Create(ctx, connection, subscription))
+		}
+
+		start, end := month(2025, time.January), month(2025, time.April)
+
+		monthly, err := repoSubscription.CalculateMonthlyCosts(
+			ctx,
+			connection,
+			userID,
+			"",
+			start,
+			&end,
+		)
+		require.NoError(t, err)
+		require.Len(t, monthly, 6)
+
+		total, err := repoSubscription.CalculateTotalCost(ctx, connection, userID, "", start, &end)
+		require.NoError(t, err)
+
+		var sum int
+		for _, cost := range monthly {
+			sum += cost.Cost
+		}
+		require.Equal(t, total, sum)
+		require.Equal(t, 4*100+2*300, sum)
+
+		byService, err := repoSubscription.CalculateTotalCostByService(
+			ctx,
+			connection,
+			userID,
+			"",
+			start,
+			&end,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ServiceTotalCost{
+			{
+				Name:          "Music",
+				BillingPeriod: domain.BillingMonthly,
+				Currency:      domain.DefaultCurrency,
+				Charges:       4,
+				Price:         100,
+				Cost:          400,
+			},
+			{
+				Name:          "Video",
+				BillingPeriod: domain.BillingMonthly,
+				Currency:      domain.DefaultCurrency,
+				Charges:       2,
+				Price:         300,
+				Cost:          600,
+			},
+		}, byService)
+	})
+}
+
+func TestCostsMatchBillingEngineIntegration(t *testing.T) {
+	random := rand.New(rand.NewPCG(7, 8))
+
+	for range 20 {
+		rollback(t, func(ctx context.Context, connection domain.Connection) {
+			repoSubscription := repository.NewSubscription()
+			userID := uuid.New()
+
+			var subscriptions []domain.Subscription
+			for _, name := range []domain.ServiceName{"cloud", "music", "video"} {
+				// Subscriptions to one service follow each other without overlapping.
+				start := time.Date(2023, time.Month(1+random.IntN(12)), 1, 0, 0, 0, 0, time.UTC)
+				for range random.IntN(3) {
+					subscription := domain.Subscription{
+						ID:        uuid.New(),
+						Name:      name,
+						Cost:      random.IntN(1000),
+						UserID:    userID,
+						StartDate: start.AddDate(0, 0, random.IntN(28)),
+					}
+					if random.IntN(4) > 0 {
+						end := start.AddDate(0, random.IntN(18), random.IntN(28))
+						subscription.EndDate = &end
+						start = domain.MonthStart(end).AddDate(0, 1+random.IntN(3), 0)
+					}
+					require.NoError(t, repoSubscription.Create(ctx, connection, subscription))
+					subscriptions = append(subscriptions, subscription)
+
+					if subscription.EndDate == nil {
+						break
+					}
+				}
+			}
+
+			start := time.Date(2023, time.Month(1+random.IntN(12)), 1, 0, 0, 0, 0, time.UTC)
+			end := start.AddDate(0, random.IntN(36), 0)
+			period := domain.NewPeriod(start, &end)
+
+			total, err := repoSubscription.CalculateTotalCost(
+				ctx,
+				connection,
+				userID,
+				"",
+				start,
+				&end,
+			)
+			require.NoError(t, err)
+			require.Equal(t, domain.TotalCost(subscriptions, period), total)
+
+			byService, err := repoSubscription.CalculateTotalCostByService(
+				ctx,
+				connection,
+				userID,
+				"",
+				start,
+				&end,
+			)
+			require.NoError(t, err)
+			require.ElementsMatch(t, domain.CostByService(subscriptions, period), byService)
+
+			monthly, err := repoSubscription.CalculateMonthlyCosts(
+				ctx,
+				connection,
+				userID,
+				"",
+				start,
+				&end,
+			)
+			require.NoError(t, err)
+
+			var expected []domain.MonthlyServiceCost
+			for _, month := range domain.MonthlyCosts(subscriptions, period) {
+				for _, service := range month.Services {
+					expected = append(expected, domain.MonthlyServiceCost{
+						Month: month.Month,
+						Name:  service.Name,
+						Cost:  service.Cost,
+					})
+				}
+			}
+			for i := range monthly {
+				monthly[i].Month = monthly[i].Month.UTC()
+			}
+			require.ElementsMatch(t, expected, monthly)
+		})
+	}
+}
+
 func fixtureCreateSubscription(
 	t *testing.T,
 	connection domain.Connection,
@@ -113,8 +400,133 @@ func fixtureCreateSubscription(
 		Cost:      1,
 		Name:      name,
 		StartDate: time.Now().UTC().Truncate(24 * time.Hour),
-	}
+	}.WithBillingDefaults()
 	require.NoError(t, repository.NewSubscription().Create(t.Context(), connection, subscription))
 
 	return subscription
 }
+
+func TestOverlappingSubscriptionsIntegration(t *testing.T) {
+	rollback(t, func(ctx context.Context, connection domain.Connection) {
+		repoSubscription := repository.NewSubscription()
+		userID := uuid.New()
+
+		first := domain.Subscription{
+			ID:        uuid.New(),
+			Name:      "music",
+			Cost:      100,
+			UserID:    userID,
+			StartDate: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   pointer.Ref(time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)),
+		}.WithBillingDefaults()
+		require.NoError(t, repoSubscription.Create(ctx, connection, first))
+
+		// Another service and the following day are both free.
+		otherService := first
+		otherService.ID = uuid.New()
+		otherService.Name = "video"
+		require.NoError(t, repoSubscription.Create(ctx, connection, otherService))
+
+		next := first
+		next.ID = uuid.New()
+		next.StartDate = time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+		next.EndDate = nil
+		require.NoError(t, repoSubscription.Create(ctx, connection, next))
+
+		backDated := first
+		backDated.ID = uuid.New()
+		backDated.StartDate = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		backDated.EndDate = pointer.Ref(time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC))
+
+		overlapping, err := repoSubscription.ReadOverlapping(ctx, connection, backDated)
+		require.NoError(t, err)
+		require.Equal(t, []domain.Subscription{first}, overlapping)
+
+		overlapping, err = repoSubscription.ReadOverlapping(ctx, connection, first)
+		require.NoError(t, err)
+		require.Empty(t, overlapping)
+
+		// The failed insert aborts the transaction, so it goes last.
+		err = repoSubscription.Create(ctx, connection, backDated)
+		require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+	})
+}
+
+func TestConcurrentCreatesDoNotOverlapIntegration(t *testing.T) {
+	provider := cleanTablesAndCreateProvider(t)
+	defer provider.Close()
+
+	service := domain.NewSubscriptionService(provider, repository.NewRepositories())
+	userID := uuid.New()
+
+	const writers = 8
+	errs := make(chan error, writers)
+	for i := range writers {
+		go func() {
+			errs <- service.Create(t.Context(), domain.Subscription{
+				ID:        uuid.New(),
+				Name:      "music",
+				Cost:      100,
+				UserID:    userID,
+				StartDate: time.Date(2025, time.January, 1+i, 0, 0, 0, 0, time.UTC),
+			})
+		}()
+	}
+
+	var created int
+	for range writers {
+		if err := <-errs; err != nil {
+			require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+			continue
+		}
+		created++
+	}
+	require.Equal(t, 1, created)
+}
+
+func TestConcurrentUpdatesDoNotOverlapIntegration(t *testing.T) {
+	provider := cleanTablesAndCreateProvider(t)
+	defer provider.Close()
+
+	service := domain.NewSubscriptionService(provider, repository.NewRepositories())
+	date := func(m time.Month, day int) time.Time {
+		return time.Date(2025, m, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	for range 10 {
+		userID := uuid.New()
+		early := domain.Subscription{
+			ID:        uuid.New(),
+			Name:      "music",
+			Cost:      100,
+			UserID:    userID,
+			StartDate: date(time.January, 1),
+			EndDate:   pointer.Ref(date(time.February, 28)),
+		}
+		late := early
+		late.ID = uuid.New()
+		late.StartDate = date(time.June, 1)
+		late.EndDate = pointer.Ref(date(time.July, 31))
+		require.NoError(t, service.Create(t.Context(), early))
+		require.NoError(t, service.Create(t.Context(), late))
+
+		// Either update alone is fine, together they overlap in March and April.
+		early.EndDate = pointer.Ref(date(time.April, 30))
+		late.StartDate = date(time.March, 1)
+
+		errs := make(chan error, 2)
+		for _, subscription := range []domain.Subscription{early, late} {
+			go func() { errs <- service.Update(t.Context(), subscription) }()
+		}
+
+		var updated int
+		for range 2 {
+			if err := <-errs; err != nil {
+				require.ErrorIs(t, err, domain.ErrSubscriptionOverlap)
+				continue
+			}
+			updated++
+		}
+		require.Equal(t, 1, updated)
+	}
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/Vera-Kovaleva/subscriptions-service/internal/domain"
+)
+
+var (
+	errTag       = errors.New("tag repository error")
+	ErrCreateTag = errors.Join(errTag, errors.New("create failed"))
+	ErrReadTag   = errors.Join(errTag, errors.New("read failed"))
+	ErrListTags  = errors.Join(errTag, errors.New("list failed"))
+	ErrRenameTag = errors.Join(errTag, errors.New("rename failed"))
+	ErrDeleteTag = errors.Join(errTag, errors.New("delete failed"))
+	ErrMergeTags = errors.Join(errTag, errors.New("merge failed"))
+)
+
+var _ domain.TagsRepository = (*TagRepository)(nil)
+
+// tagColumns selects a tag with the number of live subscriptions tagged with
+// it, from tags aliased as t.
+const tagColumns = `t.id, t.user_id, t.name, (
+    select count(*) from subscription_tags st
+    join subscriptions s on s.id = st.subscription_id
+    where st.tag_id = t.id and s.deleted_at is null
+)::int as subscriptions`
+
+type TagRepository struct{}
+
+func NewTags() *TagRepository {
+	return &TagRepository{}
+}
+
+func (r *TagRepository) Create(
+	ctx context.Context,
+	connection domain.Connection,
+	tag domain.Tag,
+) error {
+	const query = `insert into tags (id, user_id, name, name_key) values ($1, $2, $3, $4)`
+
+	if _, err := connection.ExecContext(ctx, query, tag.ID, tag.UserID, tag.Name, domain.TagKey(tag.Name)); err != nil {
+		return errors.Join(ErrCreateTag, classify(err, domain.ErrTagNotFound))
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Read(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+) (domain.Tag, error) {
+	const query = `select ` + tagColumns + ` from tags t where t.id = $1`
+
+	var tag domain.Tag
+	if err := connection.GetContext(ctx, &tag, query, id); err != nil {
+		return domain.Tag{}, errors.Join(ErrReadTag, classify(err, domain.ErrTagNotFound))
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) List(
+	ctx context.Context,
+	connection domain.Connection,
+	userID domain.UserID,
+) ([]domain.Tag, error) {
+	const query = `select ` + tagColumns + ` from tags t
+	where t.user_id = $1
+	order by t.name collate "C", t.id`
+
+	var tags []domain.Tag
+	if err := connection.SelectContext(ctx, &tags, query, userID); err != nil {
+		return nil, errors.Join(ErrListTags, classify(err, domain.ErrTagNotFound))
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) Rename(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+	name string,
+) error {
+	const query = `update tags set name = $2, name_key = $3 where id = $1`
+
+	rowsAffected, err := connection.ExecContext(ctx, query, id, name, domain.TagKey(name))
+	if err != nil {
+		return errors.Join(ErrRenameTag, classify(err, domain.ErrTagNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrRenameTag, domain.ErrTagNotFound)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Delete(
+	ctx context.Context,
+	connection domain.Connection,
+	id domain.TagID,
+) error {
+	const query = `delete from tags where id = $1`
+
+	rowsAffected, err := connection.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Join(ErrDeleteTag, classify(err, domain.ErrTagNotFound))
+	}
+	if rowsAffected == 0 {
+		return errors.Join(ErrDeleteTag, domain.ErrTagNotFound)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Merge(
+	ctx context.Context,
+	connection domain.Connection,
+	from, into domain.TagID,
+) error {
+	const (
+		existQuery = `select count(*) from tags where id = $1 or id = $2`
+		moveQuery  = `insert into subscription_tags (subscription_id, tag_id)
+	select subscription_id, $2 from subscription_tags where tag_id = $1
+	on conflict do nothing`
+		deleteQuery = `delete from tags where id = $1`
+	)
+
+	var found int
+	if err := connection.GetContext(ctx, &found, existQuery, from, into); err != nil {
+		return errors.Join(ErrMergeTags, classify(err, domain.ErrTagNotFound))
+	}
+	if found < 2 {
+		return errors.Join(ErrMergeTags, domain.ErrTagNotFound)
+	}
+
+	if _, err := connection.ExecContext(ctx, moveQuery, from, into); err != nil {
+		return errors.Join(ErrMergeTags, classify(err, domain.ErrTagNotFound))
+	}
+	if _, err := connection.ExecContext(ctx, deleteQuery, from); err != nil {
+		return errors.Join(ErrMergeTags, classify(err, domain.ErrTagNotFound))
+	}
+
+	return nil
+}
+
+// saveTags replaces the tags of the subscription, creating the ones its user
+// does not have yet. Names are matched by domain.TagKey, so an existing tag
+// keeps its spelling.
+func saveTags(
+	ctx context.Context,
+	connection domain.Connection,
+	subscription domain.Subscription,
+) error {
+	const (
+		deleteQuery = `delete from subscription_tags where subscription_id = $1`
+		tagQuery    = `insert into tags (id, user_id, name, name_key) values ($1, $2, $3, $4)
+	on conflict (user_id, name_key) do update set name_key = excluded.name_key
+	returning id`
+		linkQuery = `insert into subscription_tags (subscription_id, tag_id) values ($1, $2)
+	on conflict do nothing`
+	)
+
+	if _, err := connection.ExecContext(ctx, deleteQuery, subscription.ID); err != nil {
+		return classify(err, domain.ErrSubscriptionNotFound)
+	}
+	for _, name := range subscription.Tags {
+		var tagID domain.TagID
+		if err := connection.GetContext(ctx, &tagID, tagQuery, uuid.New(), subscription.UserID, name, domain.TagKey(name)); err != nil {
+			return classify(err, domain.ErrSubscriptionNotFound)
+		}
+		if _, err := connection.ExecContext(ctx, linkQuery, subscription.ID, tagID); err != nil {
+			return classify(err, domain.ErrSubscriptionNotFound)
+		}
+	}
+
+	return nil
+}
+
+// readTags fills in the tag names of the subscriptions.
+func readTags(
+	ctx context.Context,
+	connection domain.Connection,
+	subscriptions []domain.Subscription,
+) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	const query = `select st.subscription_id, t.name
+	from subscription_tags st
+	join tags t on t.id = st.tag_id
+	where st.subscription_id = any($1)
+	order by st.subscription_id, t.name collate "C"`
+
+	ids := make([]domain.SubscriptionID, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+
+	var tags []struct {
+		SubscriptionID domain.SubscriptionID `db:"subscription_id"`
+		Name           string                `db:"name"`
+	}
+	if err := connection.SelectContext(ctx, &tags, query, ids); err != nil {
+		return classify(err, domain.ErrSubscriptionNotFound)
+	}
+
+	bySubscription := make(map[domain.SubscriptionID][]string)
+	for _, tag := range tags {
+		bySubscription[tag.SubscriptionID] = append(bySubscription[tag.SubscriptionID], tag.Name)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Tags = bySubscription[subscriptions[i].ID]
+	}
+
+	return nil
+}